@@ -23,12 +23,76 @@ func (*GenericSQLDialect) IsDelimitedIdentifierStart(r rune) bool {
 	return r == '"' || r == '`'
 }
 
+// IsPlaceHolderStart reports no placeholders, as the generic dialect parses
+// the text for completion, hover and formatting, where @name and :name are
+// identifiers and colons. The dialects of the databases recognize the
+// placeholders when the text is executed.
 func (*GenericSQLDialect) IsPlaceHolderStart(r rune) bool {
-	return r == '$'
+	return false
 }
 
 func (*GenericSQLDialect) IsPlaceHolderPart(r rune) bool {
-	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+	return false
 }
 
 var _ Dialect = &GenericSQLDialect{}
+
+// SQLite3Dialect takes ?, ?1, $1, :name and @name as placeholders.
+type SQLite3Dialect struct {
+	GenericSQLDialect
+}
+
+func (*SQLite3Dialect) IsPlaceHolderStart(r rune) bool {
+	return r == '$' || r == '?' || r == ':' || r == '@'
+}
+
+func (*SQLite3Dialect) IsPlaceHolderPart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_'
+}
+
+var _ Dialect = &SQLite3Dialect{}
+
+// MySQLDialect only takes ? as a placeholder, @name being a user variable.
+type MySQLDialect struct {
+	GenericSQLDialect
+}
+
+func (*MySQLDialect) IsPlaceHolderStart(r rune) bool {
+	return r == '?'
+}
+
+func (*MySQLDialect) IsPlaceHolderPart(r rune) bool {
+	return false
+}
+
+var _ Dialect = &MySQLDialect{}
+
+// PostgreSQLDialect only takes $1, $2 and so on as placeholders, ? being an
+// operator of jsonb.
+type PostgreSQLDialect struct {
+	GenericSQLDialect
+}
+
+func (*PostgreSQLDialect) IsPlaceHolderStart(r rune) bool {
+	return r == '$'
+}
+
+func (*PostgreSQLDialect) IsPlaceHolderPart(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+var _ Dialect = &PostgreSQLDialect{}
+
+// DatabaseDialect returns the dialect recognizing the placeholders of the
+// driver when executing a text. The SQLite3 dialect, taking all the styles of
+// placeholders, is used for unknown drivers as well.
+func DatabaseDialect(driver DatabaseDriver) Dialect {
+	switch driver {
+	case DatabaseDriverMySQL, DatabaseDriverMySQL8, DatabaseDriverMySQL57, DatabaseDriverMySQL56:
+		return &MySQLDialect{}
+	case DatabaseDriverPostgreSQL:
+		return &PostgreSQLDialect{}
+	default:
+		return &SQLite3Dialect{}
+	}
+}
@@ -0,0 +1,65 @@
+package dialect
+
+import "strings"
+
+// FunctionDesc is a built-in function of a database. Optional arguments are
+// enclosed in brackets, and "..." stands for more of the arguments before it.
+// Overloaded functions have an entry per signature.
+type FunctionDesc struct {
+	Name        string
+	Arguments   []string
+	ReturnType  string
+	Description string
+}
+
+// Signature returns the declaration of the function, such as
+// "LEFT(string text, n integer) RETURNS text".
+func (fd *FunctionDesc) Signature() string {
+	sig := fd.Name + "(" + strings.Join(fd.Arguments, ", ") + ")"
+	if fd.ReturnType != "" {
+		sig += " RETURNS " + fd.ReturnType
+	}
+	return sig
+}
+
+// DataBaseFunctionCatalog returns the built-in functions of the driver with
+// their signatures.
+func DataBaseFunctionCatalog(driver DatabaseDriver) []*FunctionDesc {
+	switch driver {
+	case DatabaseDriverMySQL, DatabaseDriverMySQL8, DatabaseDriverMySQL57, DatabaseDriverMySQL56:
+		return mysqlFunctions
+	case DatabaseDriverPostgreSQL:
+		return postgresqlFunctions
+	case DatabaseDriverSQLite3:
+		return sqliteFunctions
+	default:
+		return []*FunctionDesc{}
+	}
+}
+
+// LookupFunction returns the signatures of the built-in function with the
+// name, ignoring case.
+func LookupFunction(driver DatabaseDriver, name string) []*FunctionDesc {
+	funcs := []*FunctionDesc{}
+	for _, fn := range DataBaseFunctionCatalog(driver) {
+		if strings.EqualFold(fn.Name, name) {
+			funcs = append(funcs, fn)
+		}
+	}
+	return funcs
+}
+
+// functionNames returns the names of the functions without the duplicates of
+// overloads.
+func functionNames(funcs []*FunctionDesc) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, fn := range funcs {
+		if seen[fn.Name] {
+			continue
+		}
+		seen[fn.Name] = true
+		names = append(names, fn.Name)
+	}
+	return names
+}
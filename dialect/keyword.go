@@ -417,9 +417,9 @@ func DataBaseFunctions(driver DatabaseDriver) []string {
 	case DatabaseDriverMySQL56:
 		return mysql56Function
 	case DatabaseDriverPostgreSQL:
-		return []string{}
+		return functionNames(postgresqlFunctions)
 	case DatabaseDriverSQLite3:
-		return []string{}
+		return functionNames(sqliteFunctions)
 	default:
 		return []string{}
 	}
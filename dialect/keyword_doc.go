@@ -0,0 +1,38 @@
+package dialect
+
+import "strings"
+
+// KeywordDesc is the documentation of a keyword or a clause of a database,
+// such as "GROUP BY" or "ON CONFLICT". The name of a clause is its keywords
+// separated by a space.
+type KeywordDesc struct {
+	Name        string
+	Syntax      string
+	Description string
+}
+
+// DataBaseKeywordDocs returns the documentation of the keywords of the driver.
+func DataBaseKeywordDocs(driver DatabaseDriver) []*KeywordDesc {
+	switch driver {
+	case DatabaseDriverMySQL, DatabaseDriverMySQL8, DatabaseDriverMySQL57, DatabaseDriverMySQL56:
+		return mysqlKeywordDocs
+	case DatabaseDriverPostgreSQL:
+		return postgresqlKeywordDocs
+	case DatabaseDriverSQLite3:
+		return sqliteKeywordDocs
+	default:
+		return sqliteKeywordDocs
+	}
+}
+
+// LookupKeyword returns the documentation of the keyword or the clause,
+// ignoring case and the spaces between the keywords.
+func LookupKeyword(driver DatabaseDriver, name string) (*KeywordDesc, bool) {
+	name = strings.Join(strings.Fields(name), " ")
+	for _, kw := range DataBaseKeywordDocs(driver) {
+		if strings.EqualFold(kw.Name, name) {
+			return kw, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,1366 @@
+package dialect
+
+var mysqlFunctions = []*FunctionDesc{
+	{
+		Name:        "ABS",
+		Arguments:   []string{"X"},
+		ReturnType:  "numeric",
+		Description: "Absolute value of X.",
+	},
+	{
+		Name:        "ACOS",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Arc cosine of X, in radians.",
+	},
+	{
+		Name:        "ASIN",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Arc sine of X, in radians.",
+	},
+	{
+		Name:        "ATAN",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Arc tangent of X, in radians.",
+	},
+	{
+		Name:        "ATAN2",
+		Arguments:   []string{"Y", "X"},
+		ReturnType:  "double",
+		Description: "Arc tangent of Y/X, in radians.",
+	},
+	{
+		Name:        "CEIL",
+		Arguments:   []string{"X"},
+		ReturnType:  "integer",
+		Description: "Smallest integer value not less than X.",
+	},
+	{
+		Name:        "CEILING",
+		Arguments:   []string{"X"},
+		ReturnType:  "integer",
+		Description: "Smallest integer value not less than X.",
+	},
+	{
+		Name:        "CONV",
+		Arguments:   []string{"N", "from_base", "to_base"},
+		ReturnType:  "varchar",
+		Description: "Converts the number N from one numeric base to another.",
+	},
+	{
+		Name:        "COS",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Cosine of X, in radians.",
+	},
+	{
+		Name:        "COT",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Cotangent of X.",
+	},
+	{
+		Name:        "CRC32",
+		Arguments:   []string{"expr"},
+		ReturnType:  "bigint",
+		Description: "Cyclic redundancy check value of the argument.",
+	},
+	{
+		Name:        "DEGREES",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Converts radians to degrees.",
+	},
+	{
+		Name:        "EXP",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "e raised to the power of X.",
+	},
+	{
+		Name:        "FLOOR",
+		Arguments:   []string{"X"},
+		ReturnType:  "integer",
+		Description: "Largest integer value not greater than X.",
+	},
+	{
+		Name:        "LN",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Natural logarithm of X.",
+	},
+	{
+		Name:        "LOG",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Natural logarithm of X.",
+	},
+	{
+		Name:        "LOG",
+		Arguments:   []string{"B", "X"},
+		ReturnType:  "double",
+		Description: "Logarithm of X to the base B.",
+	},
+	{
+		Name:        "LOG10",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Base-10 logarithm of X.",
+	},
+	{
+		Name:        "LOG2",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Base-2 logarithm of X.",
+	},
+	{
+		Name:        "MOD",
+		Arguments:   []string{"N", "M"},
+		ReturnType:  "numeric",
+		Description: "Remainder of N divided by M.",
+	},
+	{
+		Name:        "PI",
+		ReturnType:  "double",
+		Description: "Value of π.",
+	},
+	{
+		Name:        "POW",
+		Arguments:   []string{"X", "Y"},
+		ReturnType:  "double",
+		Description: "X raised to the power of Y.",
+	},
+	{
+		Name:        "POWER",
+		Arguments:   []string{"X", "Y"},
+		ReturnType:  "double",
+		Description: "X raised to the power of Y.",
+	},
+	{
+		Name:        "RADIANS",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Converts degrees to radians.",
+	},
+	{
+		Name:        "RAND",
+		Arguments:   []string{"[N]"},
+		ReturnType:  "double",
+		Description: "Random floating-point value in the range 0 <= v < 1.0, seeded by N if given.",
+	},
+	{
+		Name:        "ROUND",
+		Arguments:   []string{"X", "[D]"},
+		ReturnType:  "numeric",
+		Description: "Rounds X to D decimal places, 0 by default.",
+	},
+	{
+		Name:        "SIGN",
+		Arguments:   []string{"X"},
+		ReturnType:  "integer",
+		Description: "-1, 0 or 1, depending on whether X is negative, zero or positive.",
+	},
+	{
+		Name:        "SIN",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Sine of X, in radians.",
+	},
+	{
+		Name:        "SQRT",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Square root of X.",
+	},
+	{
+		Name:        "TAN",
+		Arguments:   []string{"X"},
+		ReturnType:  "double",
+		Description: "Tangent of X, in radians.",
+	},
+	{
+		Name:        "TRUNCATE",
+		Arguments:   []string{"X", "D"},
+		ReturnType:  "numeric",
+		Description: "X truncated to D decimal places.",
+	},
+	{
+		Name:        "ASCII",
+		Arguments:   []string{"str"},
+		ReturnType:  "integer",
+		Description: "Numeric value of the leftmost character of str.",
+	},
+	{
+		Name:        "BIN",
+		Arguments:   []string{"N"},
+		ReturnType:  "varchar",
+		Description: "Binary representation of N.",
+	},
+	{
+		Name:        "BIT_LENGTH",
+		Arguments:   []string{"str"},
+		ReturnType:  "integer",
+		Description: "Length of str in bits.",
+	},
+	{
+		Name:        "CHAR_LENGTH",
+		Arguments:   []string{"str"},
+		ReturnType:  "integer",
+		Description: "Number of characters in str.",
+	},
+	{
+		Name:        "CHARACTER_LENGTH",
+		Arguments:   []string{"str"},
+		ReturnType:  "integer",
+		Description: "Number of characters in str.",
+	},
+	{
+		Name:        "CONCAT",
+		Arguments:   []string{"str1", "str2", "..."},
+		ReturnType:  "varchar",
+		Description: "Concatenation of the arguments, or NULL if any argument is NULL.",
+	},
+	{
+		Name:        "CONCAT_WS",
+		Arguments:   []string{"separator", "str1", "str2", "..."},
+		ReturnType:  "varchar",
+		Description: "Concatenation of the arguments with the separator, skipping NULL values.",
+	},
+	{
+		Name:        "ELT",
+		Arguments:   []string{"N", "str1", "str2", "..."},
+		ReturnType:  "varchar",
+		Description: "N-th string of the list.",
+	},
+	{
+		Name:        "EXPORT_SET",
+		Arguments:   []string{"bits", "on", "off", "[separator]", "[number_of_bits]"},
+		ReturnType:  "varchar",
+		Description: "String of on and off strings for the bits set and not set in bits.",
+	},
+	{
+		Name:        "FIELD",
+		Arguments:   []string{"str", "str1", "str2", "..."},
+		ReturnType:  "integer",
+		Description: "Index of str in the list str1, str2, ..., or 0 if not found.",
+	},
+	{
+		Name:        "FIND_IN_SET",
+		Arguments:   []string{"str", "strlist"},
+		ReturnType:  "integer",
+		Description: "Index of str in the comma-separated list strlist, or 0 if not found.",
+	},
+	{
+		Name:        "FORMAT",
+		Arguments:   []string{"X", "D", "[locale]"},
+		ReturnType:  "varchar",
+		Description: "Formats X like '#,###,###.##', rounded to D decimal places.",
+	},
+	{
+		Name:        "FROM_BASE64",
+		Arguments:   []string{"str"},
+		ReturnType:  "blob",
+		Description: "Decodes the base-64 encoded string.",
+	},
+	{
+		Name:        "HEX",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "Hexadecimal representation of str or N.",
+	},
+	{
+		Name:        "INSERT",
+		Arguments:   []string{"str", "pos", "len", "newstr"},
+		ReturnType:  "varchar",
+		Description: "str with the substring beginning at pos and len characters long replaced by newstr.",
+	},
+	{
+		Name:        "INSTR",
+		Arguments:   []string{"str", "substr"},
+		ReturnType:  "integer",
+		Description: "Position of the first occurrence of substr in str.",
+	},
+	{
+		Name:        "LCASE",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "Synonym for LOWER().",
+	},
+	{
+		Name:        "LEFT",
+		Arguments:   []string{"str", "len"},
+		ReturnType:  "varchar",
+		Description: "Leftmost len characters from str.",
+	},
+	{
+		Name:        "LENGTH",
+		Arguments:   []string{"str"},
+		ReturnType:  "integer",
+		Description: "Length of str in bytes.",
+	},
+	{
+		Name:        "LOCATE",
+		Arguments:   []string{"substr", "str", "[pos]"},
+		ReturnType:  "integer",
+		Description: "Position of the first occurrence of substr in str, starting at pos.",
+	},
+	{
+		Name:        "LOWER",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "str with all characters changed to lowercase.",
+	},
+	{
+		Name:        "LPAD",
+		Arguments:   []string{"str", "len", "padstr"},
+		ReturnType:  "varchar",
+		Description: "str left-padded with padstr to a length of len characters.",
+	},
+	{
+		Name:        "LTRIM",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "str with leading space characters removed.",
+	},
+	{
+		Name:        "MAKE_SET",
+		Arguments:   []string{"bits", "str1", "str2", "..."},
+		ReturnType:  "varchar",
+		Description: "Comma-separated set of the strings that have the corresponding bit in bits set.",
+	},
+	{
+		Name:        "MID",
+		Arguments:   []string{"str", "pos", "len"},
+		ReturnType:  "varchar",
+		Description: "Synonym for SUBSTRING(str, pos, len).",
+	},
+	{
+		Name:        "OCT",
+		Arguments:   []string{"N"},
+		ReturnType:  "varchar",
+		Description: "Octal representation of N.",
+	},
+	{
+		Name:        "OCTET_LENGTH",
+		Arguments:   []string{"str"},
+		ReturnType:  "integer",
+		Description: "Synonym for LENGTH().",
+	},
+	{
+		Name:        "ORD",
+		Arguments:   []string{"str"},
+		ReturnType:  "integer",
+		Description: "Code of the leftmost character of str, taking multibyte characters into account.",
+	},
+	{
+		Name:        "POSITION",
+		Arguments:   []string{"substr IN str"},
+		ReturnType:  "integer",
+		Description: "Synonym for LOCATE(substr, str).",
+	},
+	{
+		Name:        "QUOTE",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "Quotes str to produce a properly escaped string literal.",
+	},
+	{
+		Name:        "REGEXP_INSTR",
+		Arguments:   []string{"expr", "pat", "[pos]", "[occurrence]", "[return_option]", "[match_type]"},
+		ReturnType:  "integer",
+		Description: "Starting index of the substring of expr matching the regular expression pat. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "REGEXP_LIKE",
+		Arguments:   []string{"expr", "pat", "[match_type]"},
+		ReturnType:  "integer",
+		Description: "1 if expr matches the regular expression pat, 0 otherwise. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "REGEXP_REPLACE",
+		Arguments:   []string{"expr", "pat", "repl", "[pos]", "[occurrence]", "[match_type]"},
+		ReturnType:  "varchar",
+		Description: "Replaces occurrences in expr that match the regular expression pat with repl. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "REGEXP_SUBSTR",
+		Arguments:   []string{"expr", "pat", "[pos]", "[occurrence]", "[match_type]"},
+		ReturnType:  "varchar",
+		Description: "Substring of expr that matches the regular expression pat. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "REPEAT",
+		Arguments:   []string{"str", "count"},
+		ReturnType:  "varchar",
+		Description: "str repeated count times.",
+	},
+	{
+		Name:        "REPLACE",
+		Arguments:   []string{"str", "from_str", "to_str"},
+		ReturnType:  "varchar",
+		Description: "str with all occurrences of from_str replaced by to_str.",
+	},
+	{
+		Name:        "REVERSE",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "str with the order of the characters reversed.",
+	},
+	{
+		Name:        "RIGHT",
+		Arguments:   []string{"str", "len"},
+		ReturnType:  "varchar",
+		Description: "Rightmost len characters from str.",
+	},
+	{
+		Name:        "RPAD",
+		Arguments:   []string{"str", "len", "padstr"},
+		ReturnType:  "varchar",
+		Description: "str right-padded with padstr to a length of len characters.",
+	},
+	{
+		Name:        "RTRIM",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "str with trailing space characters removed.",
+	},
+	{
+		Name:        "SOUNDEX",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "Soundex string from str.",
+	},
+	{
+		Name:        "SPACE",
+		Arguments:   []string{"N"},
+		ReturnType:  "varchar",
+		Description: "String consisting of N space characters.",
+	},
+	{
+		Name:        "STRCMP",
+		Arguments:   []string{"expr1", "expr2"},
+		ReturnType:  "integer",
+		Description: "0 if the strings are the same, -1 if the first is smaller, 1 otherwise.",
+	},
+	{
+		Name:        "SUBSTR",
+		Arguments:   []string{"str", "pos", "[len]"},
+		ReturnType:  "varchar",
+		Description: "Substring of str starting at pos, len characters long if given.",
+	},
+	{
+		Name:        "SUBSTRING",
+		Arguments:   []string{"str", "pos", "[len]"},
+		ReturnType:  "varchar",
+		Description: "Substring of str starting at pos, len characters long if given.",
+	},
+	{
+		Name:        "SUBSTRING_INDEX",
+		Arguments:   []string{"str", "delim", "count"},
+		ReturnType:  "varchar",
+		Description: "Substring of str before count occurrences of delim, counting from the right if count is negative.",
+	},
+	{
+		Name:        "TO_BASE64",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "str encoded in base-64.",
+	},
+	{
+		Name:        "TRIM",
+		Arguments:   []string{"[{BOTH "},
+		ReturnType:  " LEADING ",
+		Description: " TRAILING} [remstr] FROM] str|varchar|str with the remstr prefixes or suffixes, spaces by default, removed.",
+	},
+	{
+		Name:        "UCASE",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "Synonym for UPPER().",
+	},
+	{
+		Name:        "UNHEX",
+		Arguments:   []string{"str"},
+		ReturnType:  "varbinary",
+		Description: "Interprets each pair of characters in str as a hexadecimal number and converts it to the byte it represents.",
+	},
+	{
+		Name:        "UPPER",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "str with all characters changed to uppercase.",
+	},
+	{
+		Name:        "ADDDATE",
+		Arguments:   []string{"date", "INTERVAL expr unit"},
+		ReturnType:  "date",
+		Description: "Adds the interval to the date. Synonym for DATE_ADD().",
+	},
+	{
+		Name:        "ADDTIME",
+		Arguments:   []string{"expr1", "expr2"},
+		ReturnType:  "time",
+		Description: "Adds the time expr2 to expr1.",
+	},
+	{
+		Name:        "CONVERT_TZ",
+		Arguments:   []string{"dt", "from_tz", "to_tz"},
+		ReturnType:  "datetime",
+		Description: "Converts the datetime dt from the time zone from_tz to to_tz.",
+	},
+	{
+		Name:        "CURDATE",
+		ReturnType:  "date",
+		Description: "Current date as 'YYYY-MM-DD' or YYYYMMDD.",
+	},
+	{
+		Name:        "CURTIME",
+		Arguments:   []string{"[fsp]"},
+		ReturnType:  "time",
+		Description: "Current time as 'hh:mm:ss' or hhmmss.",
+	},
+	{
+		Name:        "DATE",
+		Arguments:   []string{"expr"},
+		ReturnType:  "date",
+		Description: "Date part of the date or datetime expression.",
+	},
+	{
+		Name:        "DATE_ADD",
+		Arguments:   []string{"date", "INTERVAL expr unit"},
+		ReturnType:  "date",
+		Description: "Adds the interval to the date.",
+	},
+	{
+		Name:        "DATE_FORMAT",
+		Arguments:   []string{"date", "format"},
+		ReturnType:  "varchar",
+		Description: "Formats the date according to the format string, such as '%Y-%m-%d %H:%i:%s'.",
+	},
+	{
+		Name:        "DATE_SUB",
+		Arguments:   []string{"date", "INTERVAL expr unit"},
+		ReturnType:  "date",
+		Description: "Subtracts the interval from the date.",
+	},
+	{
+		Name:        "DATEDIFF",
+		Arguments:   []string{"expr1", "expr2"},
+		ReturnType:  "integer",
+		Description: "expr1 - expr2 expressed as a number of days.",
+	},
+	{
+		Name:        "DAY",
+		Arguments:   []string{"date"},
+		ReturnType:  "integer",
+		Description: "Synonym for DAYOFMONTH().",
+	},
+	{
+		Name:        "DAYNAME",
+		Arguments:   []string{"date"},
+		ReturnType:  "varchar",
+		Description: "Name of the weekday of the date.",
+	},
+	{
+		Name:        "DAYOFMONTH",
+		Arguments:   []string{"date"},
+		ReturnType:  "integer",
+		Description: "Day of the month, in the range 1 to 31.",
+	},
+	{
+		Name:        "DAYOFWEEK",
+		Arguments:   []string{"date"},
+		ReturnType:  "integer",
+		Description: "Weekday index, 1 for Sunday to 7 for Saturday.",
+	},
+	{
+		Name:        "DAYOFYEAR",
+		Arguments:   []string{"date"},
+		ReturnType:  "integer",
+		Description: "Day of the year, in the range 1 to 366.",
+	},
+	{
+		Name:        "EXTRACT",
+		Arguments:   []string{"unit FROM date"},
+		ReturnType:  "integer",
+		Description: "Part of the date, such as YEAR or MINUTE.",
+	},
+	{
+		Name:        "FROM_DAYS",
+		Arguments:   []string{"N"},
+		ReturnType:  "date",
+		Description: "Date for the day number N.",
+	},
+	{
+		Name:        "FROM_UNIXTIME",
+		Arguments:   []string{"unix_timestamp", "[format]"},
+		ReturnType:  "datetime",
+		Description: "Unix timestamp as a datetime, formatted if format is given.",
+	},
+	{
+		Name:        "GET_FORMAT",
+		Arguments:   []string{"{DATE "},
+		ReturnType:  " TIME ",
+		Description: " DATETIME};{'EUR' | 'USA' | 'JIS' | 'ISO' | 'INTERNAL'}|varchar|Format string for DATE_FORMAT() and STR_TO_DATE().",
+	},
+	{
+		Name:        "HOUR",
+		Arguments:   []string{"time"},
+		ReturnType:  "integer",
+		Description: "Hour of the time.",
+	},
+	{
+		Name:        "LAST_DAY",
+		Arguments:   []string{"date"},
+		ReturnType:  "date",
+		Description: "Last day of the month of the date.",
+	},
+	{
+		Name:        "MAKEDATE",
+		Arguments:   []string{"year", "dayofyear"},
+		ReturnType:  "date",
+		Description: "Date from the year and the day of the year.",
+	},
+	{
+		Name:        "MAKETIME",
+		Arguments:   []string{"hour", "minute", "second"},
+		ReturnType:  "time",
+		Description: "Time from the hour, minute and second.",
+	},
+	{
+		Name:        "MICROSECOND",
+		Arguments:   []string{"expr"},
+		ReturnType:  "integer",
+		Description: "Microseconds of the time or datetime.",
+	},
+	{
+		Name:        "MINUTE",
+		Arguments:   []string{"time"},
+		ReturnType:  "integer",
+		Description: "Minute of the time.",
+	},
+	{
+		Name:        "MONTH",
+		Arguments:   []string{"date"},
+		ReturnType:  "integer",
+		Description: "Month of the date, in the range 1 to 12.",
+	},
+	{
+		Name:        "MONTHNAME",
+		Arguments:   []string{"date"},
+		ReturnType:  "varchar",
+		Description: "Name of the month of the date.",
+	},
+	{
+		Name:        "NOW",
+		Arguments:   []string{"[fsp]"},
+		ReturnType:  "datetime",
+		Description: "Current date and time as 'YYYY-MM-DD hh:mm:ss'.",
+	},
+	{
+		Name:        "PERIOD_ADD",
+		Arguments:   []string{"P", "N"},
+		ReturnType:  "integer",
+		Description: "Adds N months to the period P in the format YYMM or YYYYMM.",
+	},
+	{
+		Name:        "PERIOD_DIFF",
+		Arguments:   []string{"P1", "P2"},
+		ReturnType:  "integer",
+		Description: "Number of months between the periods P1 and P2.",
+	},
+	{
+		Name:        "QUARTER",
+		Arguments:   []string{"date"},
+		ReturnType:  "integer",
+		Description: "Quarter of the year of the date, in the range 1 to 4.",
+	},
+	{
+		Name:        "SEC_TO_TIME",
+		Arguments:   []string{"seconds"},
+		ReturnType:  "time",
+		Description: "Seconds converted to hours, minutes and seconds.",
+	},
+	{
+		Name:        "SECOND",
+		Arguments:   []string{"time"},
+		ReturnType:  "integer",
+		Description: "Second of the time.",
+	},
+	{
+		Name:        "STR_TO_DATE",
+		Arguments:   []string{"str", "format"},
+		ReturnType:  "datetime",
+		Description: "Parses str into a date or time value according to the format string.",
+	},
+	{
+		Name:        "SUBDATE",
+		Arguments:   []string{"date", "INTERVAL expr unit"},
+		ReturnType:  "date",
+		Description: "Subtracts the interval from the date. Synonym for DATE_SUB().",
+	},
+	{
+		Name:        "SUBTIME",
+		Arguments:   []string{"expr1", "expr2"},
+		ReturnType:  "time",
+		Description: "Subtracts the time expr2 from expr1.",
+	},
+	{
+		Name:        "SYSDATE",
+		Arguments:   []string{"[fsp]"},
+		ReturnType:  "datetime",
+		Description: "Time at which the function executes.",
+	},
+	{
+		Name:        "TIME",
+		Arguments:   []string{"expr"},
+		ReturnType:  "time",
+		Description: "Time part of the time or datetime expression.",
+	},
+	{
+		Name:        "TIME_FORMAT",
+		Arguments:   []string{"time", "format"},
+		ReturnType:  "varchar",
+		Description: "Formats the time according to the format string.",
+	},
+	{
+		Name:        "TIME_TO_SEC",
+		Arguments:   []string{"time"},
+		ReturnType:  "integer",
+		Description: "Time converted to seconds.",
+	},
+	{
+		Name:        "TIMEDIFF",
+		Arguments:   []string{"expr1", "expr2"},
+		ReturnType:  "time",
+		Description: "expr1 - expr2 expressed as a time value.",
+	},
+	{
+		Name:        "TIMESTAMP",
+		Arguments:   []string{"expr", "[expr2]"},
+		ReturnType:  "datetime",
+		Description: "Datetime of the expression, with the time expr2 added if given.",
+	},
+	{
+		Name:        "TIMESTAMPADD",
+		Arguments:   []string{"unit", "interval", "datetime_expr"},
+		ReturnType:  "datetime",
+		Description: "Adds the integer interval of unit to the datetime expression.",
+	},
+	{
+		Name:        "TIMESTAMPDIFF",
+		Arguments:   []string{"unit", "datetime_expr1", "datetime_expr2"},
+		ReturnType:  "integer",
+		Description: "datetime_expr2 - datetime_expr1 expressed in unit.",
+	},
+	{
+		Name:        "TO_DAYS",
+		Arguments:   []string{"date"},
+		ReturnType:  "integer",
+		Description: "Day number of the date, the number of days since year 0.",
+	},
+	{
+		Name:        "TO_SECONDS",
+		Arguments:   []string{"expr"},
+		ReturnType:  "integer",
+		Description: "Number of seconds since year 0.",
+	},
+	{
+		Name:        "UNIX_TIMESTAMP",
+		Arguments:   []string{"[date]"},
+		ReturnType:  "integer",
+		Description: "Seconds since '1970-01-01 00:00:00' UTC of the date, or of now.",
+	},
+	{
+		Name:        "UTC_DATE",
+		ReturnType:  "date",
+		Description: "Current UTC date.",
+	},
+	{
+		Name:        "UTC_TIME",
+		Arguments:   []string{"[fsp]"},
+		ReturnType:  "time",
+		Description: "Current UTC time.",
+	},
+	{
+		Name:        "UTC_TIMESTAMP",
+		Arguments:   []string{"[fsp]"},
+		ReturnType:  "datetime",
+		Description: "Current UTC date and time.",
+	},
+	{
+		Name:        "WEEK",
+		Arguments:   []string{"date", "[mode]"},
+		ReturnType:  "integer",
+		Description: "Week number of the date.",
+	},
+	{
+		Name:        "WEEKDAY",
+		Arguments:   []string{"date"},
+		ReturnType:  "integer",
+		Description: "Weekday index, 0 for Monday to 6 for Sunday.",
+	},
+	{
+		Name:        "WEEKOFYEAR",
+		Arguments:   []string{"date"},
+		ReturnType:  "integer",
+		Description: "Calendar week of the date, in the range 1 to 53.",
+	},
+	{
+		Name:        "YEAR",
+		Arguments:   []string{"date"},
+		ReturnType:  "integer",
+		Description: "Year of the date.",
+	},
+	{
+		Name:        "YEARWEEK",
+		Arguments:   []string{"date", "[mode]"},
+		ReturnType:  "integer",
+		Description: "Year and week of the date.",
+	},
+	{
+		Name:        "AVG",
+		Arguments:   []string{"[DISTINCT] expr"},
+		ReturnType:  "double",
+		Description: "Average value of expr.",
+	},
+	{
+		Name:        "BIT_AND",
+		Arguments:   []string{"expr"},
+		ReturnType:  "bigint",
+		Description: "Bitwise AND of all bits in expr.",
+	},
+	{
+		Name:        "BIT_OR",
+		Arguments:   []string{"expr"},
+		ReturnType:  "bigint",
+		Description: "Bitwise OR of all bits in expr.",
+	},
+	{
+		Name:        "BIT_XOR",
+		Arguments:   []string{"expr"},
+		ReturnType:  "bigint",
+		Description: "Bitwise XOR of all bits in expr.",
+	},
+	{
+		Name:        "COUNT",
+		Arguments:   []string{"*"},
+		ReturnType:  "bigint",
+		Description: "Number of rows retrieved.",
+	},
+	{
+		Name:        "COUNT",
+		Arguments:   []string{"[DISTINCT] expr"},
+		ReturnType:  "bigint",
+		Description: "Number of non-NULL values of expr in the rows retrieved.",
+	},
+	{
+		Name:        "GROUP_CONCAT",
+		Arguments:   []string{"[DISTINCT] expr [ORDER BY ...] [SEPARATOR str_val]"},
+		ReturnType:  "text",
+		Description: "Concatenation of the non-NULL values from a group, separated by commas by default.",
+	},
+	{
+		Name:        "JSON_ARRAYAGG",
+		Arguments:   []string{"col_or_expr"},
+		ReturnType:  "json",
+		Description: "Aggregates the values into a JSON array. MySQL 5.7.22 and later.",
+	},
+	{
+		Name:        "JSON_OBJECTAGG",
+		Arguments:   []string{"key", "value"},
+		ReturnType:  "json",
+		Description: "Aggregates the key/value pairs into a JSON object. MySQL 5.7.22 and later.",
+	},
+	{
+		Name:        "MAX",
+		Arguments:   []string{"[DISTINCT] expr"},
+		ReturnType:  "any",
+		Description: "Maximum value of expr.",
+	},
+	{
+		Name:        "MIN",
+		Arguments:   []string{"[DISTINCT] expr"},
+		ReturnType:  "any",
+		Description: "Minimum value of expr.",
+	},
+	{
+		Name:        "STD",
+		Arguments:   []string{"expr"},
+		ReturnType:  "double",
+		Description: "Population standard deviation of expr.",
+	},
+	{
+		Name:        "STDDEV",
+		Arguments:   []string{"expr"},
+		ReturnType:  "double",
+		Description: "Population standard deviation of expr.",
+	},
+	{
+		Name:        "STDDEV_POP",
+		Arguments:   []string{"expr"},
+		ReturnType:  "double",
+		Description: "Population standard deviation of expr.",
+	},
+	{
+		Name:        "STDDEV_SAMP",
+		Arguments:   []string{"expr"},
+		ReturnType:  "double",
+		Description: "Sample standard deviation of expr.",
+	},
+	{
+		Name:        "SUM",
+		Arguments:   []string{"[DISTINCT] expr"},
+		ReturnType:  "numeric",
+		Description: "Sum of expr.",
+	},
+	{
+		Name:        "VAR_POP",
+		Arguments:   []string{"expr"},
+		ReturnType:  "double",
+		Description: "Population standard variance of expr.",
+	},
+	{
+		Name:        "VAR_SAMP",
+		Arguments:   []string{"expr"},
+		ReturnType:  "double",
+		Description: "Sample variance of expr.",
+	},
+	{
+		Name:        "VARIANCE",
+		Arguments:   []string{"expr"},
+		ReturnType:  "double",
+		Description: "Population standard variance of expr.",
+	},
+	{
+		Name:        "CUME_DIST",
+		ReturnType:  "double",
+		Description: "Cumulative distribution of the value within its partition. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "DENSE_RANK",
+		ReturnType:  "bigint",
+		Description: "Rank of the current row within its partition, without gaps. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "FIRST_VALUE",
+		Arguments:   []string{"expr"},
+		ReturnType:  "any",
+		Description: "Value of expr from the first row of the window frame. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "LAG",
+		Arguments:   []string{"expr", "[N]", "[default]"},
+		ReturnType:  "any",
+		Description: "Value of expr from the row lagging the current row by N rows within its partition. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "LAST_VALUE",
+		Arguments:   []string{"expr"},
+		ReturnType:  "any",
+		Description: "Value of expr from the last row of the window frame. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "LEAD",
+		Arguments:   []string{"expr", "[N]", "[default]"},
+		ReturnType:  "any",
+		Description: "Value of expr from the row leading the current row by N rows within its partition. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "NTH_VALUE",
+		Arguments:   []string{"expr", "N"},
+		ReturnType:  "any",
+		Description: "Value of expr from the N-th row of the window frame. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "NTILE",
+		Arguments:   []string{"N"},
+		ReturnType:  "bigint",
+		Description: "Number of the bucket of the current row within its partition divided into N buckets. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "PERCENT_RANK",
+		ReturnType:  "double",
+		Description: "Percentage of partition values less than the value in the current row. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "RANK",
+		ReturnType:  "bigint",
+		Description: "Rank of the current row within its partition, with gaps. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "ROW_NUMBER",
+		ReturnType:  "bigint",
+		Description: "Number of the current row within its partition. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "COALESCE",
+		Arguments:   []string{"value", "..."},
+		ReturnType:  "any",
+		Description: "First non-NULL value in the list, or NULL if there are none.",
+	},
+	{
+		Name:        "GREATEST",
+		Arguments:   []string{"value1", "value2", "..."},
+		ReturnType:  "any",
+		Description: "Largest argument.",
+	},
+	{
+		Name:        "IF",
+		Arguments:   []string{"expr1", "expr2", "expr3"},
+		ReturnType:  "any",
+		Description: "expr2 if expr1 is true, otherwise expr3.",
+	},
+	{
+		Name:        "IFNULL",
+		Arguments:   []string{"expr1", "expr2"},
+		ReturnType:  "any",
+		Description: "expr1 if it is not NULL, otherwise expr2.",
+	},
+	{
+		Name:        "INTERVAL",
+		Arguments:   []string{"N", "N1", "N2", "..."},
+		ReturnType:  "integer",
+		Description: "0 if N < N1, 1 if N < N2 and so on.",
+	},
+	{
+		Name:        "ISNULL",
+		Arguments:   []string{"expr"},
+		ReturnType:  "integer",
+		Description: "1 if expr is NULL, otherwise 0.",
+	},
+	{
+		Name:        "LEAST",
+		Arguments:   []string{"value1", "value2", "..."},
+		ReturnType:  "any",
+		Description: "Smallest argument.",
+	},
+	{
+		Name:        "NULLIF",
+		Arguments:   []string{"expr1", "expr2"},
+		ReturnType:  "any",
+		Description: "NULL if expr1 = expr2 is true, otherwise expr1.",
+	},
+	{
+		Name:        "CAST",
+		Arguments:   []string{"expr AS type"},
+		ReturnType:  "any",
+		Description: "Converts expr to the type, such as CHAR, DATE, DECIMAL(M,D) or SIGNED.",
+	},
+	{
+		Name:        "CONVERT",
+		Arguments:   []string{"expr", "type"},
+		ReturnType:  "any",
+		Description: "Converts expr to the type, or with CONVERT(expr USING transcoding_name) to the character set.",
+	},
+	{
+		Name:        "JSON_ARRAY",
+		Arguments:   []string{"[val]", "..."},
+		ReturnType:  "json",
+		Description: "JSON array of the values. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_ARRAY_APPEND",
+		Arguments:   []string{"json_doc", "path", "val", "..."},
+		ReturnType:  "json",
+		Description: "Appends the values to the end of the arrays at the paths. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_ARRAY_INSERT",
+		Arguments:   []string{"json_doc", "path", "val", "..."},
+		ReturnType:  "json",
+		Description: "Inserts the values into the arrays at the paths. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_CONTAINS",
+		Arguments:   []string{"target", "candidate", "[path]"},
+		ReturnType:  "integer",
+		Description: "1 if candidate is contained in target, at path if given. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_CONTAINS_PATH",
+		Arguments:   []string{"json_doc", "one_or_all", "path", "..."},
+		ReturnType:  "integer",
+		Description: "1 if the document contains data at one or all of the paths. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_DEPTH",
+		Arguments:   []string{"json_doc"},
+		ReturnType:  "integer",
+		Description: "Maximum depth of the JSON document. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_EXTRACT",
+		Arguments:   []string{"json_doc", "path", "..."},
+		ReturnType:  "json",
+		Description: "Data from the JSON document selected by the paths. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_INSERT",
+		Arguments:   []string{"json_doc", "path", "val", "..."},
+		ReturnType:  "json",
+		Description: "Inserts the values at the paths without replacing existing values. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_KEYS",
+		Arguments:   []string{"json_doc", "[path]"},
+		ReturnType:  "json",
+		Description: "Keys of the top-level object, or of the object at path. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_LENGTH",
+		Arguments:   []string{"json_doc", "[path]"},
+		ReturnType:  "integer",
+		Description: "Length of the JSON document, or of the value at path. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_MERGE_PATCH",
+		Arguments:   []string{"json_doc", "json_doc", "..."},
+		ReturnType:  "json",
+		Description: "RFC 7396 compliant merge of the JSON documents. MySQL 5.7.22 and later.",
+	},
+	{
+		Name:        "JSON_MERGE_PRESERVE",
+		Arguments:   []string{"json_doc", "json_doc", "..."},
+		ReturnType:  "json",
+		Description: "Merges the JSON documents, preserving duplicate keys. MySQL 5.7.22 and later.",
+	},
+	{
+		Name:        "JSON_OBJECT",
+		Arguments:   []string{"[key]", "[val]", "..."},
+		ReturnType:  "json",
+		Description: "JSON object of the key/value pairs. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_OVERLAPS",
+		Arguments:   []string{"json_doc1", "json_doc2"},
+		ReturnType:  "integer",
+		Description: "1 if the two documents have any key/value pairs or array elements in common. MySQL 8.0.17 and later.",
+	},
+	{
+		Name:        "JSON_PRETTY",
+		Arguments:   []string{"json_val"},
+		ReturnType:  "text",
+		Description: "Pretty-printed JSON value. MySQL 5.7.22 and later.",
+	},
+	{
+		Name:        "JSON_QUOTE",
+		Arguments:   []string{"string"},
+		ReturnType:  "json",
+		Description: "Quotes the string as a JSON value. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_REMOVE",
+		Arguments:   []string{"json_doc", "path", "..."},
+		ReturnType:  "json",
+		Description: "Removes the data at the paths from the JSON document. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_REPLACE",
+		Arguments:   []string{"json_doc", "path", "val", "..."},
+		ReturnType:  "json",
+		Description: "Replaces the existing values at the paths. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_SEARCH",
+		Arguments:   []string{"json_doc", "one_or_all", "search_str", "[escape_char]", "[path]", "..."},
+		ReturnType:  "json",
+		Description: "Paths to the given string within the JSON document. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_SET",
+		Arguments:   []string{"json_doc", "path", "val", "..."},
+		ReturnType:  "json",
+		Description: "Inserts or replaces the values at the paths. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_TABLE",
+		Arguments:   []string{"expr", "path COLUMNS (column_list)"},
+		ReturnType:  "table",
+		Description: "Data of the JSON document as a relational table. MySQL 8.0.4 and later.",
+	},
+	{
+		Name:        "JSON_TYPE",
+		Arguments:   []string{"json_val"},
+		ReturnType:  "varchar",
+		Description: "Type of the JSON value. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_UNQUOTE",
+		Arguments:   []string{"json_val"},
+		ReturnType:  "text",
+		Description: "Unquoted JSON value. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "JSON_VALID",
+		Arguments:   []string{"val"},
+		ReturnType:  "integer",
+		Description: "1 if the value is valid JSON, otherwise 0. MySQL 5.7.8 and later.",
+	},
+	{
+		Name:        "AES_DECRYPT",
+		Arguments:   []string{"crypt_str", "key_str", "[init_vector]"},
+		ReturnType:  "blob",
+		Description: "Decrypts using AES.",
+	},
+	{
+		Name:        "AES_ENCRYPT",
+		Arguments:   []string{"str", "key_str", "[init_vector]"},
+		ReturnType:  "blob",
+		Description: "Encrypts using AES.",
+	},
+	{
+		Name:        "MD5",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "MD5 128-bit checksum of str.",
+	},
+	{
+		Name:        "RANDOM_BYTES",
+		Arguments:   []string{"len"},
+		ReturnType:  "varbinary",
+		Description: "Binary string of len random bytes.",
+	},
+	{
+		Name:        "SHA1",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "SHA-1 160-bit checksum of str.",
+	},
+	{
+		Name:        "SHA2",
+		Arguments:   []string{"str", "hash_length"},
+		ReturnType:  "varchar",
+		Description: "SHA-2 checksum of str, with hash_length of 224, 256, 384, 512 or 0 for 256.",
+	},
+	{
+		Name:        "BENCHMARK",
+		Arguments:   []string{"count", "expr"},
+		ReturnType:  "integer",
+		Description: "Executes expr count times, returning 0.",
+	},
+	{
+		Name:        "CHARSET",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "Character set of the string argument.",
+	},
+	{
+		Name:        "COLLATION",
+		Arguments:   []string{"str"},
+		ReturnType:  "varchar",
+		Description: "Collation of the string argument.",
+	},
+	{
+		Name:        "CONNECTION_ID",
+		ReturnType:  "bigint",
+		Description: "Connection ID (thread ID) of the connection.",
+	},
+	{
+		Name:        "CURRENT_USER",
+		ReturnType:  "varchar",
+		Description: "User name and host name combination used by the server to authenticate the current client.",
+	},
+	{
+		Name:        "DATABASE",
+		ReturnType:  "varchar",
+		Description: "Default (current) database name.",
+	},
+	{
+		Name:        "FOUND_ROWS",
+		ReturnType:  "bigint",
+		Description: "Number of rows the last SELECT with SQL_CALC_FOUND_ROWS would have returned without LIMIT.",
+	},
+	{
+		Name:        "LAST_INSERT_ID",
+		Arguments:   []string{"[expr]"},
+		ReturnType:  "bigint",
+		Description: "First automatically generated value inserted for an AUTO_INCREMENT column by the most recent INSERT.",
+	},
+	{
+		Name:        "ROW_COUNT",
+		ReturnType:  "bigint",
+		Description: "Number of rows changed, deleted or inserted by the last statement.",
+	},
+	{
+		Name:        "SCHEMA",
+		ReturnType:  "varchar",
+		Description: "Synonym for DATABASE().",
+	},
+	{
+		Name:        "USER",
+		ReturnType:  "varchar",
+		Description: "Current MySQL user name and host name.",
+	},
+	{
+		Name:        "VERSION",
+		ReturnType:  "varchar",
+		Description: "Version of the MySQL server.",
+	},
+	{
+		Name:        "GET_LOCK",
+		Arguments:   []string{"str", "timeout"},
+		ReturnType:  "integer",
+		Description: "Tries to obtain the named lock for timeout seconds, returning 1 on success.",
+	},
+	{
+		Name:        "RELEASE_LOCK",
+		Arguments:   []string{"str"},
+		ReturnType:  "integer",
+		Description: "Releases the named lock, returning 1 if it was released.",
+	},
+	{
+		Name:        "SLEEP",
+		Arguments:   []string{"duration"},
+		ReturnType:  "integer",
+		Description: "Sleeps for the number of seconds given by duration, returning 0.",
+	},
+	{
+		Name:        "UUID",
+		ReturnType:  "varchar",
+		Description: "Universal Unique Identifier (UUID) generated according to RFC 4122.",
+	},
+	{
+		Name:        "UUID_SHORT",
+		ReturnType:  "bigint",
+		Description: "\"Short\" universal identifier as a 64-bit unsigned integer.",
+	},
+	{
+		Name:        "UUID_TO_BIN",
+		Arguments:   []string{"string_uuid", "[swap_flag]"},
+		ReturnType:  "varbinary",
+		Description: "UUID converted to a binary string. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "BIN_TO_UUID",
+		Arguments:   []string{"binary_uuid", "[swap_flag]"},
+		ReturnType:  "varchar",
+		Description: "Binary UUID converted to a string. MySQL 8.0 and later.",
+	},
+	{
+		Name:        "INET_ATON",
+		Arguments:   []string{"expr"},
+		ReturnType:  "bigint",
+		Description: "Numeric value of the dotted-quad IPv4 address.",
+	},
+	{
+		Name:        "INET_NTOA",
+		Arguments:   []string{"expr"},
+		ReturnType:  "varchar",
+		Description: "Dotted-quad IPv4 address of the numeric value.",
+	},
+	{
+		Name:        "INET6_ATON",
+		Arguments:   []string{"expr"},
+		ReturnType:  "varbinary",
+		Description: "Numeric value of the IPv6 or IPv4 address.",
+	},
+	{
+		Name:        "INET6_NTOA",
+		Arguments:   []string{"expr"},
+		ReturnType:  "varchar",
+		Description: "IPv6 or IPv4 address of the numeric value.",
+	},
+}
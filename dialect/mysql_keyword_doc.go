@@ -0,0 +1,170 @@
+package dialect
+
+// mysqlKeywordDocs is curated by hand from the MySQL reference manual rather
+// than generated from the help tables, whose topics mix the syntax of 5.6,
+// 5.7 and 8.0.
+var mysqlKeywordDocs = []*KeywordDesc{
+	{
+		Name: "SELECT",
+		Syntax: `SELECT [ALL | DISTINCT | DISTINCTROW] select_expr [, select_expr] ...
+    [FROM table_references]
+    [WHERE where_condition]
+    [GROUP BY {col_name | expr | position}, ... [WITH ROLLUP]]
+    [HAVING where_condition]
+    [WINDOW window_name AS (window_spec), ...]
+    [ORDER BY {col_name | expr | position} [ASC | DESC], ...]
+    [LIMIT {[offset,] row_count | row_count OFFSET offset}]
+    [FOR {UPDATE | SHARE} [OF tbl_name [, tbl_name] ...] [NOWAIT | SKIP LOCKED]]`,
+		Description: "Retrieves rows selected from one or more tables, and can include UNION statements and subqueries.",
+	},
+	{
+		Name: "INSERT",
+		Syntax: `INSERT [LOW_PRIORITY | DELAYED | HIGH_PRIORITY] [IGNORE]
+    [INTO] tbl_name [(col_name [, col_name] ...)]
+    {VALUES | VALUE} (value_list) [, (value_list)] ...
+    [ON DUPLICATE KEY UPDATE assignment_list]`,
+		Description: "Inserts new rows into an existing table. The rows come from a VALUES list, a SET assignment list or a SELECT statement.",
+	},
+	{
+		Name: "INSERT INTO",
+		Syntax: `INSERT [IGNORE] INTO tbl_name [(col_name [, col_name] ...)]
+    {VALUES | VALUE} (value_list) [, (value_list)] ...
+    [ON DUPLICATE KEY UPDATE assignment_list]`,
+		Description: "Inserts new rows into an existing table.",
+	},
+	{
+		Name: "ON DUPLICATE KEY UPDATE",
+		Syntax: `INSERT INTO tbl_name (col_name, ...) VALUES (value_list)
+    ON DUPLICATE KEY UPDATE col_name = expr [, col_name = expr] ...`,
+		Description: "Updates the existing row when an inserted row would cause a duplicate value in a UNIQUE index or PRIMARY KEY. VALUES(col_name) refers to the value that would have been inserted.",
+	},
+	{
+		Name: "REPLACE",
+		Syntax: `REPLACE [LOW_PRIORITY | DELAYED] [INTO] tbl_name [(col_name [, col_name] ...)]
+    {VALUES | VALUE} (value_list) [, (value_list)] ...`,
+		Description: "Works exactly like INSERT, except that an old row with the same value for a PRIMARY KEY or a UNIQUE index is deleted before the new row is inserted.",
+	},
+	{
+		Name: "UPDATE",
+		Syntax: `UPDATE [LOW_PRIORITY] [IGNORE] table_reference
+    SET assignment_list
+    [WHERE where_condition]
+    [ORDER BY ...]
+    [LIMIT row_count]`,
+		Description: "Modifies the columns of existing rows in the named table with new values. Without WHERE, all rows are updated.",
+	},
+	{
+		Name: "DELETE",
+		Syntax: `DELETE [LOW_PRIORITY] [QUICK] [IGNORE] FROM tbl_name [[AS] tbl_alias]
+    [PARTITION (partition_name [, partition_name] ...)]
+    [WHERE where_condition]
+    [ORDER BY ...]
+    [LIMIT row_count]`,
+		Description: "Removes rows from a table and returns the number of deleted rows. Without WHERE, all rows are deleted.",
+	},
+	{
+		Name:        "DELETE FROM",
+		Syntax:      `DELETE FROM tbl_name [WHERE where_condition] [ORDER BY ...] [LIMIT row_count]`,
+		Description: "Removes rows from a table and returns the number of deleted rows.",
+	},
+	{
+		Name:        "FROM",
+		Syntax:      `FROM table_references [PARTITION partition_list]`,
+		Description: "Indicates the table or tables from which to retrieve rows. If more than one table is named, a join is performed.",
+	},
+	{
+		Name:        "WHERE",
+		Syntax:      `WHERE where_condition`,
+		Description: "Indicates the condition that rows must satisfy to be selected. Without WHERE, all rows are selected.",
+	},
+	{
+		Name:        "GROUP BY",
+		Syntax:      `GROUP BY {col_name | expr | position}, ... [WITH ROLLUP]`,
+		Description: "Groups the rows by the values of the columns or expressions, producing one row per group. WITH ROLLUP adds rows for the super-aggregates.",
+	},
+	{
+		Name:        "HAVING",
+		Syntax:      `HAVING where_condition`,
+		Description: "Filters the groups produced by GROUP BY. Unlike WHERE, it can refer to aggregate functions.",
+	},
+	{
+		Name:        "ORDER BY",
+		Syntax:      `ORDER BY {col_name | expr | position} [ASC | DESC], ...`,
+		Description: "Sorts the result rows. The default order is ascending.",
+	},
+	{
+		Name:        "LIMIT",
+		Syntax:      `LIMIT {[offset,] row_count | row_count OFFSET offset}`,
+		Description: "Constrains the number of rows returned. The offset of the first row is 0.",
+	},
+	{
+		Name: "JOIN",
+		Syntax: `table_reference [INNER | CROSS] JOIN table_factor [join_specification]
+table_reference {LEFT | RIGHT} [OUTER] JOIN table_reference join_specification
+join_specification: ON search_condition | USING (join_column_list)`,
+		Description: "Combines the rows of two tables. INNER JOIN and CROSS JOIN are equivalent in MySQL.",
+	},
+	{
+		Name:        "INNER JOIN",
+		Syntax:      `table_reference INNER JOIN table_factor [ON search_condition | USING (join_column_list)]`,
+		Description: "Produces the rows of both tables that satisfy the join condition.",
+	},
+	{
+		Name:        "LEFT JOIN",
+		Syntax:      `table_reference LEFT [OUTER] JOIN table_reference {ON search_condition | USING (join_column_list)}`,
+		Description: "Produces all rows of the left table. The columns of the right table are NULL for the rows without a match.",
+	},
+	{
+		Name:        "RIGHT JOIN",
+		Syntax:      `table_reference RIGHT [OUTER] JOIN table_reference {ON search_condition | USING (join_column_list)}`,
+		Description: "Produces all rows of the right table. The columns of the left table are NULL for the rows without a match.",
+	},
+	{
+		Name:        "UNION",
+		Syntax:      `SELECT ... UNION [ALL | DISTINCT] SELECT ... [UNION [ALL | DISTINCT] SELECT ...]`,
+		Description: "Combines the results of several SELECT statements into a single result set. Duplicate rows are removed unless ALL is given.",
+	},
+	{
+		Name: "WITH",
+		Syntax: `WITH [RECURSIVE]
+    cte_name [(col_name [, col_name] ...)] AS (subquery)
+    [, cte_name [(col_name [, col_name] ...)] AS (subquery)] ...`,
+		Description: "Defines common table expressions, named temporary result sets that can be referred to in the statement. Available as of MySQL 8.0.",
+	},
+	{
+		Name: "CASE",
+		Syntax: `CASE value WHEN compare_value THEN result [WHEN compare_value THEN result ...] [ELSE result] END
+CASE WHEN condition THEN result [WHEN condition THEN result ...] [ELSE result] END`,
+		Description: "Returns the result of the first WHEN that matches, or the ELSE result. Without ELSE, NULL is returned.",
+	},
+	{
+		Name: "CREATE TABLE",
+		Syntax: `CREATE [TEMPORARY] TABLE [IF NOT EXISTS] tbl_name
+    (create_definition, ...)
+    [table_options]
+    [partition_options]`,
+		Description: "Creates a table with the given name.",
+	},
+	{
+		Name: "ALTER TABLE",
+		Syntax: `ALTER TABLE tbl_name
+    [alter_option [, alter_option] ...]
+    [partition_options]`,
+		Description: "Changes the structure of a table, such as adding or dropping columns, creating or destroying indexes, and renaming columns or the table itself.",
+	},
+	{
+		Name:        "TRUNCATE",
+		Syntax:      `TRUNCATE [TABLE] tbl_name`,
+		Description: "Empties a table completely. It is mapped to DROP TABLE followed by CREATE TABLE, bypassing the row-by-row deletion.",
+	},
+	{
+		Name:        "DISTINCT",
+		Syntax:      `SELECT DISTINCT select_expr [, select_expr] ...`,
+		Description: "Removes duplicate rows from the result set.",
+	},
+	{
+		Name:        "EXPLAIN",
+		Syntax:      `{EXPLAIN | DESCRIBE | DESC} [explain_type] {explainable_stmt | FOR CONNECTION connection_id}`,
+		Description: "Provides information about how MySQL executes a statement.",
+	},
+}
@@ -0,0 +1,1286 @@
+package dialect
+
+var postgresqlFunctions = []*FunctionDesc{
+	{
+		Name:        "ABS",
+		Arguments:   []string{"x numeric"},
+		ReturnType:  "numeric",
+		Description: "Absolute value.",
+	},
+	{
+		Name:        "CBRT",
+		Arguments:   []string{"x double precision"},
+		ReturnType:  "double precision",
+		Description: "Cube root.",
+	},
+	{
+		Name:        "CEIL",
+		Arguments:   []string{"x numeric"},
+		ReturnType:  "numeric",
+		Description: "Nearest integer greater than or equal to the argument.",
+	},
+	{
+		Name:        "CEILING",
+		Arguments:   []string{"x numeric"},
+		ReturnType:  "numeric",
+		Description: "Nearest integer greater than or equal to the argument.",
+	},
+	{
+		Name:        "DEGREES",
+		Arguments:   []string{"x double precision"},
+		ReturnType:  "double precision",
+		Description: "Converts radians to degrees.",
+	},
+	{
+		Name:        "DIV",
+		Arguments:   []string{"y numeric", "x numeric"},
+		ReturnType:  "numeric",
+		Description: "Integer quotient of y/x, truncated towards zero.",
+	},
+	{
+		Name:        "EXP",
+		Arguments:   []string{"x numeric"},
+		ReturnType:  "numeric",
+		Description: "Exponential, e raised to the given power.",
+	},
+	{
+		Name:        "FLOOR",
+		Arguments:   []string{"x numeric"},
+		ReturnType:  "numeric",
+		Description: "Nearest integer less than or equal to the argument.",
+	},
+	{
+		Name:        "GCD",
+		Arguments:   []string{"x integer", "y integer"},
+		ReturnType:  "integer",
+		Description: "Greatest common divisor.",
+	},
+	{
+		Name:        "LCM",
+		Arguments:   []string{"x integer", "y integer"},
+		ReturnType:  "integer",
+		Description: "Least common multiple.",
+	},
+	{
+		Name:        "LN",
+		Arguments:   []string{"x numeric"},
+		ReturnType:  "numeric",
+		Description: "Natural logarithm.",
+	},
+	{
+		Name:        "LOG",
+		Arguments:   []string{"x numeric"},
+		ReturnType:  "numeric",
+		Description: "Base 10 logarithm.",
+	},
+	{
+		Name:        "LOG",
+		Arguments:   []string{"b numeric", "x numeric"},
+		ReturnType:  "numeric",
+		Description: "Logarithm of x to base b.",
+	},
+	{
+		Name:        "LOG10",
+		Arguments:   []string{"x numeric"},
+		ReturnType:  "numeric",
+		Description: "Base 10 logarithm.",
+	},
+	{
+		Name:        "MOD",
+		Arguments:   []string{"y integer", "x integer"},
+		ReturnType:  "integer",
+		Description: "Remainder of y/x.",
+	},
+	{
+		Name:        "PI",
+		ReturnType:  "double precision",
+		Description: "Approximate value of π.",
+	},
+	{
+		Name:        "POWER",
+		Arguments:   []string{"a numeric", "b numeric"},
+		ReturnType:  "numeric",
+		Description: "a raised to the power of b.",
+	},
+	{
+		Name:        "RADIANS",
+		Arguments:   []string{"x double precision"},
+		ReturnType:  "double precision",
+		Description: "Converts degrees to radians.",
+	},
+	{
+		Name:        "RANDOM",
+		ReturnType:  "double precision",
+		Description: "Random value in the range 0.0 <= x < 1.0.",
+	},
+	{
+		Name:        "ROUND",
+		Arguments:   []string{"x numeric", "[s integer]"},
+		ReturnType:  "numeric",
+		Description: "Rounds to the nearest integer, or to s decimal places.",
+	},
+	{
+		Name:        "SCALE",
+		Arguments:   []string{"x numeric"},
+		ReturnType:  "integer",
+		Description: "Scale of the argument, the number of decimal digits in the fractional part.",
+	},
+	{
+		Name:        "SETSEED",
+		Arguments:   []string{"seed double precision"},
+		ReturnType:  "void",
+		Description: "Sets the seed for subsequent random() calls.",
+	},
+	{
+		Name:        "SIGN",
+		Arguments:   []string{"x numeric"},
+		ReturnType:  "numeric",
+		Description: "Sign of the argument, -1, 0 or +1.",
+	},
+	{
+		Name:        "SQRT",
+		Arguments:   []string{"x numeric"},
+		ReturnType:  "numeric",
+		Description: "Square root.",
+	},
+	{
+		Name:        "TRUNC",
+		Arguments:   []string{"x numeric", "[s integer]"},
+		ReturnType:  "numeric",
+		Description: "Truncates to an integer, or to s decimal places.",
+	},
+	{
+		Name:        "WIDTH_BUCKET",
+		Arguments:   []string{"operand numeric", "low numeric", "high numeric", "count integer"},
+		ReturnType:  "integer",
+		Description: "Number of the bucket in which operand falls in a histogram of count equal-width buckets.",
+	},
+	{
+		Name:        "ACOS",
+		Arguments:   []string{"x double precision"},
+		ReturnType:  "double precision",
+		Description: "Inverse cosine, in radians.",
+	},
+	{
+		Name:        "ASIN",
+		Arguments:   []string{"x double precision"},
+		ReturnType:  "double precision",
+		Description: "Inverse sine, in radians.",
+	},
+	{
+		Name:        "ATAN",
+		Arguments:   []string{"x double precision"},
+		ReturnType:  "double precision",
+		Description: "Inverse tangent, in radians.",
+	},
+	{
+		Name:        "ATAN2",
+		Arguments:   []string{"y double precision", "x double precision"},
+		ReturnType:  "double precision",
+		Description: "Inverse tangent of y/x, in radians.",
+	},
+	{
+		Name:        "COS",
+		Arguments:   []string{"x double precision"},
+		ReturnType:  "double precision",
+		Description: "Cosine, argument in radians.",
+	},
+	{
+		Name:        "SIN",
+		Arguments:   []string{"x double precision"},
+		ReturnType:  "double precision",
+		Description: "Sine, argument in radians.",
+	},
+	{
+		Name:        "TAN",
+		Arguments:   []string{"x double precision"},
+		ReturnType:  "double precision",
+		Description: "Tangent, argument in radians.",
+	},
+	{
+		Name:        "ASCII",
+		Arguments:   []string{"string text"},
+		ReturnType:  "integer",
+		Description: "Numeric code of the first character of the argument.",
+	},
+	{
+		Name:        "BIT_LENGTH",
+		Arguments:   []string{"string text"},
+		ReturnType:  "integer",
+		Description: "Number of bits in the string.",
+	},
+	{
+		Name:        "BTRIM",
+		Arguments:   []string{"string text", "[characters text]"},
+		ReturnType:  "text",
+		Description: "Removes the longest string containing only characters from the start and end of string.",
+	},
+	{
+		Name:        "CHAR_LENGTH",
+		Arguments:   []string{"string text"},
+		ReturnType:  "integer",
+		Description: "Number of characters in the string.",
+	},
+	{
+		Name:        "CHARACTER_LENGTH",
+		Arguments:   []string{"string text"},
+		ReturnType:  "integer",
+		Description: "Number of characters in the string.",
+	},
+	{
+		Name:        "CHR",
+		Arguments:   []string{"code integer"},
+		ReturnType:  "text",
+		Description: "Character with the given code.",
+	},
+	{
+		Name:        "CONCAT",
+		Arguments:   []string{"val1 \"any\"", "..."},
+		ReturnType:  "text",
+		Description: "Concatenates the text representations of all the arguments. NULL arguments are ignored.",
+	},
+	{
+		Name:        "CONCAT_WS",
+		Arguments:   []string{"sep text", "val1 \"any\"", "..."},
+		ReturnType:  "text",
+		Description: "Concatenates all but the first argument, with separators. NULL arguments are ignored.",
+	},
+	{
+		Name:        "DECODE",
+		Arguments:   []string{"string text", "format text"},
+		ReturnType:  "bytea",
+		Description: "Decodes binary data from a textual representation, such as base64 or hex.",
+	},
+	{
+		Name:        "ENCODE",
+		Arguments:   []string{"data bytea", "format text"},
+		ReturnType:  "text",
+		Description: "Encodes binary data into a textual representation, such as base64 or hex.",
+	},
+	{
+		Name:        "FORMAT",
+		Arguments:   []string{"formatstr text", "formatarg \"any\"", "..."},
+		ReturnType:  "text",
+		Description: "Formats arguments according to a format string, similarly to the C function sprintf.",
+	},
+	{
+		Name:        "INITCAP",
+		Arguments:   []string{"string text"},
+		ReturnType:  "text",
+		Description: "Converts the first letter of each word to upper case and the rest to lower case.",
+	},
+	{
+		Name:        "LEFT",
+		Arguments:   []string{"string text", "n integer"},
+		ReturnType:  "text",
+		Description: "First n characters in the string. When n is negative, all but the last |n| characters.",
+	},
+	{
+		Name:        "LENGTH",
+		Arguments:   []string{"string text"},
+		ReturnType:  "integer",
+		Description: "Number of characters in the string.",
+	},
+	{
+		Name:        "LOWER",
+		Arguments:   []string{"string text"},
+		ReturnType:  "text",
+		Description: "Converts the string to all lower case.",
+	},
+	{
+		Name:        "LPAD",
+		Arguments:   []string{"string text", "length integer", "[fill text]"},
+		ReturnType:  "text",
+		Description: "Extends the string to length by prepending the characters fill, a space by default.",
+	},
+	{
+		Name:        "LTRIM",
+		Arguments:   []string{"string text", "[characters text]"},
+		ReturnType:  "text",
+		Description: "Removes the longest string containing only characters from the start of string.",
+	},
+	{
+		Name:        "MD5",
+		Arguments:   []string{"string text"},
+		ReturnType:  "text",
+		Description: "MD5 hash of the argument, in hexadecimal.",
+	},
+	{
+		Name:        "OCTET_LENGTH",
+		Arguments:   []string{"string text"},
+		ReturnType:  "integer",
+		Description: "Number of bytes in the string.",
+	},
+	{
+		Name:        "QUOTE_IDENT",
+		Arguments:   []string{"string text"},
+		ReturnType:  "text",
+		Description: "Quotes the string to be used as an identifier, if needed.",
+	},
+	{
+		Name:        "QUOTE_LITERAL",
+		Arguments:   []string{"string text"},
+		ReturnType:  "text",
+		Description: "Quotes the string to be used as a string literal.",
+	},
+	{
+		Name:        "QUOTE_NULLABLE",
+		Arguments:   []string{"string text"},
+		ReturnType:  "text",
+		Description: "Quotes the string to be used as a string literal, or returns NULL unquoted.",
+	},
+	{
+		Name:        "REGEXP_MATCH",
+		Arguments:   []string{"string text", "pattern text", "[flags text]"},
+		ReturnType:  "text[]",
+		Description: "Substrings within the first match of a POSIX regular expression.",
+	},
+	{
+		Name:        "REGEXP_MATCHES",
+		Arguments:   []string{"string text", "pattern text", "[flags text]"},
+		ReturnType:  "setof text[]",
+		Description: "Substrings within the matches of a POSIX regular expression.",
+	},
+	{
+		Name:        "REGEXP_REPLACE",
+		Arguments:   []string{"string text", "pattern text", "replacement text", "[flags text]"},
+		ReturnType:  "text",
+		Description: "Replaces substrings matching a POSIX regular expression.",
+	},
+	{
+		Name:        "REGEXP_SPLIT_TO_ARRAY",
+		Arguments:   []string{"string text", "pattern text", "[flags text]"},
+		ReturnType:  "text[]",
+		Description: "Splits the string using a POSIX regular expression as the delimiter.",
+	},
+	{
+		Name:        "REGEXP_SPLIT_TO_TABLE",
+		Arguments:   []string{"string text", "pattern text", "[flags text]"},
+		ReturnType:  "setof text",
+		Description: "Splits the string using a POSIX regular expression as the delimiter, one row per part.",
+	},
+	{
+		Name:        "REPEAT",
+		Arguments:   []string{"string text", "number integer"},
+		ReturnType:  "text",
+		Description: "Repeats the string the given number of times.",
+	},
+	{
+		Name:        "REPLACE",
+		Arguments:   []string{"string text", "from text", "to text"},
+		ReturnType:  "text",
+		Description: "Replaces all occurrences of the substring from with the substring to.",
+	},
+	{
+		Name:        "REVERSE",
+		Arguments:   []string{"string text"},
+		ReturnType:  "text",
+		Description: "Reverses the order of the characters in the string.",
+	},
+	{
+		Name:        "RIGHT",
+		Arguments:   []string{"string text", "n integer"},
+		ReturnType:  "text",
+		Description: "Last n characters in the string. When n is negative, all but the first |n| characters.",
+	},
+	{
+		Name:        "RPAD",
+		Arguments:   []string{"string text", "length integer", "[fill text]"},
+		ReturnType:  "text",
+		Description: "Extends the string to length by appending the characters fill, a space by default.",
+	},
+	{
+		Name:        "RTRIM",
+		Arguments:   []string{"string text", "[characters text]"},
+		ReturnType:  "text",
+		Description: "Removes the longest string containing only characters from the end of string.",
+	},
+	{
+		Name:        "SPLIT_PART",
+		Arguments:   []string{"string text", "delimiter text", "n integer"},
+		ReturnType:  "text",
+		Description: "Splits the string at occurrences of delimiter and returns the n'th field, counting from one.",
+	},
+	{
+		Name:        "STARTS_WITH",
+		Arguments:   []string{"string text", "prefix text"},
+		ReturnType:  "boolean",
+		Description: "Whether the string starts with prefix.",
+	},
+	{
+		Name:        "STRPOS",
+		Arguments:   []string{"string text", "substring text"},
+		ReturnType:  "integer",
+		Description: "Position of the first occurrence of substring within string, or zero.",
+	},
+	{
+		Name:        "SUBSTR",
+		Arguments:   []string{"string text", "start integer", "[count integer]"},
+		ReturnType:  "text",
+		Description: "Substring starting at the start'th character, extending for count characters if specified.",
+	},
+	{
+		Name:        "SUBSTRING",
+		Arguments:   []string{"string text", "start integer", "[count integer]"},
+		ReturnType:  "text",
+		Description: "Substring starting at the start'th character, extending for count characters if specified.",
+	},
+	{
+		Name:        "TO_HEX",
+		Arguments:   []string{"number bigint"},
+		ReturnType:  "text",
+		Description: "Converts the number to its hexadecimal representation.",
+	},
+	{
+		Name:        "TRANSLATE",
+		Arguments:   []string{"string text", "from text", "to text"},
+		ReturnType:  "text",
+		Description: "Replaces each character in string that matches a character in from with the corresponding character in to.",
+	},
+	{
+		Name:        "UPPER",
+		Arguments:   []string{"string text"},
+		ReturnType:  "text",
+		Description: "Converts the string to all upper case.",
+	},
+	{
+		Name:        "ARRAY_AGG",
+		Arguments:   []string{"expression anyelement"},
+		ReturnType:  "anyarray",
+		Description: "Collects all the input values, including nulls, into an array.",
+	},
+	{
+		Name:        "AVG",
+		Arguments:   []string{"expression numeric"},
+		ReturnType:  "numeric",
+		Description: "Average of all the non-null input values.",
+	},
+	{
+		Name:        "BIT_AND",
+		Arguments:   []string{"expression integer"},
+		ReturnType:  "integer",
+		Description: "Bitwise AND of all the non-null input values.",
+	},
+	{
+		Name:        "BIT_OR",
+		Arguments:   []string{"expression integer"},
+		ReturnType:  "integer",
+		Description: "Bitwise OR of all the non-null input values.",
+	},
+	{
+		Name:        "BOOL_AND",
+		Arguments:   []string{"expression boolean"},
+		ReturnType:  "boolean",
+		Description: "True if all the non-null input values are true.",
+	},
+	{
+		Name:        "BOOL_OR",
+		Arguments:   []string{"expression boolean"},
+		ReturnType:  "boolean",
+		Description: "True if any non-null input value is true.",
+	},
+	{
+		Name:        "COUNT",
+		Arguments:   []string{"*"},
+		ReturnType:  "bigint",
+		Description: "Number of input rows.",
+	},
+	{
+		Name:        "COUNT",
+		Arguments:   []string{"expression \"any\""},
+		ReturnType:  "bigint",
+		Description: "Number of input rows in which the input value is not null.",
+	},
+	{
+		Name:        "EVERY",
+		Arguments:   []string{"expression boolean"},
+		ReturnType:  "boolean",
+		Description: "True if all the non-null input values are true.",
+	},
+	{
+		Name:        "JSON_AGG",
+		Arguments:   []string{"expression anyelement"},
+		ReturnType:  "json",
+		Description: "Collects all the input values, including nulls, into a JSON array.",
+	},
+	{
+		Name:        "JSONB_AGG",
+		Arguments:   []string{"expression anyelement"},
+		ReturnType:  "jsonb",
+		Description: "Collects all the input values, including nulls, into a JSON array.",
+	},
+	{
+		Name:        "JSON_OBJECT_AGG",
+		Arguments:   []string{"key \"any\"", "value \"any\""},
+		ReturnType:  "json",
+		Description: "Collects all the key/value pairs into a JSON object.",
+	},
+	{
+		Name:        "JSONB_OBJECT_AGG",
+		Arguments:   []string{"key \"any\"", "value \"any\""},
+		ReturnType:  "jsonb",
+		Description: "Collects all the key/value pairs into a JSON object.",
+	},
+	{
+		Name:        "MAX",
+		Arguments:   []string{"expression anyelement"},
+		ReturnType:  "anyelement",
+		Description: "Maximum of the non-null input values.",
+	},
+	{
+		Name:        "MIN",
+		Arguments:   []string{"expression anyelement"},
+		ReturnType:  "anyelement",
+		Description: "Minimum of the non-null input values.",
+	},
+	{
+		Name:        "STRING_AGG",
+		Arguments:   []string{"value text", "delimiter text"},
+		ReturnType:  "text",
+		Description: "Concatenates the non-null input values, separated by the delimiter.",
+	},
+	{
+		Name:        "SUM",
+		Arguments:   []string{"expression numeric"},
+		ReturnType:  "numeric",
+		Description: "Sum of the non-null input values.",
+	},
+	{
+		Name:        "CORR",
+		Arguments:   []string{"y double precision", "x double precision"},
+		ReturnType:  "double precision",
+		Description: "Correlation coefficient.",
+	},
+	{
+		Name:        "STDDEV",
+		Arguments:   []string{"expression numeric"},
+		ReturnType:  "numeric",
+		Description: "Sample standard deviation of the input values.",
+	},
+	{
+		Name:        "STDDEV_POP",
+		Arguments:   []string{"expression numeric"},
+		ReturnType:  "numeric",
+		Description: "Population standard deviation of the input values.",
+	},
+	{
+		Name:        "STDDEV_SAMP",
+		Arguments:   []string{"expression numeric"},
+		ReturnType:  "numeric",
+		Description: "Sample standard deviation of the input values.",
+	},
+	{
+		Name:        "VARIANCE",
+		Arguments:   []string{"expression numeric"},
+		ReturnType:  "numeric",
+		Description: "Sample variance of the input values.",
+	},
+	{
+		Name:        "VAR_POP",
+		Arguments:   []string{"expression numeric"},
+		ReturnType:  "numeric",
+		Description: "Population variance of the input values.",
+	},
+	{
+		Name:        "VAR_SAMP",
+		Arguments:   []string{"expression numeric"},
+		ReturnType:  "numeric",
+		Description: "Sample variance of the input values.",
+	},
+	{
+		Name:        "MODE",
+		ReturnType:  "anyelement",
+		Description: "Most frequent value of the ordered argument, used with WITHIN GROUP (ORDER BY ...).",
+	},
+	{
+		Name:        "PERCENTILE_CONT",
+		Arguments:   []string{"fraction double precision"},
+		ReturnType:  "double precision",
+		Description: "Continuous percentile, used with WITHIN GROUP (ORDER BY ...).",
+	},
+	{
+		Name:        "PERCENTILE_DISC",
+		Arguments:   []string{"fraction double precision"},
+		ReturnType:  "anyelement",
+		Description: "Discrete percentile, used with WITHIN GROUP (ORDER BY ...).",
+	},
+	{
+		Name:        "ROW_NUMBER",
+		ReturnType:  "bigint",
+		Description: "Number of the current row within its partition, counting from 1.",
+	},
+	{
+		Name:        "RANK",
+		ReturnType:  "bigint",
+		Description: "Rank of the current row, with gaps.",
+	},
+	{
+		Name:        "DENSE_RANK",
+		ReturnType:  "bigint",
+		Description: "Rank of the current row, without gaps.",
+	},
+	{
+		Name:        "PERCENT_RANK",
+		ReturnType:  "double precision",
+		Description: "Relative rank of the current row, (rank - 1) / (total partition rows - 1).",
+	},
+	{
+		Name:        "CUME_DIST",
+		ReturnType:  "double precision",
+		Description: "Cumulative distribution, (number of partition rows preceding or peers with current row) / (total partition rows).",
+	},
+	{
+		Name:        "NTILE",
+		Arguments:   []string{"num_buckets integer"},
+		ReturnType:  "integer",
+		Description: "Integer ranging from 1 to num_buckets, dividing the partition as equally as possible.",
+	},
+	{
+		Name:        "LAG",
+		Arguments:   []string{"value anyelement", "[offset integer]", "[default anyelement]"},
+		ReturnType:  "anyelement",
+		Description: "Value evaluated at the row that is offset rows before the current row within the partition.",
+	},
+	{
+		Name:        "LEAD",
+		Arguments:   []string{"value anyelement", "[offset integer]", "[default anyelement]"},
+		ReturnType:  "anyelement",
+		Description: "Value evaluated at the row that is offset rows after the current row within the partition.",
+	},
+	{
+		Name:        "FIRST_VALUE",
+		Arguments:   []string{"value anyelement"},
+		ReturnType:  "anyelement",
+		Description: "Value evaluated at the row that is the first row of the window frame.",
+	},
+	{
+		Name:        "LAST_VALUE",
+		Arguments:   []string{"value anyelement"},
+		ReturnType:  "anyelement",
+		Description: "Value evaluated at the row that is the last row of the window frame.",
+	},
+	{
+		Name:        "NTH_VALUE",
+		Arguments:   []string{"value anyelement", "n integer"},
+		ReturnType:  "anyelement",
+		Description: "Value evaluated at the row that is the n'th row of the window frame.",
+	},
+	{
+		Name:        "COALESCE",
+		Arguments:   []string{"value \"any\"", "..."},
+		ReturnType:  "anyelement",
+		Description: "First of the arguments that is not null.",
+	},
+	{
+		Name:        "NULLIF",
+		Arguments:   []string{"value1 \"any\"", "value2 \"any\""},
+		ReturnType:  "anyelement",
+		Description: "Null if value1 equals value2, otherwise value1.",
+	},
+	{
+		Name:        "GREATEST",
+		Arguments:   []string{"value \"any\"", "..."},
+		ReturnType:  "anyelement",
+		Description: "Largest value from the list of expressions.",
+	},
+	{
+		Name:        "LEAST",
+		Arguments:   []string{"value \"any\"", "..."},
+		ReturnType:  "anyelement",
+		Description: "Smallest value from the list of expressions.",
+	},
+	{
+		Name:        "AGE",
+		Arguments:   []string{"timestamp timestamp", "[since timestamp]"},
+		ReturnType:  "interval",
+		Description: "Subtracts the arguments, or the argument from current_date, producing a symbolic result in years and months.",
+	},
+	{
+		Name:        "CLOCK_TIMESTAMP",
+		ReturnType:  "timestamp with time zone",
+		Description: "Current date and time, which changes during statement execution.",
+	},
+	{
+		Name:        "DATE_PART",
+		Arguments:   []string{"field text", "source timestamp"},
+		ReturnType:  "double precision",
+		Description: "Subfield of the timestamp or interval, equivalent to extract.",
+	},
+	{
+		Name:        "DATE_TRUNC",
+		Arguments:   []string{"field text", "source timestamp", "[time_zone text]"},
+		ReturnType:  "timestamp",
+		Description: "Truncates the timestamp to the specified precision.",
+	},
+	{
+		Name:        "EXTRACT",
+		Arguments:   []string{"field FROM source timestamp"},
+		ReturnType:  "numeric",
+		Description: "Subfield of the timestamp or interval, such as year or hour.",
+	},
+	{
+		Name:        "ISFINITE",
+		Arguments:   []string{"source timestamp"},
+		ReturnType:  "boolean",
+		Description: "Tests for a finite date, timestamp or interval.",
+	},
+	{
+		Name:        "JUSTIFY_DAYS",
+		Arguments:   []string{"span interval"},
+		ReturnType:  "interval",
+		Description: "Adjusts the interval so 30-day time periods are represented as months.",
+	},
+	{
+		Name:        "JUSTIFY_HOURS",
+		Arguments:   []string{"span interval"},
+		ReturnType:  "interval",
+		Description: "Adjusts the interval so 24-hour time periods are represented as days.",
+	},
+	{
+		Name:        "JUSTIFY_INTERVAL",
+		Arguments:   []string{"span interval"},
+		ReturnType:  "interval",
+		Description: "Adjusts the interval using justify_days and justify_hours, with additional sign adjustments.",
+	},
+	{
+		Name:        "MAKE_DATE",
+		Arguments:   []string{"year integer", "month integer", "day integer"},
+		ReturnType:  "date",
+		Description: "Creates a date from year, month and day fields.",
+	},
+	{
+		Name:        "MAKE_INTERVAL",
+		Arguments:   []string{"[years integer]", "[months integer]", "[weeks integer]", "[days integer]", "[hours integer]", "[mins integer]", "[secs double precision]"},
+		ReturnType:  "interval",
+		Description: "Creates an interval from years, months, weeks, days, hours, minutes and seconds fields.",
+	},
+	{
+		Name:        "MAKE_TIME",
+		Arguments:   []string{"hour integer", "min integer", "sec double precision"},
+		ReturnType:  "time",
+		Description: "Creates a time from hour, minute and seconds fields.",
+	},
+	{
+		Name:        "MAKE_TIMESTAMP",
+		Arguments:   []string{"year integer", "month integer", "day integer", "hour integer", "min integer", "sec double precision"},
+		ReturnType:  "timestamp",
+		Description: "Creates a timestamp from year, month, day, hour, minute and seconds fields.",
+	},
+	{
+		Name:        "MAKE_TIMESTAMPTZ",
+		Arguments:   []string{"year integer", "month integer", "day integer", "hour integer", "min integer", "sec double precision", "[timezone text]"},
+		ReturnType:  "timestamp with time zone",
+		Description: "Creates a timestamp with time zone from year, month, day, hour, minute and seconds fields.",
+	},
+	{
+		Name:        "NOW",
+		ReturnType:  "timestamp with time zone",
+		Description: "Current date and time, as of the start of the current transaction.",
+	},
+	{
+		Name:        "STATEMENT_TIMESTAMP",
+		ReturnType:  "timestamp with time zone",
+		Description: "Current date and time, as of the start of the current statement.",
+	},
+	{
+		Name:        "TIMEOFDAY",
+		ReturnType:  "text",
+		Description: "Current date and time as a text string, which changes during statement execution.",
+	},
+	{
+		Name:        "TRANSACTION_TIMESTAMP",
+		ReturnType:  "timestamp with time zone",
+		Description: "Current date and time, as of the start of the current transaction.",
+	},
+	{
+		Name:        "TO_CHAR",
+		Arguments:   []string{"value \"any\"", "format text"},
+		ReturnType:  "text",
+		Description: "Converts a timestamp, interval or number to a string according to the format.",
+	},
+	{
+		Name:        "TO_DATE",
+		Arguments:   []string{"text text", "format text"},
+		ReturnType:  "date",
+		Description: "Converts a string to a date according to the format.",
+	},
+	{
+		Name:        "TO_NUMBER",
+		Arguments:   []string{"text text", "format text"},
+		ReturnType:  "numeric",
+		Description: "Converts a string to a number according to the format.",
+	},
+	{
+		Name:        "TO_TIMESTAMP",
+		Arguments:   []string{"epoch double precision"},
+		ReturnType:  "timestamp with time zone",
+		Description: "Converts Unix epoch seconds to a timestamp with time zone.",
+	},
+	{
+		Name:        "TO_TIMESTAMP",
+		Arguments:   []string{"text text", "format text"},
+		ReturnType:  "timestamp with time zone",
+		Description: "Converts a string to a timestamp according to the format.",
+	},
+	{
+		Name:        "ARRAY_TO_JSON",
+		Arguments:   []string{"array anyarray", "[pretty boolean]"},
+		ReturnType:  "json",
+		Description: "Converts an SQL array to a JSON array.",
+	},
+	{
+		Name:        "JSON_ARRAY_ELEMENTS",
+		Arguments:   []string{"from_json json"},
+		ReturnType:  "setof json",
+		Description: "Expands the top-level JSON array into a set of JSON values.",
+	},
+	{
+		Name:        "JSONB_ARRAY_ELEMENTS",
+		Arguments:   []string{"from_json jsonb"},
+		ReturnType:  "setof jsonb",
+		Description: "Expands the top-level JSON array into a set of JSON values.",
+	},
+	{
+		Name:        "JSON_ARRAY_LENGTH",
+		Arguments:   []string{"from_json json"},
+		ReturnType:  "integer",
+		Description: "Number of elements in the top-level JSON array.",
+	},
+	{
+		Name:        "JSONB_ARRAY_LENGTH",
+		Arguments:   []string{"from_json jsonb"},
+		ReturnType:  "integer",
+		Description: "Number of elements in the top-level JSON array.",
+	},
+	{
+		Name:        "JSON_BUILD_ARRAY",
+		Arguments:   []string{"value \"any\"", "..."},
+		ReturnType:  "json",
+		Description: "Builds a JSON array out of a variadic argument list.",
+	},
+	{
+		Name:        "JSONB_BUILD_ARRAY",
+		Arguments:   []string{"value \"any\"", "..."},
+		ReturnType:  "jsonb",
+		Description: "Builds a JSON array out of a variadic argument list.",
+	},
+	{
+		Name:        "JSON_BUILD_OBJECT",
+		Arguments:   []string{"key \"any\"", "value \"any\"", "..."},
+		ReturnType:  "json",
+		Description: "Builds a JSON object out of a variadic argument list of alternating keys and values.",
+	},
+	{
+		Name:        "JSONB_BUILD_OBJECT",
+		Arguments:   []string{"key \"any\"", "value \"any\"", "..."},
+		ReturnType:  "jsonb",
+		Description: "Builds a JSON object out of a variadic argument list of alternating keys and values.",
+	},
+	{
+		Name:        "JSON_EACH",
+		Arguments:   []string{"from_json json"},
+		ReturnType:  "setof record",
+		Description: "Expands the top-level JSON object into a set of key/value pairs.",
+	},
+	{
+		Name:        "JSONB_EACH",
+		Arguments:   []string{"from_json jsonb"},
+		ReturnType:  "setof record",
+		Description: "Expands the top-level JSON object into a set of key/value pairs.",
+	},
+	{
+		Name:        "JSON_EXTRACT_PATH",
+		Arguments:   []string{"from_json json", "path_elem text", "..."},
+		ReturnType:  "json",
+		Description: "JSON sub-object at the specified path, equivalent to the #> operator.",
+	},
+	{
+		Name:        "JSONB_EXTRACT_PATH",
+		Arguments:   []string{"from_json jsonb", "path_elem text", "..."},
+		ReturnType:  "jsonb",
+		Description: "JSON sub-object at the specified path, equivalent to the #> operator.",
+	},
+	{
+		Name:        "JSON_EXTRACT_PATH_TEXT",
+		Arguments:   []string{"from_json json", "path_elem text", "..."},
+		ReturnType:  "text",
+		Description: "JSON sub-object at the specified path as text, equivalent to the #>> operator.",
+	},
+	{
+		Name:        "JSONB_EXTRACT_PATH_TEXT",
+		Arguments:   []string{"from_json jsonb", "path_elem text", "..."},
+		ReturnType:  "text",
+		Description: "JSON sub-object at the specified path as text, equivalent to the #>> operator.",
+	},
+	{
+		Name:        "JSON_OBJECT",
+		Arguments:   []string{"keys text[]", "values text[]"},
+		ReturnType:  "json",
+		Description: "Builds a JSON object out of the arrays of keys and values.",
+	},
+	{
+		Name:        "JSON_OBJECT_KEYS",
+		Arguments:   []string{"from_json json"},
+		ReturnType:  "setof text",
+		Description: "Set of keys in the top-level JSON object.",
+	},
+	{
+		Name:        "JSONB_OBJECT_KEYS",
+		Arguments:   []string{"from_json jsonb"},
+		ReturnType:  "setof text",
+		Description: "Set of keys in the top-level JSON object.",
+	},
+	{
+		Name:        "JSON_TYPEOF",
+		Arguments:   []string{"from_json json"},
+		ReturnType:  "text",
+		Description: "Type of the top-level JSON value as a text string.",
+	},
+	{
+		Name:        "JSONB_TYPEOF",
+		Arguments:   []string{"from_json jsonb"},
+		ReturnType:  "text",
+		Description: "Type of the top-level JSON value as a text string.",
+	},
+	{
+		Name:        "JSONB_INSERT",
+		Arguments:   []string{"target jsonb", "path text[]", "new_value jsonb", "[insert_after boolean]"},
+		ReturnType:  "jsonb",
+		Description: "Inserts new_value into target at the path.",
+	},
+	{
+		Name:        "JSONB_PATH_EXISTS",
+		Arguments:   []string{"target jsonb", "path jsonpath", "[vars jsonb]", "[silent boolean]"},
+		ReturnType:  "boolean",
+		Description: "Whether the JSON path returns any item for the JSON value.",
+	},
+	{
+		Name:        "JSONB_PATH_QUERY",
+		Arguments:   []string{"target jsonb", "path jsonpath", "[vars jsonb]", "[silent boolean]"},
+		ReturnType:  "setof jsonb",
+		Description: "All JSON items returned by the JSON path for the JSON value.",
+	},
+	{
+		Name:        "JSONB_PRETTY",
+		Arguments:   []string{"from_json jsonb"},
+		ReturnType:  "text",
+		Description: "Converts the JSON value to pretty-printed, indented text.",
+	},
+	{
+		Name:        "JSONB_SET",
+		Arguments:   []string{"target jsonb", "path text[]", "new_value jsonb", "[create_if_missing boolean]"},
+		ReturnType:  "jsonb",
+		Description: "Replaces the item at the path with new_value, or adds it if create_if_missing is true.",
+	},
+	{
+		Name:        "JSONB_STRIP_NULLS",
+		Arguments:   []string{"from_json jsonb"},
+		ReturnType:  "jsonb",
+		Description: "Deletes all object fields that have null values, recursively.",
+	},
+	{
+		Name:        "ROW_TO_JSON",
+		Arguments:   []string{"record record", "[pretty boolean]"},
+		ReturnType:  "json",
+		Description: "Converts an SQL composite value to a JSON object.",
+	},
+	{
+		Name:        "TO_JSON",
+		Arguments:   []string{"value anyelement"},
+		ReturnType:  "json",
+		Description: "Converts any SQL value to JSON.",
+	},
+	{
+		Name:        "TO_JSONB",
+		Arguments:   []string{"value anyelement"},
+		ReturnType:  "jsonb",
+		Description: "Converts any SQL value to JSON.",
+	},
+	{
+		Name:        "ARRAY_APPEND",
+		Arguments:   []string{"array anyarray", "element anyelement"},
+		ReturnType:  "anyarray",
+		Description: "Appends an element to the end of an array.",
+	},
+	{
+		Name:        "ARRAY_CAT",
+		Arguments:   []string{"array1 anyarray", "array2 anyarray"},
+		ReturnType:  "anyarray",
+		Description: "Concatenates two arrays.",
+	},
+	{
+		Name:        "ARRAY_DIMS",
+		Arguments:   []string{"array anyarray"},
+		ReturnType:  "text",
+		Description: "Text representation of the array's dimensions.",
+	},
+	{
+		Name:        "ARRAY_FILL",
+		Arguments:   []string{"value anyelement", "dimensions integer[]", "[lower_bounds integer[]]"},
+		ReturnType:  "anyarray",
+		Description: "Array filled with copies of the value, having the given dimensions.",
+	},
+	{
+		Name:        "ARRAY_LENGTH",
+		Arguments:   []string{"array anyarray", "dimension integer"},
+		ReturnType:  "integer",
+		Description: "Length of the requested array dimension.",
+	},
+	{
+		Name:        "ARRAY_LOWER",
+		Arguments:   []string{"array anyarray", "dimension integer"},
+		ReturnType:  "integer",
+		Description: "Lower bound of the requested array dimension.",
+	},
+	{
+		Name:        "ARRAY_NDIMS",
+		Arguments:   []string{"array anyarray"},
+		ReturnType:  "integer",
+		Description: "Number of dimensions of the array.",
+	},
+	{
+		Name:        "ARRAY_POSITION",
+		Arguments:   []string{"array anyarray", "element anyelement", "[start integer]"},
+		ReturnType:  "integer",
+		Description: "Subscript of the first occurrence of the element in the array, or NULL.",
+	},
+	{
+		Name:        "ARRAY_POSITIONS",
+		Arguments:   []string{"array anyarray", "element anyelement"},
+		ReturnType:  "integer[]",
+		Description: "Subscripts of all occurrences of the element in the array.",
+	},
+	{
+		Name:        "ARRAY_PREPEND",
+		Arguments:   []string{"element anyelement", "array anyarray"},
+		ReturnType:  "anyarray",
+		Description: "Prepends an element to the beginning of an array.",
+	},
+	{
+		Name:        "ARRAY_REMOVE",
+		Arguments:   []string{"array anyarray", "element anyelement"},
+		ReturnType:  "anyarray",
+		Description: "Removes all elements equal to the given value from the array.",
+	},
+	{
+		Name:        "ARRAY_REPLACE",
+		Arguments:   []string{"array anyarray", "from anyelement", "to anyelement"},
+		ReturnType:  "anyarray",
+		Description: "Replaces each array element equal to from with to.",
+	},
+	{
+		Name:        "ARRAY_TO_STRING",
+		Arguments:   []string{"array anyarray", "delimiter text", "[null_string text]"},
+		ReturnType:  "text",
+		Description: "Concatenates the array elements using the delimiter, representing nulls by null_string.",
+	},
+	{
+		Name:        "ARRAY_UPPER",
+		Arguments:   []string{"array anyarray", "dimension integer"},
+		ReturnType:  "integer",
+		Description: "Upper bound of the requested array dimension.",
+	},
+	{
+		Name:        "CARDINALITY",
+		Arguments:   []string{"array anyarray"},
+		ReturnType:  "integer",
+		Description: "Total number of elements in the array.",
+	},
+	{
+		Name:        "STRING_TO_ARRAY",
+		Arguments:   []string{"string text", "delimiter text", "[null_string text]"},
+		ReturnType:  "text[]",
+		Description: "Splits the string at occurrences of delimiter into an array.",
+	},
+	{
+		Name:        "UNNEST",
+		Arguments:   []string{"array anyarray"},
+		ReturnType:  "setof anyelement",
+		Description: "Expands the array into a set of rows.",
+	},
+	{
+		Name:        "GENERATE_SERIES",
+		Arguments:   []string{"start integer", "stop integer", "[step integer]"},
+		ReturnType:  "setof integer",
+		Description: "Series of values from start to stop, with a step size of step.",
+	},
+	{
+		Name:        "GENERATE_SUBSCRIPTS",
+		Arguments:   []string{"array anyarray", "dim integer"},
+		ReturnType:  "setof integer",
+		Description: "Series of the valid subscripts of the given dimension of the array.",
+	},
+	{
+		Name:        "CURRENT_DATABASE",
+		ReturnType:  "name",
+		Description: "Name of the current database.",
+	},
+	{
+		Name:        "CURRENT_SCHEMA",
+		ReturnType:  "name",
+		Description: "Name of the schema first in the search path.",
+	},
+	{
+		Name:        "CURRENT_SCHEMAS",
+		Arguments:   []string{"include_implicit boolean"},
+		ReturnType:  "name[]",
+		Description: "Names of the schemas in the effective search path.",
+	},
+	{
+		Name:        "CURRENT_SETTING",
+		Arguments:   []string{"setting_name text", "[missing_ok boolean]"},
+		ReturnType:  "text",
+		Description: "Current value of the setting.",
+	},
+	{
+		Name:        "SET_CONFIG",
+		Arguments:   []string{"setting_name text", "new_value text", "is_local boolean"},
+		ReturnType:  "text",
+		Description: "Sets the parameter to new_value, returning that value.",
+	},
+	{
+		Name:        "VERSION",
+		ReturnType:  "text",
+		Description: "Version string of the server.",
+	},
+	{
+		Name:        "PG_BACKEND_PID",
+		ReturnType:  "integer",
+		Description: "Process ID of the server process attached to the current session.",
+	},
+	{
+		Name:        "PG_CANCEL_BACKEND",
+		Arguments:   []string{"pid integer"},
+		ReturnType:  "boolean",
+		Description: "Cancels the current query of the session whose backend process has the given process ID.",
+	},
+	{
+		Name:        "PG_TERMINATE_BACKEND",
+		Arguments:   []string{"pid integer"},
+		ReturnType:  "boolean",
+		Description: "Terminates the session whose backend process has the given process ID.",
+	},
+	{
+		Name:        "PG_SLEEP",
+		Arguments:   []string{"seconds double precision"},
+		ReturnType:  "void",
+		Description: "Makes the current session's process sleep for the given number of seconds.",
+	},
+	{
+		Name:        "PG_TYPEOF",
+		Arguments:   []string{"value \"any\""},
+		ReturnType:  "regtype",
+		Description: "OID of the data type of the value.",
+	},
+	{
+		Name:        "PG_DATABASE_SIZE",
+		Arguments:   []string{"name name"},
+		ReturnType:  "bigint",
+		Description: "Total disk space used by the database with the specified name.",
+	},
+	{
+		Name:        "PG_INDEXES_SIZE",
+		Arguments:   []string{"relation regclass"},
+		ReturnType:  "bigint",
+		Description: "Total disk space used by indexes attached to the table.",
+	},
+	{
+		Name:        "PG_RELATION_SIZE",
+		Arguments:   []string{"relation regclass", "[fork text]"},
+		ReturnType:  "bigint",
+		Description: "Disk space used by one fork of the relation, the main data fork by default.",
+	},
+	{
+		Name:        "PG_SIZE_PRETTY",
+		Arguments:   []string{"size bigint"},
+		ReturnType:  "text",
+		Description: "Converts a size in bytes into a human-readable format with size units.",
+	},
+	{
+		Name:        "PG_TABLE_SIZE",
+		Arguments:   []string{"relation regclass"},
+		ReturnType:  "bigint",
+		Description: "Disk space used by the table, excluding indexes but including its TOAST table and free space map.",
+	},
+	{
+		Name:        "PG_TOTAL_RELATION_SIZE",
+		Arguments:   []string{"relation regclass"},
+		ReturnType:  "bigint",
+		Description: "Total disk space used by the table, including all indexes and TOAST data.",
+	},
+	{
+		Name:        "COL_DESCRIPTION",
+		Arguments:   []string{"table oid", "column integer"},
+		ReturnType:  "text",
+		Description: "Comment for the table column.",
+	},
+	{
+		Name:        "OBJ_DESCRIPTION",
+		Arguments:   []string{"object oid", "catalog name"},
+		ReturnType:  "text",
+		Description: "Comment for the database object.",
+	},
+	{
+		Name:        "CURRVAL",
+		Arguments:   []string{"regclass regclass"},
+		ReturnType:  "bigint",
+		Description: "Value most recently obtained by nextval for the sequence in the current session.",
+	},
+	{
+		Name:        "LASTVAL",
+		ReturnType:  "bigint",
+		Description: "Value most recently returned by nextval in the current session.",
+	},
+	{
+		Name:        "NEXTVAL",
+		Arguments:   []string{"regclass regclass"},
+		ReturnType:  "bigint",
+		Description: "Advances the sequence and returns the new value.",
+	},
+	{
+		Name:        "SETVAL",
+		Arguments:   []string{"regclass regclass", "value bigint", "[is_called boolean]"},
+		ReturnType:  "bigint",
+		Description: "Sets the sequence's current value.",
+	},
+	{
+		Name:        "GEN_RANDOM_UUID",
+		ReturnType:  "uuid",
+		Description: "Version 4 (random) UUID.",
+	},
+	{
+		Name:        "TXID_CURRENT",
+		ReturnType:  "bigint",
+		Description: "Current transaction's ID, assigning a new one if the current transaction does not have one.",
+	},
+	{
+		Name:        "PLAINTO_TSQUERY",
+		Arguments:   []string{"[config regconfig]", "query text"},
+		ReturnType:  "tsquery",
+		Description: "Converts text to a tsquery, ignoring punctuation and combining the words with AND.",
+	},
+	{
+		Name:        "TO_TSQUERY",
+		Arguments:   []string{"[config regconfig]", "query text"},
+		ReturnType:  "tsquery",
+		Description: "Converts text to a tsquery, normalizing words according to the configuration.",
+	},
+	{
+		Name:        "TO_TSVECTOR",
+		Arguments:   []string{"[config regconfig]", "document text"},
+		ReturnType:  "tsvector",
+		Description: "Converts text to a tsvector, normalizing words according to the configuration.",
+	},
+	{
+		Name:        "TS_RANK",
+		Arguments:   []string{"vector tsvector", "query tsquery", "[normalization integer]"},
+		ReturnType:  "real",
+		Description: "Ranks the document for the query, based on the frequency of matching lexemes.",
+	},
+	{
+		Name:        "WEBSEARCH_TO_TSQUERY",
+		Arguments:   []string{"[config regconfig]", "query text"},
+		ReturnType:  "tsquery",
+		Description: "Converts text to a tsquery in the syntax of web search engines.",
+	},
+}
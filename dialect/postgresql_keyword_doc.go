@@ -0,0 +1,192 @@
+package dialect
+
+var postgresqlKeywordDocs = []*KeywordDesc{
+	{
+		Name: "SELECT",
+		Syntax: `[WITH [RECURSIVE] with_query [, ...]]
+SELECT [ALL | DISTINCT [ON (expression [, ...])]]
+    [* | expression [[AS] output_name] [, ...]]
+    [FROM from_item [, ...]]
+    [WHERE condition]
+    [GROUP BY grouping_element [, ...]]
+    [HAVING condition]
+    [WINDOW window_name AS (window_definition) [, ...]]
+    [{UNION | INTERSECT | EXCEPT} [ALL | DISTINCT] select]
+    [ORDER BY expression [ASC | DESC | USING operator] [NULLS {FIRST | LAST}] [, ...]]
+    [LIMIT {count | ALL}]
+    [OFFSET start [ROW | ROWS]]
+    [FOR {UPDATE | NO KEY UPDATE | SHARE | KEY SHARE} [OF table_name [, ...]] [NOWAIT | SKIP LOCKED]]`,
+		Description: "Retrieves rows from zero or more tables.",
+	},
+	{
+		Name: "INSERT",
+		Syntax: `[WITH [RECURSIVE] with_query [, ...]]
+INSERT INTO table_name [AS alias] [(column_name [, ...])]
+    {DEFAULT VALUES | VALUES ({expression | DEFAULT} [, ...]) [, ...] | query}
+    [ON CONFLICT [conflict_target] conflict_action]
+    [RETURNING * | output_expression [[AS] output_name] [, ...]]`,
+		Description: "Inserts new rows into a table, from value expressions or from the result of a query.",
+	},
+	{
+		Name: "INSERT INTO",
+		Syntax: `INSERT INTO table_name [AS alias] [(column_name [, ...])]
+    {DEFAULT VALUES | VALUES ({expression | DEFAULT} [, ...]) [, ...] | query}
+    [ON CONFLICT [conflict_target] conflict_action]
+    [RETURNING * | output_expression [[AS] output_name] [, ...]]`,
+		Description: "Inserts new rows into a table.",
+	},
+	{
+		Name: "ON CONFLICT",
+		Syntax: `ON CONFLICT [conflict_target] conflict_action
+
+conflict_target:
+    (index_column_name [, ...]) [WHERE index_predicate]
+    ON CONSTRAINT constraint_name
+
+conflict_action:
+    DO NOTHING
+    DO UPDATE SET column_name = {expression | DEFAULT} [, ...] [WHERE condition]`,
+		Description: "Specifies an alternative action to raising a unique violation or exclusion constraint violation error. DO UPDATE updates the existing row, and the row proposed for insertion is available as EXCLUDED.",
+	},
+	{
+		Name:        "RETURNING",
+		Syntax:      `RETURNING * | output_expression [[AS] output_name] [, ...]`,
+		Description: "Computes and returns values based on each row actually inserted, updated or deleted.",
+	},
+	{
+		Name: "UPDATE",
+		Syntax: `[WITH [RECURSIVE] with_query [, ...]]
+UPDATE [ONLY] table_name [*] [[AS] alias]
+    SET {column_name = {expression | DEFAULT} | (column_name [, ...]) = (sub-SELECT)} [, ...]
+    [FROM from_item [, ...]]
+    [WHERE condition | WHERE CURRENT OF cursor_name]
+    [RETURNING * | output_expression [[AS] output_name] [, ...]]`,
+		Description: "Changes the values of the specified columns in all rows that satisfy the condition. Other tables can be referred to in the FROM clause.",
+	},
+	{
+		Name: "DELETE",
+		Syntax: `[WITH [RECURSIVE] with_query [, ...]]
+DELETE FROM [ONLY] table_name [*] [[AS] alias]
+    [USING from_item [, ...]]
+    [WHERE condition | WHERE CURRENT OF cursor_name]
+    [RETURNING * | output_expression [[AS] output_name] [, ...]]`,
+		Description: "Deletes rows that satisfy the WHERE clause from the table. Without WHERE, all rows are deleted.",
+	},
+	{
+		Name:        "DELETE FROM",
+		Syntax:      `DELETE FROM [ONLY] table_name [[AS] alias] [USING from_item [, ...]] [WHERE condition] [RETURNING ...]`,
+		Description: "Deletes rows that satisfy the WHERE clause from the table.",
+	},
+	{
+		Name:        "FROM",
+		Syntax:      `FROM from_item [, ...]`,
+		Description: "Specifies one or more source tables for the SELECT. If multiple sources are specified, the result is the Cartesian product of all the sources.",
+	},
+	{
+		Name:        "WHERE",
+		Syntax:      `WHERE condition`,
+		Description: "Eliminates the rows that do not satisfy the condition.",
+	},
+	{
+		Name:        "GROUP BY",
+		Syntax:      `GROUP BY grouping_element [, ...]`,
+		Description: "Condenses into a single row all selected rows that share the same values for the grouped expressions. ROLLUP, CUBE and GROUPING SETS produce several groupings at once.",
+	},
+	{
+		Name:        "HAVING",
+		Syntax:      `HAVING condition`,
+		Description: "Eliminates group rows that do not satisfy the condition.",
+	},
+	{
+		Name:        "ORDER BY",
+		Syntax:      `ORDER BY expression [ASC | DESC | USING operator] [NULLS {FIRST | LAST}] [, ...]`,
+		Description: "Sorts the result rows according to the expressions.",
+	},
+	{
+		Name:        "LIMIT",
+		Syntax:      `LIMIT {count | ALL}`,
+		Description: "Returns at most count rows.",
+	},
+	{
+		Name:        "OFFSET",
+		Syntax:      `OFFSET start [ROW | ROWS]`,
+		Description: "Skips the start rows before beginning to return rows.",
+	},
+	{
+		Name: "JOIN",
+		Syntax: `from_item [NATURAL] join_type from_item [ON join_condition | USING (join_column [, ...])]
+
+join_type:
+    [INNER] JOIN
+    LEFT [OUTER] JOIN
+    RIGHT [OUTER] JOIN
+    FULL [OUTER] JOIN
+    CROSS JOIN`,
+		Description: "Combines the rows of two from items.",
+	},
+	{
+		Name:        "INNER JOIN",
+		Syntax:      `from_item INNER JOIN from_item {ON join_condition | USING (join_column [, ...])}`,
+		Description: "Produces the combined rows of both items that satisfy the join condition.",
+	},
+	{
+		Name:        "LEFT JOIN",
+		Syntax:      `from_item LEFT [OUTER] JOIN from_item {ON join_condition | USING (join_column [, ...])}`,
+		Description: "Produces all rows of the left item, with null values for the right-hand columns of the rows without a match.",
+	},
+	{
+		Name:        "RIGHT JOIN",
+		Syntax:      `from_item RIGHT [OUTER] JOIN from_item {ON join_condition | USING (join_column [, ...])}`,
+		Description: "Produces all rows of the right item, with null values for the left-hand columns of the rows without a match.",
+	},
+	{
+		Name:        "LATERAL",
+		Syntax:      `[LATERAL] (select) [AS] alias`,
+		Description: "Allows a sub-SELECT in FROM to refer to columns of the FROM items that appear before it.",
+	},
+	{
+		Name:        "UNION",
+		Syntax:      `select_statement UNION [ALL | DISTINCT] select_statement`,
+		Description: "Computes the set union of the rows returned by the selects. Duplicate rows are eliminated unless ALL is specified.",
+	},
+	{
+		Name:        "WITH",
+		Syntax:      `WITH [RECURSIVE] with_query_name [(column_name [, ...])] AS [[NOT] MATERIALIZED] (select | values | insert | update | delete) [, ...]`,
+		Description: "Specifies subqueries that can be referenced by name in the primary query. RECURSIVE allows a query to refer to its own output.",
+	},
+	{
+		Name:        "DISTINCT ON",
+		Syntax:      `SELECT DISTINCT ON (expression [, ...]) ...`,
+		Description: "Keeps only the first row of each set of rows where the given expressions evaluate to equal. ORDER BY decides which row is the first.",
+	},
+	{
+		Name: "CASE",
+		Syntax: `CASE WHEN condition THEN result [WHEN ...] [ELSE result] END
+CASE expression WHEN value THEN result [WHEN ...] [ELSE result] END`,
+		Description: "A generic conditional expression. Returns the result of the first WHEN that is true, or the ELSE result, or null.",
+	},
+	{
+		Name: "CREATE TABLE",
+		Syntax: `CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP} | UNLOGGED] TABLE [IF NOT EXISTS] table_name (
+    [{column_name data_type [COLLATE collation] [column_constraint [...]] | table_constraint | LIKE source_table [like_option ...]} [, ...]]
+)
+[INHERITS (parent_table [, ...])]
+[PARTITION BY {RANGE | LIST | HASH} ({column_name | (expression)} [, ...])]`,
+		Description: "Creates a new, initially empty table.",
+	},
+	{
+		Name:        "ALTER TABLE",
+		Syntax:      `ALTER TABLE [IF EXISTS] [ONLY] name [*] action [, ...]`,
+		Description: "Changes the definition of an existing table.",
+	},
+	{
+		Name:        "TRUNCATE",
+		Syntax:      `TRUNCATE [TABLE] [ONLY] name [*] [, ...] [RESTART IDENTITY | CONTINUE IDENTITY] [CASCADE | RESTRICT]`,
+		Description: "Quickly removes all rows from a set of tables.",
+	},
+	{
+		Name:        "EXPLAIN",
+		Syntax:      `EXPLAIN [(option [, ...])] statement`,
+		Description: "Shows the execution plan that the planner generates for the statement. ANALYZE executes the statement and shows the actual run times.",
+	},
+}
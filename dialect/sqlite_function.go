@@ -0,0 +1,611 @@
+package dialect
+
+var sqliteFunctions = []*FunctionDesc{
+	{
+		Name:        "ABS",
+		Arguments:   []string{"X"},
+		ReturnType:  "numeric",
+		Description: "Absolute value of the numeric argument.",
+	},
+	{
+		Name:        "CHANGES",
+		ReturnType:  "integer",
+		Description: "Number of rows modified, inserted or deleted by the most recently completed statement.",
+	},
+	{
+		Name:        "CHAR",
+		Arguments:   []string{"X1", "X2", "..."},
+		ReturnType:  "text",
+		Description: "String composed of characters having the unicode code point values of the arguments.",
+	},
+	{
+		Name:        "COALESCE",
+		Arguments:   []string{"X", "Y", "..."},
+		ReturnType:  "any",
+		Description: "Copy of the first non-NULL argument.",
+	},
+	{
+		Name:        "FORMAT",
+		Arguments:   []string{"FORMAT", "..."},
+		ReturnType:  "text",
+		Description: "Formats the arguments like the printf() function from the standard C library.",
+	},
+	{
+		Name:        "GLOB",
+		Arguments:   []string{"X", "Y"},
+		ReturnType:  "integer",
+		Description: "Equivalent to the expression \"Y GLOB X\".",
+	},
+	{
+		Name:        "HEX",
+		Arguments:   []string{"X"},
+		ReturnType:  "text",
+		Description: "Upper-case hexadecimal rendering of the content of the argument.",
+	},
+	{
+		Name:        "IFNULL",
+		Arguments:   []string{"X", "Y"},
+		ReturnType:  "any",
+		Description: "Copy of the first non-NULL argument, or NULL if both arguments are NULL.",
+	},
+	{
+		Name:        "IIF",
+		Arguments:   []string{"X", "Y", "Z"},
+		ReturnType:  "any",
+		Description: "Y if X is true, and Z otherwise.",
+	},
+	{
+		Name:        "INSTR",
+		Arguments:   []string{"X", "Y"},
+		ReturnType:  "integer",
+		Description: "Character position of the first occurrence of Y within X, or 0.",
+	},
+	{
+		Name:        "LAST_INSERT_ROWID",
+		ReturnType:  "integer",
+		Description: "ROWID of the last row inserted from the database connection.",
+	},
+	{
+		Name:        "LENGTH",
+		Arguments:   []string{"X"},
+		ReturnType:  "integer",
+		Description: "Number of characters of a string, or bytes of a blob.",
+	},
+	{
+		Name:        "LIKE",
+		Arguments:   []string{"X", "Y", "[Z]"},
+		ReturnType:  "integer",
+		Description: "Equivalent to the expression \"Y LIKE X [ESCAPE Z]\".",
+	},
+	{
+		Name:        "LIKELIHOOD",
+		Arguments:   []string{"X", "Y"},
+		ReturnType:  "any",
+		Description: "X unchanged, with the hint that X is true with probability Y.",
+	},
+	{
+		Name:        "LIKELY",
+		Arguments:   []string{"X"},
+		ReturnType:  "any",
+		Description: "X unchanged, with the hint that X is usually true.",
+	},
+	{
+		Name:        "LOWER",
+		Arguments:   []string{"X"},
+		ReturnType:  "text",
+		Description: "Copy of the string with all ASCII characters converted to lower case.",
+	},
+	{
+		Name:        "LTRIM",
+		Arguments:   []string{"X", "[Y]"},
+		ReturnType:  "text",
+		Description: "Removes the characters in Y, spaces by default, from the left side of X.",
+	},
+	{
+		Name:        "MAX",
+		Arguments:   []string{"X", "Y", "..."},
+		ReturnType:  "any",
+		Description: "Argument with the maximum value.",
+	},
+	{
+		Name:        "MIN",
+		Arguments:   []string{"X", "Y", "..."},
+		ReturnType:  "any",
+		Description: "Argument with the minimum value.",
+	},
+	{
+		Name:        "NULLIF",
+		Arguments:   []string{"X", "Y"},
+		ReturnType:  "any",
+		Description: "X if the arguments are different, NULL if they are the same.",
+	},
+	{
+		Name:        "PRINTF",
+		Arguments:   []string{"FORMAT", "..."},
+		ReturnType:  "text",
+		Description: "Formats the arguments like the printf() function from the standard C library.",
+	},
+	{
+		Name:        "QUOTE",
+		Arguments:   []string{"X"},
+		ReturnType:  "text",
+		Description: "Text of an SQL literal which is the value of the argument.",
+	},
+	{
+		Name:        "RANDOM",
+		ReturnType:  "integer",
+		Description: "Pseudo-random integer between -9223372036854775808 and +9223372036854775807.",
+	},
+	{
+		Name:        "RANDOMBLOB",
+		Arguments:   []string{"N"},
+		ReturnType:  "blob",
+		Description: "N-byte blob containing pseudo-random bytes.",
+	},
+	{
+		Name:        "REPLACE",
+		Arguments:   []string{"X", "Y", "Z"},
+		ReturnType:  "text",
+		Description: "String formed by substituting Z for every occurrence of Y in X.",
+	},
+	{
+		Name:        "ROUND",
+		Arguments:   []string{"X", "[Y]"},
+		ReturnType:  "real",
+		Description: "X rounded off to Y digits to the right of the decimal point, 0 by default.",
+	},
+	{
+		Name:        "RTRIM",
+		Arguments:   []string{"X", "[Y]"},
+		ReturnType:  "text",
+		Description: "Removes the characters in Y, spaces by default, from the right side of X.",
+	},
+	{
+		Name:        "SIGN",
+		Arguments:   []string{"X"},
+		ReturnType:  "integer",
+		Description: "-1, 0 or +1 if the argument is negative, zero or positive.",
+	},
+	{
+		Name:        "SOUNDEX",
+		Arguments:   []string{"X"},
+		ReturnType:  "text",
+		Description: "Soundex encoding of the string.",
+	},
+	{
+		Name:        "SQLITE_VERSION",
+		ReturnType:  "text",
+		Description: "Version string of the SQLite library.",
+	},
+	{
+		Name:        "SUBSTR",
+		Arguments:   []string{"X", "Y", "[Z]"},
+		ReturnType:  "text",
+		Description: "Substring of X that begins with the Y-th character and which is Z characters long.",
+	},
+	{
+		Name:        "SUBSTRING",
+		Arguments:   []string{"X", "Y", "[Z]"},
+		ReturnType:  "text",
+		Description: "Substring of X that begins with the Y-th character and which is Z characters long.",
+	},
+	{
+		Name:        "TOTAL_CHANGES",
+		ReturnType:  "integer",
+		Description: "Number of rows modified, inserted or deleted since the database connection was opened.",
+	},
+	{
+		Name:        "TRIM",
+		Arguments:   []string{"X", "[Y]"},
+		ReturnType:  "text",
+		Description: "Removes the characters in Y, spaces by default, from both ends of X.",
+	},
+	{
+		Name:        "TYPEOF",
+		Arguments:   []string{"X"},
+		ReturnType:  "text",
+		Description: "Datatype of the expression, \"null\", \"integer\", \"real\", \"text\" or \"blob\".",
+	},
+	{
+		Name:        "UNICODE",
+		Arguments:   []string{"X"},
+		ReturnType:  "integer",
+		Description: "Unicode code point of the first character of the string.",
+	},
+	{
+		Name:        "UNLIKELY",
+		Arguments:   []string{"X"},
+		ReturnType:  "any",
+		Description: "X unchanged, with the hint that X is usually not true.",
+	},
+	{
+		Name:        "UPPER",
+		Arguments:   []string{"X"},
+		ReturnType:  "text",
+		Description: "Copy of the string with all ASCII characters converted to upper case.",
+	},
+	{
+		Name:        "ZEROBLOB",
+		Arguments:   []string{"N"},
+		ReturnType:  "blob",
+		Description: "Blob consisting of N bytes of 0x00.",
+	},
+	{
+		Name:        "AVG",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Average value of all non-NULL X within a group.",
+	},
+	{
+		Name:        "COUNT",
+		Arguments:   []string{"*"},
+		ReturnType:  "integer",
+		Description: "Number of rows in the group.",
+	},
+	{
+		Name:        "COUNT",
+		Arguments:   []string{"X"},
+		ReturnType:  "integer",
+		Description: "Number of times that X is not NULL in a group.",
+	},
+	{
+		Name:        "GROUP_CONCAT",
+		Arguments:   []string{"X", "[Y]"},
+		ReturnType:  "text",
+		Description: "Concatenation of all non-NULL values of X, separated by Y, a comma by default.",
+	},
+	{
+		Name:        "SUM",
+		Arguments:   []string{"X"},
+		ReturnType:  "numeric",
+		Description: "Sum of all non-NULL values in the group, or NULL if there are none.",
+	},
+	{
+		Name:        "TOTAL",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Sum of all non-NULL values in the group, or 0.0 if there are none.",
+	},
+	{
+		Name:        "DATE",
+		Arguments:   []string{"time_value", "[modifier]", "..."},
+		ReturnType:  "text",
+		Description: "Date as text in the format YYYY-MM-DD.",
+	},
+	{
+		Name:        "DATETIME",
+		Arguments:   []string{"time_value", "[modifier]", "..."},
+		ReturnType:  "text",
+		Description: "Date and time as text in the format YYYY-MM-DD HH:MM:SS.",
+	},
+	{
+		Name:        "JULIANDAY",
+		Arguments:   []string{"time_value", "[modifier]", "..."},
+		ReturnType:  "real",
+		Description: "Julian day number, fractional days since noon in Greenwich on November 24, 4714 B.C.",
+	},
+	{
+		Name:        "STRFTIME",
+		Arguments:   []string{"format", "time_value", "[modifier]", "..."},
+		ReturnType:  "text",
+		Description: "Date formatted according to the format string.",
+	},
+	{
+		Name:        "TIME",
+		Arguments:   []string{"time_value", "[modifier]", "..."},
+		ReturnType:  "text",
+		Description: "Time as text in the format HH:MM:SS.",
+	},
+	{
+		Name:        "UNIXEPOCH",
+		Arguments:   []string{"time_value", "[modifier]", "..."},
+		ReturnType:  "integer",
+		Description: "Unix timestamp, the number of seconds since 1970-01-01 00:00:00 UTC.",
+	},
+	{
+		Name:        "ROW_NUMBER",
+		ReturnType:  "integer",
+		Description: "Number of the row within the current partition, counting from 1.",
+	},
+	{
+		Name:        "RANK",
+		ReturnType:  "integer",
+		Description: "Row number of the first peer in each group, with gaps.",
+	},
+	{
+		Name:        "DENSE_RANK",
+		ReturnType:  "integer",
+		Description: "Number of the current row's peer group within its partition, without gaps.",
+	},
+	{
+		Name:        "PERCENT_RANK",
+		ReturnType:  "real",
+		Description: "(rank - 1) / (partition rows - 1).",
+	},
+	{
+		Name:        "CUME_DIST",
+		ReturnType:  "real",
+		Description: "Cumulative distribution, row number of the last peer / partition rows.",
+	},
+	{
+		Name:        "NTILE",
+		Arguments:   []string{"N"},
+		ReturnType:  "integer",
+		Description: "Number of the group, from 1 to N, the current row belongs to when the partition is divided into N groups.",
+	},
+	{
+		Name:        "LAG",
+		Arguments:   []string{"expr", "[offset]", "[default]"},
+		ReturnType:  "any",
+		Description: "expr evaluated against the row offset rows before the current row within the partition.",
+	},
+	{
+		Name:        "LEAD",
+		Arguments:   []string{"expr", "[offset]", "[default]"},
+		ReturnType:  "any",
+		Description: "expr evaluated against the row offset rows after the current row within the partition.",
+	},
+	{
+		Name:        "FIRST_VALUE",
+		Arguments:   []string{"expr"},
+		ReturnType:  "any",
+		Description: "expr evaluated against the first row in the window frame.",
+	},
+	{
+		Name:        "LAST_VALUE",
+		Arguments:   []string{"expr"},
+		ReturnType:  "any",
+		Description: "expr evaluated against the last row in the window frame.",
+	},
+	{
+		Name:        "NTH_VALUE",
+		Arguments:   []string{"expr", "N"},
+		ReturnType:  "any",
+		Description: "expr evaluated against the row N of the window frame.",
+	},
+	{
+		Name:        "ACOS",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Arccosine of X, in radians.",
+	},
+	{
+		Name:        "ASIN",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Arcsine of X, in radians.",
+	},
+	{
+		Name:        "ATAN",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Arctangent of X, in radians.",
+	},
+	{
+		Name:        "ATAN2",
+		Arguments:   []string{"Y", "X"},
+		ReturnType:  "real",
+		Description: "Arctangent of Y/X, in radians.",
+	},
+	{
+		Name:        "CEIL",
+		Arguments:   []string{"X"},
+		ReturnType:  "numeric",
+		Description: "First representable integer value greater than or equal to X.",
+	},
+	{
+		Name:        "CEILING",
+		Arguments:   []string{"X"},
+		ReturnType:  "numeric",
+		Description: "First representable integer value greater than or equal to X.",
+	},
+	{
+		Name:        "COS",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Cosine of X, in radians.",
+	},
+	{
+		Name:        "DEGREES",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Converts X from radians into degrees.",
+	},
+	{
+		Name:        "EXP",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "e raised to the power X.",
+	},
+	{
+		Name:        "FLOOR",
+		Arguments:   []string{"X"},
+		ReturnType:  "numeric",
+		Description: "First representable integer value less than or equal to X.",
+	},
+	{
+		Name:        "LN",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Natural logarithm of X.",
+	},
+	{
+		Name:        "LOG",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Base-10 logarithm of X.",
+	},
+	{
+		Name:        "LOG",
+		Arguments:   []string{"B", "X"},
+		ReturnType:  "real",
+		Description: "Base-B logarithm of X.",
+	},
+	{
+		Name:        "LOG10",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Base-10 logarithm of X.",
+	},
+	{
+		Name:        "LOG2",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Logarithm base-2 of X.",
+	},
+	{
+		Name:        "MOD",
+		Arguments:   []string{"X", "Y"},
+		ReturnType:  "numeric",
+		Description: "Remainder after dividing X by Y.",
+	},
+	{
+		Name:        "PI",
+		ReturnType:  "real",
+		Description: "Approximation for π.",
+	},
+	{
+		Name:        "POW",
+		Arguments:   []string{"X", "Y"},
+		ReturnType:  "real",
+		Description: "X raised to the Y-th power.",
+	},
+	{
+		Name:        "POWER",
+		Arguments:   []string{"X", "Y"},
+		ReturnType:  "real",
+		Description: "X raised to the Y-th power.",
+	},
+	{
+		Name:        "RADIANS",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Converts X from degrees into radians.",
+	},
+	{
+		Name:        "SIN",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Sine of X, in radians.",
+	},
+	{
+		Name:        "SQRT",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Square root of X.",
+	},
+	{
+		Name:        "TAN",
+		Arguments:   []string{"X"},
+		ReturnType:  "real",
+		Description: "Tangent of X, in radians.",
+	},
+	{
+		Name:        "TRUNC",
+		Arguments:   []string{"X"},
+		ReturnType:  "numeric",
+		Description: "Representable integer in between X and 0 that is furthest away from zero.",
+	},
+	{
+		Name:        "JSON",
+		Arguments:   []string{"json"},
+		ReturnType:  "text",
+		Description: "Minified version of the JSON string.",
+	},
+	{
+		Name:        "JSON_ARRAY",
+		Arguments:   []string{"value", "..."},
+		ReturnType:  "text",
+		Description: "Well-formed JSON array composed from the arguments.",
+	},
+	{
+		Name:        "JSON_ARRAY_LENGTH",
+		Arguments:   []string{"json", "[path]"},
+		ReturnType:  "integer",
+		Description: "Number of elements in the JSON array, at path if given.",
+	},
+	{
+		Name:        "JSON_EACH",
+		Arguments:   []string{"json", "[path]"},
+		ReturnType:  "table",
+		Description: "One row for each element of the JSON array or object, at path if given.",
+	},
+	{
+		Name:        "JSON_EXTRACT",
+		Arguments:   []string{"json", "path", "..."},
+		ReturnType:  "any",
+		Description: "One or more values extracted from the JSON at the paths.",
+	},
+	{
+		Name:        "JSON_GROUP_ARRAY",
+		Arguments:   []string{"value"},
+		ReturnType:  "text",
+		Description: "JSON array composed of all values in the aggregation.",
+	},
+	{
+		Name:        "JSON_GROUP_OBJECT",
+		Arguments:   []string{"name", "value"},
+		ReturnType:  "text",
+		Description: "JSON object composed of all name/value pairs in the aggregation.",
+	},
+	{
+		Name:        "JSON_INSERT",
+		Arguments:   []string{"json", "path", "value", "..."},
+		ReturnType:  "text",
+		Description: "Copy of the JSON with the values inserted at the paths, without overwriting.",
+	},
+	{
+		Name:        "JSON_OBJECT",
+		Arguments:   []string{"label", "value", "..."},
+		ReturnType:  "text",
+		Description: "Well-formed JSON object composed from pairs of arguments.",
+	},
+	{
+		Name:        "JSON_PATCH",
+		Arguments:   []string{"target", "patch"},
+		ReturnType:  "text",
+		Description: "Result of applying the RFC-7396 MergePatch to the target.",
+	},
+	{
+		Name:        "JSON_QUOTE",
+		Arguments:   []string{"value"},
+		ReturnType:  "text",
+		Description: "JSON representation of the SQL value.",
+	},
+	{
+		Name:        "JSON_REMOVE",
+		Arguments:   []string{"json", "path", "..."},
+		ReturnType:  "text",
+		Description: "Copy of the JSON with the elements at the paths removed.",
+	},
+	{
+		Name:        "JSON_REPLACE",
+		Arguments:   []string{"json", "path", "value", "..."},
+		ReturnType:  "text",
+		Description: "Copy of the JSON with the values at the paths overwritten, without creating them.",
+	},
+	{
+		Name:        "JSON_SET",
+		Arguments:   []string{"json", "path", "value", "..."},
+		ReturnType:  "text",
+		Description: "Copy of the JSON with the values at the paths overwritten or created.",
+	},
+	{
+		Name:        "JSON_TREE",
+		Arguments:   []string{"json", "[path]"},
+		ReturnType:  "table",
+		Description: "One row for each element of the JSON, walking it recursively from path if given.",
+	},
+	{
+		Name:        "JSON_TYPE",
+		Arguments:   []string{"json", "[path]"},
+		ReturnType:  "text",
+		Description: "Type of the outermost element of the JSON, at path if given.",
+	},
+	{
+		Name:        "JSON_VALID",
+		Arguments:   []string{"json"},
+		ReturnType:  "integer",
+		Description: "1 if the argument is well-formed JSON, 0 otherwise.",
+	},
+}
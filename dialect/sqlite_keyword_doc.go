@@ -0,0 +1,157 @@
+package dialect
+
+var sqliteKeywordDocs = []*KeywordDesc{
+	{
+		Name: "SELECT",
+		Syntax: `[WITH [RECURSIVE] common-table-expression, ...]
+SELECT [DISTINCT | ALL] result-column, ...
+    [FROM {table-or-subquery, ... | join-clause}]
+    [WHERE expr]
+    [GROUP BY expr, ... [HAVING expr]]
+    [WINDOW window-name AS window-defn, ...]
+    [compound-operator select-core] ...
+    [ORDER BY ordering-term, ...]
+    [LIMIT expr [{OFFSET | ,} expr]]`,
+		Description: "Queries the database. The result of a SELECT is zero or more rows of data where each row has a fixed number of columns.",
+	},
+	{
+		Name: "INSERT",
+		Syntax: `[WITH [RECURSIVE] common-table-expression, ...]
+{INSERT | REPLACE | INSERT OR {REPLACE | ROLLBACK | ABORT | FAIL | IGNORE}}
+    INTO [schema-name.]table-name [AS alias] [(column-name, ...)]
+    {VALUES (expr, ...), ... | select-stmt | DEFAULT VALUES}
+    [upsert-clause]
+    [returning-clause]`,
+		Description: "Creates new rows in a table, from a list of values, from the result of a SELECT or with the default values.",
+	},
+	{
+		Name: "INSERT INTO",
+		Syntax: `INSERT INTO [schema-name.]table-name [AS alias] [(column-name, ...)]
+    {VALUES (expr, ...), ... | select-stmt | DEFAULT VALUES}
+    [upsert-clause]`,
+		Description: "Creates new rows in a table.",
+	},
+	{
+		Name: "ON CONFLICT",
+		Syntax: `ON CONFLICT [(indexed-column, ...) [WHERE expr]]
+    DO {NOTHING | UPDATE SET {column-name | column-name-list} = expr, ... [WHERE expr]}`,
+		Description: "The upsert clause. Causes the INSERT to behave as an UPDATE or a no-op if the insert would violate a uniqueness constraint. The row proposed for insertion is available as excluded.",
+	},
+	{
+		Name:        "INSERT OR REPLACE",
+		Syntax:      `INSERT OR REPLACE INTO table-name [(column-name, ...)] VALUES (expr, ...)`,
+		Description: "Deletes the pre-existing rows that cause a UNIQUE or PRIMARY KEY constraint violation before inserting the current row.",
+	},
+	{
+		Name:        "RETURNING",
+		Syntax:      `RETURNING {* | expr [[AS] column-alias]}, ...`,
+		Description: "Causes an INSERT, UPDATE or DELETE statement to return the rows it modified. Available as of SQLite 3.35.0.",
+	},
+	{
+		Name: "UPDATE",
+		Syntax: `[WITH [RECURSIVE] common-table-expression, ...]
+UPDATE [OR {ROLLBACK | ABORT | REPLACE | FAIL | IGNORE}] qualified-table-name
+    SET {column-name | column-name-list} = expr, ...
+    [FROM {table-or-subquery, ... | join-clause}]
+    [WHERE expr]
+    [returning-clause]`,
+		Description: "Modifies a subset of the values stored in zero or more rows of the table. Without WHERE, all rows are modified.",
+	},
+	{
+		Name: "DELETE",
+		Syntax: `[WITH [RECURSIVE] common-table-expression, ...]
+DELETE FROM qualified-table-name
+    [WHERE expr]
+    [returning-clause]`,
+		Description: "Deletes rows from the table. Without WHERE, all rows are deleted.",
+	},
+	{
+		Name:        "DELETE FROM",
+		Syntax:      `DELETE FROM qualified-table-name [WHERE expr] [returning-clause]`,
+		Description: "Deletes rows from the table.",
+	},
+	{
+		Name:        "FROM",
+		Syntax:      `FROM {table-or-subquery, ... | join-clause}`,
+		Description: "Specifies the input data of a simple SELECT. Without FROM, the input is a single row with zero columns.",
+	},
+	{
+		Name:        "WHERE",
+		Syntax:      `WHERE expr`,
+		Description: "Excludes the rows for which the expression is false or NULL.",
+	},
+	{
+		Name:        "GROUP BY",
+		Syntax:      `GROUP BY expr, ... [HAVING expr]`,
+		Description: "Groups the input rows that have the same values for the expressions into a single result row.",
+	},
+	{
+		Name:        "HAVING",
+		Syntax:      `HAVING expr`,
+		Description: "Excludes the groups for which the expression is false or NULL.",
+	},
+	{
+		Name:        "ORDER BY",
+		Syntax:      `ORDER BY expr [COLLATE collation-name] [ASC | DESC] [NULLS {FIRST | LAST}], ...`,
+		Description: "Sorts the returned rows. The default order is ascending.",
+	},
+	{
+		Name:        "LIMIT",
+		Syntax:      `LIMIT expr [{OFFSET | ,} expr]`,
+		Description: "Places an upper bound on the number of rows returned. A negative value means no upper bound.",
+	},
+	{
+		Name: "JOIN",
+		Syntax: `table-or-subquery join-operator table-or-subquery [ON expr | USING (column-name, ...)]
+
+join-operator:
+    , | [NATURAL] [LEFT [OUTER] | INNER | CROSS] JOIN`,
+		Description: "Combines the data from two tables. SQLite supports only LEFT outer joins before version 3.39.0.",
+	},
+	{
+		Name:        "INNER JOIN",
+		Syntax:      `table-or-subquery INNER JOIN table-or-subquery [ON expr | USING (column-name, ...)]`,
+		Description: "Produces the combined rows of both tables that satisfy the join constraint.",
+	},
+	{
+		Name:        "LEFT JOIN",
+		Syntax:      `table-or-subquery LEFT [OUTER] JOIN table-or-subquery [ON expr | USING (column-name, ...)]`,
+		Description: "Produces all rows of the left table. The columns of the right table are NULL for the rows without a match.",
+	},
+	{
+		Name:        "UNION",
+		Syntax:      `select-core UNION [ALL] select-core`,
+		Description: "Returns all the rows of both selects. Duplicate rows are removed unless ALL is specified.",
+	},
+	{
+		Name:        "WITH",
+		Syntax:      `WITH [RECURSIVE] table-name [(column-name, ...)] AS [[NOT] MATERIALIZED] (select-stmt), ...`,
+		Description: "Common table expressions act like temporary views that exist only for the duration of a single statement.",
+	},
+	{
+		Name:        "CASE",
+		Syntax:      `CASE [base-expr] WHEN expr THEN expr [WHEN ...] [ELSE expr] END`,
+		Description: "Returns the result of the first WHEN that matches, or the ELSE result. Without ELSE, NULL is returned.",
+	},
+	{
+		Name: "CREATE TABLE",
+		Syntax: `CREATE [TEMP | TEMPORARY] TABLE [IF NOT EXISTS] [schema-name.]table-name
+    {(column-def, ... [, table-constraint, ...]) [WITHOUT ROWID | STRICT] | AS select-stmt}`,
+		Description: "Creates a new table.",
+	},
+	{
+		Name:        "ALTER TABLE",
+		Syntax:      `ALTER TABLE [schema-name.]table-name {RENAME TO new-table-name | RENAME [COLUMN] column-name TO new-column-name | ADD [COLUMN] column-def | DROP [COLUMN] column-name}`,
+		Description: "Renames a table, renames a column, adds a column or drops a column.",
+	},
+	{
+		Name:        "EXPLAIN QUERY PLAN",
+		Syntax:      `EXPLAIN QUERY PLAN statement`,
+		Description: "Returns a high-level description of the strategy or plan that SQLite uses to implement the query.",
+	},
+	{
+		Name:        "PRAGMA",
+		Syntax:      `PRAGMA [schema-name.]pragma-name [= pragma-value | (pragma-value)]`,
+		Description: "Modifies the operation of the SQLite library or queries the library for internal data.",
+	},
+}
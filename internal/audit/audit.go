@@ -0,0 +1,124 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/lighttiger2505/sqls/internal/database"
+	"golang.org/x/xerrors"
+)
+
+const (
+	OutcomeOK    = "ok"
+	OutcomeError = "error"
+
+	redacted = "[REDACTED]"
+)
+
+// Entry is a statement executed on a connection with an audit log.
+type Entry struct {
+	Time      time.Time         `json:"time"`
+	OSUser    string            `json:"osUser"`
+	Alias     string            `json:"alias"`
+	Statement string            `json:"statement"`
+	Params    map[string]string `json:"params,omitempty"`
+	Outcome   string            `json:"outcome"`
+	RowCount  int64             `json:"rowCount"`
+	Duration  time.Duration     `json:"duration"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// Logger appends entries to the audit log of a connection as JSON lines.
+// The file is only ever opened for appending.
+type Logger struct {
+	path   string
+	redact []*regexp.Regexp
+	osUser string
+
+	mu sync.Mutex
+}
+
+func NewLogger(cfg *database.AuditLogConfig) (*Logger, error) {
+	redact := make([]*regexp.Regexp, len(cfg.Redact))
+	for i, pattern := range cfg.Redact {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid audit log redact pattern %q, %+v", pattern, err)
+		}
+		redact[i] = re
+	}
+	return &Logger{
+		path:   cfg.Path,
+		redact: redact,
+		osUser: currentOSUser(),
+	}, nil
+}
+
+// Record writes an entry for the statement. The values of the params are
+// redacted according to the redact patterns of the connection.
+func (l *Logger) Record(alias, statement string, params map[string]interface{}, start time.Time, rowCount int64, stmtErr error) error {
+	entry := &Entry{
+		Time:      start,
+		OSUser:    l.osUser,
+		Alias:     alias,
+		Statement: statement,
+		Params:    l.redactParams(params),
+		Outcome:   OutcomeOK,
+		RowCount:  rowCount,
+		Duration:  time.Since(start),
+	}
+	if stmtErr != nil {
+		entry.Outcome = OutcomeError
+		entry.Error = stmtErr.Error()
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return xerrors.Errorf("cannot marshal audit log entry, %+v", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return xerrors.Errorf("cannot create audit log directory, %+v", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return xerrors.Errorf("cannot open audit log, %+v", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(b, '\n')); err != nil {
+		return xerrors.Errorf("cannot write audit log, %+v", err)
+	}
+	return nil
+}
+
+func (l *Logger) redactParams(params map[string]interface{}) map[string]string {
+	if len(params) == 0 {
+		return nil
+	}
+	redactedParams := make(map[string]string, len(params))
+	for key, value := range params {
+		redactedParams[key] = fmt.Sprint(value)
+		for _, re := range l.redact {
+			if re.MatchString(key) {
+				redactedParams[key] = redacted
+				break
+			}
+		}
+	}
+	return redactedParams
+}
+
+func currentOSUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/lighttiger2505/sqls/internal/database"
+)
+
+func TestLogger_Record(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqls-audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit", "audit.log")
+
+	logger, err := NewLogger(&database.AuditLogConfig{
+		Path:   path,
+		Redact: []string{"(?i)password", `^\$2$`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	params := map[string]interface{}{
+		":name":     "Kabul",
+		":Password": "secret",
+		"$1":        float64(10),
+		"$2":        "token",
+	}
+	if err := logger.Record("production", "UPDATE users SET name = :name WHERE password = :Password", params, start, 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.Record("production", "DELETE FROM city", nil, start, 0, errors.New("no such table: city")); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	got := []*Entry{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, entry)
+	}
+
+	want := []*Entry{
+		{
+			Time:      start,
+			OSUser:    currentOSUser(),
+			Alias:     "production",
+			Statement: "UPDATE users SET name = :name WHERE password = :Password",
+			Params: map[string]string{
+				":name":     "Kabul",
+				":Password": "[REDACTED]",
+				"$1":        "10",
+				"$2":        "[REDACTED]",
+			},
+			Outcome:  OutcomeOK,
+			RowCount: 1,
+		},
+		{
+			Time:      start,
+			OSUser:    currentOSUser(),
+			Alias:     "production",
+			Statement: "DELETE FROM city",
+			Outcome:   OutcomeError,
+			Error:     "no such table: city",
+		},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Entry{}, "Duration")); diff != "" {
+		t.Errorf("unmatch audit log (- want, + got):\n%s", diff)
+	}
+}
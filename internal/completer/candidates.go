@@ -1,8 +1,11 @@
 package completer
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/lighttiger2505/sqls/dialect"
+	"github.com/lighttiger2505/sqls/internal/config"
 	"github.com/lighttiger2505/sqls/internal/database"
 	"github.com/lighttiger2505/sqls/internal/lsp"
 	"github.com/lighttiger2505/sqls/parser/parseutil"
@@ -25,6 +28,11 @@ func (c *Completer) keywordCandidates(lower bool, keywords []string) []lsp.Compl
 }
 
 func (c *Completer) functionCandidates(lower bool, keywords []string) []lsp.CompletionItem {
+	catalog := map[string][]*dialect.FunctionDesc{}
+	for _, fn := range dialect.DataBaseFunctionCatalog(c.Driver) {
+		catalog[fn.Name] = append(catalog[fn.Name], fn)
+	}
+
 	candidates := []lsp.CompletionItem{}
 	for _, k := range keywords {
 		candidate := lsp.CompletionItem{
@@ -32,6 +40,13 @@ func (c *Completer) functionCandidates(lower bool, keywords []string) []lsp.Comp
 			Kind:   lsp.FunctionCompletion,
 			Detail: "Function",
 		}
+		if funcs, ok := catalog[k]; ok {
+			candidate.Detail = funcs[0].Signature()
+			candidate.Data = &DocumentTarget{
+				Type:     DocumentTargetFunction,
+				Function: k,
+			}
+		}
 		if lower {
 			candidate.Label = strings.ToLower(candidate.Label)
 		}
@@ -83,9 +98,11 @@ func generateColumnCandidates(tableName string, columns []*database.ColumnDesc)
 			Label:  column.Name,
 			Kind:   lsp.FieldCompletion,
 			Detail: columnDetail(tableName),
-			Documentation: lsp.MarkupContent{
-				Kind:  lsp.Markdown,
-				Value: database.ColumnDoc(tableName, column),
+			Data: &DocumentTarget{
+				Type:   DocumentTargetColumn,
+				Schema: column.Schema,
+				Table:  tableName,
+				Column: column.Name,
 			},
 		}
 		candidates = append(candidates, candidate)
@@ -114,7 +131,7 @@ func (c *Completer) ReferencedTableCandidates(targetTables []*parseutil.TableInf
 				includeTables = append(includeTables, targetTable)
 			}
 		}
-		genCands := generateTableCandidatesByInfos(includeTables, c.DBCache)
+		genCands := generateTableCandidatesByInfos(includeTables)
 		candidates = append(candidates, genCands...)
 	}
 	return candidates
@@ -138,41 +155,119 @@ func (c *Completer) TableCandidates(parent *completionParent, targetTables []*pa
 			}
 			excludeTables = append(excludeTables, table)
 		}
-		candidates = append(candidates, generateTableCandidates(excludeTables, c.DBCache)...)
+		candidates = append(candidates, generateTableCandidates(excludeTables)...)
 	case ParentTypeSchema:
 		tables, ok := c.DBCache.SortedTablesByDBName(parent.Name)
 		if ok {
-			candidates = append(candidates, generateTableCandidates(tables, c.DBCache)...)
+			candidates = append(candidates, generateTableCandidates(tables)...)
 		} else {
 			tables := c.DBCache.SortedTables()
-			candidates = append(candidates, generateTableCandidates(tables, c.DBCache)...)
+			candidates = append(candidates, generateTableCandidates(tables)...)
 		}
 	case ParentTypeTable:
 	}
 	return candidates
 }
 
-func generateTableCandidates(tables []string, dbCache *database.DBCache) []lsp.CompletionItem {
+func generateTableCandidates(tables []string) []lsp.CompletionItem {
 	candidates := []lsp.CompletionItem{}
 	for _, tableName := range tables {
 		candidate := lsp.CompletionItem{
 			Label:  tableName,
 			Kind:   lsp.FieldCompletion,
 			Detail: "table",
+			Data: &DocumentTarget{
+				Type:  DocumentTargetTable,
+				Table: tableName,
+			},
 		}
-		cols, ok := dbCache.ColumnDescs(tableName)
-		if ok {
-			candidate.Documentation = lsp.MarkupContent{
-				Kind:  lsp.Markdown,
-				Value: database.TableDoc(tableName, cols),
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+func (c *Completer) ViewCandidates(parent *completionParent, targetTables []*parseutil.TableInfo) []lsp.CompletionItem {
+	candidates := []lsp.CompletionItem{}
+
+	switch parent.Type {
+	case ParentTypeNone:
+		views := []string{}
+		for _, view := range c.DBCache.SortedViews() {
+			isExclude := false
+			for _, targetTable := range targetTables {
+				if view == targetTable.Name {
+					isExclude = true
+				}
 			}
+			if !isExclude {
+				views = append(views, view)
+			}
+		}
+		candidates = append(candidates, generateViewCandidates("", views)...)
+	case ParentTypeSchema:
+		schema, ok := c.DBCache.Database(parent.Name)
+		if !ok {
+			break
+		}
+		views, _ := c.DBCache.SortedViewsByDBName(schema)
+		candidates = append(candidates, generateViewCandidates(schema, views)...)
+	}
+	return candidates
+}
+
+func generateViewCandidates(schema string, views []string) []lsp.CompletionItem {
+	candidates := []lsp.CompletionItem{}
+	for _, viewName := range views {
+		candidate := lsp.CompletionItem{
+			Label:  viewName,
+			Kind:   lsp.FieldCompletion,
+			Detail: "view",
+			Data: &DocumentTarget{
+				Type:   DocumentTargetView,
+				Schema: schema,
+				Table:  viewName,
+			},
 		}
 		candidates = append(candidates, candidate)
 	}
 	return candidates
 }
 
-func generateTableCandidatesByInfos(tables []*parseutil.TableInfo, dbCache *database.DBCache) []lsp.CompletionItem {
+// routineCandidates returns the stored functions of the default schema, or of
+// the schema qualifying the function.
+func (c *Completer) routineCandidates(parent *completionParent) []lsp.CompletionItem {
+	var schema string
+	switch parent.Type {
+	case ParentTypeNone:
+	case ParentTypeSchema, ParentTypeTable:
+		db, ok := c.DBCache.Database(parent.Name)
+		if !ok {
+			return nil
+		}
+		schema = db
+	default:
+		return nil
+	}
+	candidates := []lsp.CompletionItem{}
+	for _, routine := range c.DBCache.SchemaRoutines(schema) {
+		if routine.Type != database.RoutineTypeFunction {
+			continue
+		}
+		candidates = append(candidates, lsp.CompletionItem{
+			Label:  routine.Name,
+			Kind:   lsp.FunctionCompletion,
+			Detail: routine.Signature(),
+			Data: &DocumentTarget{
+				Type:     DocumentTargetRoutine,
+				Schema:   schema,
+				Function: routine.Name,
+			},
+		})
+	}
+	return candidates
+}
+
+func generateTableCandidatesByInfos(tables []*parseutil.TableInfo) []lsp.CompletionItem {
 	candidates := []lsp.CompletionItem{}
 	for _, table := range tables {
 		name := table.Name
@@ -185,19 +280,195 @@ func generateTableCandidatesByInfos(tables []*parseutil.TableInfo, dbCache *data
 			Label:  name,
 			Kind:   lsp.FieldCompletion,
 			Detail: detail,
+			Data: &DocumentTarget{
+				Type:  DocumentTargetTable,
+				Table: table.Name,
+			},
 		}
-		cols, ok := dbCache.ColumnDescs(table.Name)
-		if ok {
-			candidate.Documentation = lsp.MarkupContent{
-				Kind:  lsp.Markdown,
-				Value: database.TableDoc(table.Name, cols),
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// joinConditionCandidates returns the conditions joining the table of the
+// last JOIN clause to the other tables of the statement by foreign keys.
+func (c *Completer) joinConditionCandidates(joined *parseutil.TableInfo, targetTables []*parseutil.TableInfo) []lsp.CompletionItem {
+	candidates := []lsp.CompletionItem{}
+	for _, table := range targetTables {
+		if table.Name == "" || (table.Name == joined.Name && table.Alias == joined.Alias) {
+			continue
+		}
+		fks := c.DBCache.JoinForeignKeys(joined.DatabaseSchema, joined.Name, table.DatabaseSchema, table.Name)
+		for _, fk := range fks {
+			if strings.EqualFold(fk.Table, joined.Name) && strings.EqualFold(fk.RefTable, table.Name) {
+				candidates = append(candidates, joinConditionCandidate(joined, fk.Columns, table, fk.RefColumns, fk))
+			}
+			if strings.EqualFold(fk.RefTable, joined.Name) && strings.EqualFold(fk.Table, table.Name) {
+				candidates = append(candidates, joinConditionCandidate(joined, fk.RefColumns, table, fk.Columns, fk))
 			}
 		}
-		candidates = append(candidates, candidate)
+		if len(fks) == 0 && c.JoinConventions != nil {
+			candidates = append(candidates, c.inferredJoinConditionCandidates(joined, table)...)
+		}
 	}
 	return candidates
 }
 
+func joinConditionCandidate(left *parseutil.TableInfo, leftCols []string, right *parseutil.TableInfo, rightCols []string, fk *database.ForeignKeyDesc) lsp.CompletionItem {
+	conds := make([]string, len(leftCols))
+	for i := range leftCols {
+		conds[i] = fmt.Sprintf("%s.%s = %s.%s", tableQualifier(left), leftCols[i], tableQualifier(right), rightCols[i])
+	}
+	detail := "join condition from foreign key"
+	if fk.Name != "" {
+		detail = fmt.Sprintf("join condition from %q", fk.Name)
+	}
+	label := strings.Join(conds, " AND ")
+	return lsp.CompletionItem{
+		Label:  label,
+		Kind:   lsp.ReferenceCompletion,
+		Detail: detail,
+		Documentation: lsp.MarkupContent{
+			Kind:  lsp.Markdown,
+			Value: database.ForeignKeyDoc(fk),
+		},
+		// declared foreign keys come before the inferred conditions
+		SortText: "0" + label,
+	}
+}
+
+// inferredJoinConditionCandidates returns the conditions joining two tables
+// without declared foreign keys by the naming conventions of their columns.
+func (c *Completer) inferredJoinConditionCandidates(left, right *parseutil.TableInfo) []lsp.CompletionItem {
+	leftCols, ok := c.tableColumns(left)
+	if !ok {
+		return nil
+	}
+	rightCols, ok := c.tableColumns(right)
+	if !ok {
+		return nil
+	}
+	candidates := []lsp.CompletionItem{}
+	for _, pair := range inferJoinColumns(left.Name, leftCols, right.Name, rightCols, c.JoinConventions) {
+		label := fmt.Sprintf("%s.%s = %s.%s", tableQualifier(left), pair[0].Name, tableQualifier(right), pair[1].Name)
+		candidates = append(candidates, lsp.CompletionItem{
+			Label:    label,
+			Kind:     lsp.ReferenceCompletion,
+			Detail:   "join condition inferred from column names",
+			SortText: "1" + label,
+		})
+	}
+	return candidates
+}
+
+func (c *Completer) tableColumns(table *parseutil.TableInfo) ([]*database.ColumnDesc, bool) {
+	if table.DatabaseSchema != "" {
+		return c.DBCache.ColumnDatabase(table.DatabaseSchema, table.Name)
+	}
+	return c.DBCache.ColumnDescs(table.Name)
+}
+
+// inferJoinColumns returns the pairs of columns of the left and right tables
+// that are likely to be joined. A column named by a foreign key convention
+// after the other table is paired with its primary key column, and columns
+// of the same name are paired when one of them is a key. Paired columns must
+// hold values of the same kind.
+func inferJoinColumns(leftTable string, leftCols []*database.ColumnDesc, rightTable string, rightCols []*database.ColumnDesc, conventions *config.JoinConventions) [][2]*database.ColumnDesc {
+	pairs := [][2]*database.ColumnDesc{}
+	seen := map[[2]*database.ColumnDesc]bool{}
+	add := func(l, r *database.ColumnDesc) {
+		pair := [2]*database.ColumnDesc{l, r}
+		if seen[pair] || !l.SameTypeKind(r) {
+			return
+		}
+		seen[pair] = true
+		pairs = append(pairs, pair)
+	}
+
+	for _, l := range leftCols {
+		for _, r := range rightCols {
+			switch {
+			case conventionNameIs(l.Name, conventions.ForeignKeyColumns, rightTable) && conventionNameIs(r.Name, conventions.PrimaryKeyColumns, rightTable):
+				add(l, r)
+			case conventionNameIs(r.Name, conventions.ForeignKeyColumns, leftTable) && conventionNameIs(l.Name, conventions.PrimaryKeyColumns, leftTable):
+				add(l, r)
+			case strings.EqualFold(l.Name, r.Name):
+				if !isKeyColumn(l) && !isKeyColumn(r) {
+					continue
+				}
+				if conventionNameIs(l.Name, conventions.PrimaryKeyColumns, leftTable) || conventionNameIs(r.Name, conventions.PrimaryKeyColumns, rightTable) {
+					// both tables have their own "id"
+					continue
+				}
+				if nameIn(l.Name, conventions.IgnoreColumns) {
+					continue
+				}
+				add(l, r)
+			}
+		}
+	}
+	return pairs
+}
+
+// conventionNameIs reports whether the column is named by one of the
+// patterns, where "{table}" stands for the table name or its singular form.
+func conventionNameIs(column string, patterns []string, table string) bool {
+	for _, pattern := range patterns {
+		if !strings.Contains(pattern, config.TablePlaceholder) {
+			if strings.EqualFold(column, pattern) {
+				return true
+			}
+			continue
+		}
+		for _, form := range tableNameForms(table) {
+			if strings.EqualFold(column, strings.Replace(pattern, config.TablePlaceholder, form, -1)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tableNameForms returns the table name with the singular forms it may have
+// in column names, "customers" for "customer_id".
+func tableNameForms(table string) []string {
+	forms := []string{table}
+	lower := strings.ToLower(table)
+	switch {
+	case strings.HasSuffix(lower, "ies"):
+		forms = append(forms, table[:len(table)-3]+"y")
+	case strings.HasSuffix(lower, "es"):
+		forms = append(forms, table[:len(table)-2], table[:len(table)-1])
+	case strings.HasSuffix(lower, "s"):
+		forms = append(forms, table[:len(table)-1])
+	}
+	return forms
+}
+
+// isKeyColumn reports whether the column is part of the primary key, or of an
+// index on MySQL.
+func isKeyColumn(col *database.ColumnDesc) bool {
+	return col.IsPrimaryKey() || col.Key == "MUL" || col.Key == "UNI"
+}
+
+func nameIn(name string, names []string) bool {
+	for _, n := range names {
+		if strings.EqualFold(name, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// tableQualifier returns the name qualifying the columns of the table in the
+// statement.
+func tableQualifier(table *parseutil.TableInfo) string {
+	if table.Alias != "" {
+		return table.Alias
+	}
+	return table.Name
+}
+
 func (c *Completer) SubQueryCandidates(infos []*parseutil.SubQueryInfo) []lsp.CompletionItem {
 	candidates := []lsp.CompletionItem{}
 	for _, info := range infos {
@@ -279,3 +550,17 @@ func (c *Completer) SchemaCandidates() []lsp.CompletionItem {
 	}
 	return candidates
 }
+
+func (c *Completer) historyCandidates() []lsp.CompletionItem {
+	candidates := []lsp.CompletionItem{}
+	for _, query := range c.History {
+		candidate := lsp.CompletionItem{
+			Label:      strings.Join(strings.Fields(query), " "),
+			Kind:       lsp.TextCompletion,
+			Detail:     "history",
+			InsertText: query,
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
@@ -5,11 +5,13 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/lighttiger2505/sqls/ast"
 	"github.com/lighttiger2505/sqls/ast/astutil"
 	"github.com/lighttiger2505/sqls/dialect"
+	"github.com/lighttiger2505/sqls/internal/config"
 	"github.com/lighttiger2505/sqls/internal/database"
 	"github.com/lighttiger2505/sqls/internal/lsp"
 	"github.com/lighttiger2505/sqls/parser"
@@ -32,6 +34,7 @@ const (
 	CompletionTypeChange
 	CompletionTypeUser
 	CompletionTypeSchema
+	CompletionTypeJoinCondition
 )
 
 func (ct completionType) String() string {
@@ -54,6 +57,8 @@ func (ct completionType) String() string {
 		return "User"
 	case CompletionTypeSchema:
 		return "Schema"
+	case CompletionTypeJoinCondition:
+		return "JoinCondition"
 	default:
 		return ""
 	}
@@ -62,6 +67,16 @@ func (ct completionType) String() string {
 type Completer struct {
 	DBCache *database.DBCache
 	Driver  dialect.DatabaseDriver
+	// History is the past queries offered at the head of a statement, most recent first
+	History []string
+	// JoinConventions infer join conditions between tables without foreign
+	// keys, nil to only use foreign keys
+	JoinConventions *config.JoinConventions
+	// SnippetSupport enables the snippets, as the client can expand them
+	SnippetSupport bool
+	// Snippets are the user-defined snippets offered besides the templates
+	// generated from the tables
+	Snippets []*config.Snippet
 }
 
 func NewCompleter(dbCache *database.DBCache) *Completer {
@@ -111,60 +126,88 @@ func (c *Completer) Complete(text string, params lsp.CompletionParams, lowercase
 	items := []lsp.CompletionItem{}
 
 	if c.DBCache != nil {
+		if completionTypeIs(ctx.types, CompletionTypeJoinCondition) {
+			joinedTable, err := parseutil.ExtractJoinedTable(parsed, pos)
+			if err != nil {
+				return nil, err
+			}
+			if joinedTable != nil {
+				items = appendCandidates(items, rankJoinCondition, c.joinConditionCandidates(joinedTable, definedTables))
+			}
+		}
 		if completionTypeIs(ctx.types, CompletionTypeColumn) {
 			candidates := c.columnCandidates(definedTables, ctx.parent)
 			if withBackQuote {
 				candidates = toQuotedCandidates(candidates)
 			}
-			items = append(items, candidates...)
+			items = appendCandidates(items, rankColumn, candidates)
 		}
 		if completionTypeIs(ctx.types, CompletionTypeReferencedTable) {
 			candidates := c.ReferencedTableCandidates(definedTables)
 			if withBackQuote {
 				candidates = toQuotedCandidates(candidates)
 			}
-			items = append(items, candidates...)
+			items = appendCandidates(items, rankAlias, candidates)
 		}
 		if completionTypeIs(ctx.types, CompletionTypeTable) {
 			candidates := c.TableCandidates(ctx.parent, definedTables)
 			if withBackQuote {
 				candidates = toQuotedCandidates(candidates)
 			}
-			items = append(items, candidates...)
+			items = appendCandidates(items, rankTable, candidates)
+		}
+		// In the column list of INSERT, a view is the target whose columns
+		// are completed, not a candidate itself
+		if completionTypeIs(ctx.types, CompletionTypeView) && ctx.syntaxPos != parseutil.InsertColumn {
+			candidates := c.ViewCandidates(ctx.parent, definedTables)
+			if withBackQuote {
+				candidates = toQuotedCandidates(candidates)
+			}
+			items = appendCandidates(items, rankTable, candidates)
+		}
+		if completionTypeIs(ctx.types, CompletionTypeFunction) {
+			items = appendCandidates(items, rankFunction, c.routineCandidates(ctx.parent))
 		}
 		if completionTypeIs(ctx.types, CompletionTypeSchema) {
 			candidates := c.SchemaCandidates()
 			if withBackQuote {
 				candidates = toQuotedCandidates(candidates)
 			}
-			items = append(items, candidates...)
+			items = appendCandidates(items, rankTable, candidates)
 		}
 		if completionTypeIs(ctx.types, CompletionTypeSubQuery) {
 			candidates := c.SubQueryCandidates(definedSubQuerys)
 			if withBackQuote {
 				candidates = toQuotedCandidates(candidates)
 			}
-			items = append(items, candidates...)
+			items = appendCandidates(items, rankAlias, candidates)
 		}
 		if completionTypeIs(ctx.types, CompletionTypeSubQueryColumn) {
 			candidates := c.SubQueryColumnCandidates(definedSubQuerys)
 			if withBackQuote {
 				candidates = toQuotedCandidates(candidates)
 			}
-			items = append(items, candidates...)
+			items = appendCandidates(items, rankColumn, candidates)
 		}
 	}
 
 	if completionTypeIs(ctx.types, CompletionTypeKeyword) {
 		drivers := dialect.DataBaseKeywords(c.Driver)
-		items = append(items, c.keywordCandidates(lowercaseKeywords, drivers)...)
+		items = appendCandidates(items, rankKeyword, c.keywordCandidates(lowercaseKeywords, drivers))
 	}
 	if completionTypeIs(ctx.types, CompletionTypeFunction) {
 		drivers := dialect.DataBaseFunctions(c.Driver)
-		items = append(items, c.functionCandidates(lowercaseKeywords, drivers)...)
+		items = appendCandidates(items, rankFunction, c.functionCandidates(lowercaseKeywords, drivers))
+	}
+	head := isStatementHead(text, params.Position.Line+1, params.Position.Character, lastWord)
+	if c.SnippetSupport {
+		items = appendCandidates(items, rankSnippet, c.snippetCandidates(lowercaseKeywords, head, len(definedTables) > 0, lastWord))
+	}
+	if head {
+		items = appendCandidates(items, rankHistory, c.historyCandidates())
 	}
 
-	items = filterCandidates(items, lastWord)
+	items = filterCandidates(items, lastWord, recentWords(c.History))
 
 	return items, nil
 }
@@ -187,8 +230,13 @@ type completionParent struct {
 var noneParent = &completionParent{Type: ParentTypeNone}
 
 type CompletionContext struct {
-	types  []completionType
-	parent *completionParent
+	types     []completionType
+	parent    *completionParent
+	syntaxPos parseutil.SyntaxPosition
+}
+
+var onKeywordMatcher = astutil.NodeMatcher{
+	ExpectKeyword: []string{"ON"},
 }
 
 func getCompletionTypes(nw *parseutil.NodeWalker) *CompletionContext {
@@ -304,6 +352,9 @@ func getCompletionTypes(nw *parseutil.NodeWalker) *CompletionContext {
 				CompletionTypeFunction,
 				CompletionTypeKeyword,
 			}
+			if nw.PrevNodesIs(true, onKeywordMatcher) {
+				t = append([]completionType{CompletionTypeJoinCondition}, t...)
+			}
 		}
 	case syntaxPos == parseutil.InsertColumn:
 		t = []completionType{
@@ -316,21 +367,47 @@ func getCompletionTypes(nw *parseutil.NodeWalker) *CompletionContext {
 		}
 	}
 	return &CompletionContext{
-		types:  t,
-		parent: p,
+		types:     t,
+		parent:    p,
+		syntaxPos: syntaxPos,
 	}
 }
 
-func filterCandidates(candidates []lsp.CompletionItem, lastWord string) []lsp.CompletionItem {
+// filterCandidates returns the candidates matching the last word, ordered by
+// their sort text. Within a rank, the candidates matching by prefix come
+// first, then the ones used in the recent queries.
+func filterCandidates(candidates []lsp.CompletionItem, lastWord string, recent map[string]bool) []lsp.CompletionItem {
 	filterd := []lsp.CompletionItem{}
 	for _, candidate := range candidates {
-		if strings.HasPrefix(strings.ToUpper(candidate.Label), strings.ToUpper(lastWord)) {
-			filterd = append(filterd, candidate)
+		match, ok := matchCandidate(candidate.Label, lastWord)
+		if !ok {
+			continue
+		}
+		used := 1
+		if recent[strings.ToUpper(strings.Trim(candidate.Label, "`"))] {
+			used = 0
 		}
+		if candidate.SortText != "" {
+			candidate.SortText = fmt.Sprintf("%s%d%d%s", candidate.SortText[:1], match, used, candidate.SortText[1:])
+		}
+		filterd = append(filterd, candidate)
 	}
+	sort.SliceStable(filterd, func(i, j int) bool {
+		return filterd[i].SortText < filterd[j].SortText
+	})
 	return filterd
 }
 
+// isStatementHead reports whether nothing but the last word precedes the
+// cursor in the current statement.
+func isStatementHead(text string, line, char int, lastWord string) bool {
+	before := getBeforeCursorText(text, line, char)
+	if i := strings.LastIndex(before, ";"); i >= 0 {
+		before = before[i+1:]
+	}
+	return strings.TrimSpace(before) == lastWord
+}
+
 func getLine(text string, line int) string {
 	scanner := bufio.NewScanner(strings.NewReader(text))
 	i := 1
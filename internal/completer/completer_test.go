@@ -4,6 +4,10 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/dialect"
+	"github.com/lighttiger2505/sqls/internal/config"
+	"github.com/lighttiger2505/sqls/internal/database"
 	"github.com/lighttiger2505/sqls/internal/lsp"
 )
 
@@ -157,9 +161,197 @@ func TestComplete(t *testing.T) {
 				t.Fatal(err)
 			}
 
+			// the ranking is tested by TestFilterCandidates
+			for i := range got {
+				got[i].SortText = ""
+			}
 			if !reflect.DeepEqual(got, tt.expected) {
 				t.Errorf("\nwant: %v\ngot:  %v", tt.expected, got)
 			}
 		})
 	}
 }
+
+func Test_isStatementHead(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		line int
+		char int
+		want bool
+	}{
+		{"empty", "", 1, 0, true},
+		{"first word", "SEL", 1, 3, true},
+		{"after statement", "SELECT 1;\n  SEL", 2, 5, true},
+		{"after first word", "SELECT ", 1, 7, false},
+		{"in statement", "SELECT a\nFR", 2, 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lastWord := getLastWord(tt.in, tt.line, tt.char)
+			if got := isStatementHead(tt.in, tt.line, tt.char, lastWord); got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInferJoinColumns(t *testing.T) {
+	customers := []*database.ColumnDesc{
+		{Name: "id", Type: "int(11)", Key: "PRI"},
+		{Name: "name", Type: "varchar(64)"},
+		{Name: "country_code", Type: "char(3)", Key: "MUL"},
+		{Name: "created_at", Type: "datetime", Key: "MUL"},
+	}
+	orders := []*database.ColumnDesc{
+		{Name: "id", Type: "int(11)", Key: "PRI"},
+		{Name: "customer_id", Type: "bigint(20)", Key: "MUL"},
+		{Name: "name", Type: "varchar(64)"},
+		{Name: "country_code", Type: "char(3)"},
+		{Name: "created_at", Type: "datetime"},
+	}
+	addresses := []*database.ColumnDesc{
+		{Name: "customers_id", Type: "varchar(11)"},
+	}
+	tests := []struct {
+		name        string
+		leftTable   string
+		leftCols    []*database.ColumnDesc
+		rightTable  string
+		rightCols   []*database.ColumnDesc
+		conventions *config.JoinConventions
+		want        []string
+	}{
+		{
+			name:        "default conventions",
+			leftTable:   "orders",
+			leftCols:    orders,
+			rightTable:  "customers",
+			rightCols:   customers,
+			conventions: (&config.Config{}).JoinInference(),
+			want:        []string{"customer_id = id", "country_code = country_code", "created_at = created_at"},
+		},
+		{
+			name:       "ignored columns",
+			leftTable:  "customers",
+			leftCols:   customers,
+			rightTable: "orders",
+			rightCols:  orders,
+			conventions: (&config.Config{
+				JoinConventions: &config.JoinConventions{IgnoreColumns: []string{"created_at"}},
+			}).JoinInference(),
+			want: []string{"id = customer_id", "country_code = country_code"},
+		},
+		{
+			name:        "unmatched type",
+			leftTable:   "addresses",
+			leftCols:    addresses,
+			rightTable:  "customers",
+			rightCols:   customers,
+			conventions: (&config.Config{}).JoinInference(),
+			want:        []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, pair := range inferJoinColumns(tt.leftTable, tt.leftCols, tt.rightTable, tt.rightCols, tt.conventions) {
+				got = append(got, pair[0].Name+" = "+pair[1].Name)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch pairs (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFunctionCandidates(t *testing.T) {
+	c := &Completer{Driver: dialect.DatabaseDriverPostgreSQL}
+	got := c.functionCandidates(true, []string{"LEFT", "MY_FUNC"})
+	want := []lsp.CompletionItem{
+		{
+			Label:  "left",
+			Kind:   lsp.FunctionCompletion,
+			Detail: "LEFT(string text, n integer) RETURNS text",
+			Data: &DocumentTarget{
+				Type:     DocumentTargetFunction,
+				Function: "LEFT",
+			},
+		},
+		{
+			Label:  "my_func",
+			Kind:   lsp.FunctionCompletion,
+			Detail: "Function",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unmatch candidates (- want, + got):\n%s", diff)
+	}
+
+	resolved, err := c.Resolve(got[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDoc := lsp.MarkupContent{
+		Kind:  lsp.Markdown,
+		Value: "LEFT function\n\nLEFT(string text, n integer) RETURNS text\n\nFirst n characters in the string. When n is negative, all but the last |n| characters.\n",
+	}
+	if diff := cmp.Diff(wantDoc, resolved.Documentation); diff != "" {
+		t.Errorf("unmatch documentation (- want, + got):\n%s", diff)
+	}
+}
+
+func TestMatchCandidate(t *testing.T) {
+	tests := []struct {
+		label  string
+		word   string
+		want   matchType
+		wantOK bool
+	}{
+		{label: "country", word: "", want: matchPrefix, wantOK: true},
+		{label: "country", word: "COUN", want: matchPrefix, wantOK: true},
+		{label: "country", word: "cntry", want: matchFuzzy, wantOK: true},
+		{label: "countrylanguage", word: "cl", want: matchFuzzy, wantOK: true},
+		{label: "`city`", word: "`cty", want: matchFuzzy, wantOK: true},
+		{label: "country", word: "ountry", wantOK: false},
+		{label: "country", word: "cyr", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label+" "+tt.word, func(t *testing.T) {
+			got, ok := matchCandidate(tt.label, tt.word)
+			if ok != tt.wantOK {
+				t.Fatalf("matchCandidate() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("matchCandidate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterCandidates(t *testing.T) {
+	items := []lsp.CompletionItem{}
+	items = appendCandidates(items, rankKeyword, []lsp.CompletionItem{{Label: "COUNT"}, {Label: "CROSS"}})
+	items = appendCandidates(items, rankTable, []lsp.CompletionItem{{Label: "city"}, {Label: "country"}, {Label: "countrylanguage"}})
+	items = appendCandidates(items, rankAlias, []lsp.CompletionItem{{Label: "co"}})
+	items = appendCandidates(items, rankColumn, []lsp.CompletionItem{{Label: "Code"}, {Label: "CountryCode"}, {Label: "Continent"}})
+
+	got := filterCandidates(items, "cont", recentWords([]string{"SELECT Name FROM countrylanguage"}))
+	labels := []string{}
+	for _, item := range got {
+		labels = append(labels, item.Label)
+	}
+	want := []string{
+		// columns, by prefix first
+		"Continent",
+		"CountryCode",
+		// tables, the recently used first
+		"countrylanguage",
+		"country",
+		// keywords
+		"COUNT",
+	}
+	if diff := cmp.Diff(want, labels); diff != "" {
+		t.Errorf("unmatch candidates (- want, + got):\n%s", diff)
+	}
+}
@@ -0,0 +1,85 @@
+package completer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lighttiger2505/sqls/internal/lsp"
+)
+
+// candidateRank orders the kinds of candidates, the most relevant first.
+type candidateRank int
+
+const (
+	rankJoinCondition candidateRank = iota
+	rankColumn
+	rankAlias
+	rankTable
+	rankKeyword
+	rankSnippet
+	rankFunction
+	rankHistory
+)
+
+// recentQueryCount is the number of past queries whose words are boosted.
+const recentQueryCount = 50
+
+// appendCandidates appends the candidates to the items with the rank as the
+// head of their sort text, followed by their own sort text or else their
+// position, so that the order of the items is kept within the rank.
+func appendCandidates(items []lsp.CompletionItem, rank candidateRank, candidates []lsp.CompletionItem) []lsp.CompletionItem {
+	for _, candidate := range candidates {
+		tail := candidate.SortText
+		if tail == "" {
+			tail = fmt.Sprintf("%05d", len(items))
+		}
+		candidate.SortText = fmt.Sprintf("%d%s", rank, tail)
+		items = append(items, candidate)
+	}
+	return items
+}
+
+type matchType int
+
+const (
+	matchPrefix matchType = iota
+	matchFuzzy
+)
+
+// matchCandidate reports how the label matches the word typed, ignoring
+// case: by prefix, or by a subsequence starting with the same character such
+// as "cntry" for "country".
+func matchCandidate(label, word string) (matchType, bool) {
+	label = strings.ToUpper(label)
+	word = strings.ToUpper(word)
+	if strings.HasPrefix(label, word) {
+		return matchPrefix, true
+	}
+	if label == "" || label[0] != word[0] {
+		return 0, false
+	}
+	i := 0
+	for j := 0; j < len(label) && i < len(word); j++ {
+		if label[j] == word[i] {
+			i++
+		}
+	}
+	return matchFuzzy, i == len(word)
+}
+
+var wordRegexp = regexp.MustCompile(`\w+`)
+
+// recentWords returns the words of the most recent queries in upper case.
+func recentWords(queries []string) map[string]bool {
+	if len(queries) > recentQueryCount {
+		queries = queries[:recentQueryCount]
+	}
+	words := map[string]bool{}
+	for _, query := range queries {
+		for _, word := range wordRegexp.FindAllString(query, -1) {
+			words[strings.ToUpper(word)] = true
+		}
+	}
+	return words
+}
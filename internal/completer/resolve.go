@@ -0,0 +1,102 @@
+package completer
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/lighttiger2505/sqls/dialect"
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+)
+
+type DocumentTargetType string
+
+const (
+	DocumentTargetTable  DocumentTargetType = "table"
+	DocumentTargetView   DocumentTargetType = "view"
+	DocumentTargetColumn DocumentTargetType = "column"
+	// DocumentTargetFunction is a built-in function of the driver
+	DocumentTargetFunction DocumentTargetType = "function"
+	// DocumentTargetRoutine is a function defined in the database
+	DocumentTargetRoutine DocumentTargetType = "routine"
+)
+
+// DocumentTarget is the data of a completion item naming the table, view,
+// column or function it documents. The documentation is only rendered when the client
+// resolves the item, as a list may hold thousands of them.
+type DocumentTarget struct {
+	Type     DocumentTargetType `json:"type"`
+	Schema   string             `json:"schema,omitempty"`
+	Table    string             `json:"table,omitempty"`
+	Column   string             `json:"column,omitempty"`
+	Function string             `json:"function,omitempty"`
+}
+
+// Resolve fills the documentation of a completion item from its data. The
+// item is returned as is if it has no data or its target no longer exists.
+func (c *Completer) Resolve(item lsp.CompletionItem) (lsp.CompletionItem, error) {
+	if item.Data == nil {
+		return item, nil
+	}
+	b, err := json.Marshal(item.Data)
+	if err != nil {
+		return item, err
+	}
+	target := &DocumentTarget{}
+	if err := json.Unmarshal(b, target); err != nil {
+		return item, err
+	}
+	if doc, ok := c.targetDoc(target); ok {
+		item.Documentation = lsp.MarkupContent{
+			Kind:  lsp.Markdown,
+			Value: doc,
+		}
+	}
+	return item, nil
+}
+
+func (c *Completer) targetDoc(target *DocumentTarget) (string, bool) {
+	if target.Type == DocumentTargetFunction {
+		funcs := dialect.LookupFunction(c.Driver, target.Function)
+		if len(funcs) == 0 {
+			return "", false
+		}
+		return database.FunctionDoc(funcs), true
+	}
+	if c.DBCache == nil {
+		return "", false
+	}
+	if target.Type == DocumentTargetRoutine {
+		routines := c.DBCache.Routine(target.Schema, target.Function)
+		if len(routines) == 0 {
+			return "", false
+		}
+		docs := make([]string, len(routines))
+		for i, routine := range routines {
+			docs[i] = database.RoutineDoc(routine)
+		}
+		return strings.Join(docs, "\n"), true
+	}
+
+	cols, ok := c.DBCache.ColumnDatabase(target.Schema, target.Table)
+	if !ok {
+		cols, ok = c.DBCache.ColumnDescs(target.Table)
+	}
+	if !ok {
+		return "", false
+	}
+	comment := c.DBCache.TableComment(target.Schema, target.Table)
+	switch target.Type {
+	case DocumentTargetTable:
+		return database.TableDoc(target.Table, comment, cols), true
+	case DocumentTargetView:
+		return database.ViewDoc(target.Table, comment, cols), true
+	case DocumentTargetColumn:
+		for _, col := range cols {
+			if strings.EqualFold(col.Name, target.Column) {
+				return database.ColumnDoc(target.Table, col), true
+			}
+		}
+	}
+	return "", false
+}
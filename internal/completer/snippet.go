@@ -0,0 +1,168 @@
+package completer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lighttiger2505/sqls/internal/config"
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+)
+
+// tableSnippet is a statement template generated for every table, completed
+// from the prefix followed by the table name such as "inscity".
+type tableSnippet struct {
+	prefix string
+	// head tells the template starts a statement, otherwise it follows the
+	// tables of a statement
+	head   bool
+	detail string
+	body   func(table string, cols []*database.ColumnDesc, kw func(string) string) string
+}
+
+var tableSnippets = []*tableSnippet{
+	{
+		prefix: "ins",
+		head:   true,
+		detail: "INSERT INTO %s",
+		body: func(table string, cols []*database.ColumnDesc, kw func(string) string) string {
+			names := make([]string, len(cols))
+			values := make([]string, len(cols))
+			for i, col := range cols {
+				names[i] = escapeSnippet(col.Name)
+				values[i] = fmt.Sprintf("${%d:%s}", i+1, escapeSnippet(col.Name))
+			}
+			return fmt.Sprintf("%s %s (%s) %s (%s)$0", kw("INSERT INTO"), escapeSnippet(table), strings.Join(names, ", "), kw("VALUES"), strings.Join(values, ", "))
+		},
+	},
+	{
+		prefix: "sel",
+		head:   true,
+		detail: "SELECT all columns FROM %s",
+		body: func(table string, cols []*database.ColumnDesc, kw func(string) string) string {
+			names := make([]string, len(cols))
+			for i, col := range cols {
+				names[i] = escapeSnippet(col.Name)
+			}
+			return fmt.Sprintf("%s %s %s %s$0", kw("SELECT"), strings.Join(names, ", "), kw("FROM"), escapeSnippet(table))
+		},
+	},
+	{
+		prefix: "upd",
+		head:   true,
+		detail: "UPDATE %s SET",
+		body: func(table string, cols []*database.ColumnDesc, kw func(string) string) string {
+			stop := 0
+			assign := func(col *database.ColumnDesc) string {
+				stop++
+				return fmt.Sprintf("%s = ${%d:%s}", escapeSnippet(col.Name), stop, escapeSnippet(col.Name))
+			}
+			sets := []string{}
+			for _, col := range cols {
+				if !col.IsPrimaryKey() {
+					sets = append(sets, assign(col))
+				}
+			}
+			conds := []string{}
+			for _, col := range cols {
+				if col.IsPrimaryKey() {
+					conds = append(conds, assign(col))
+				}
+			}
+			if len(conds) == 0 {
+				conds = append(conds, fmt.Sprintf("${%d:condition}", stop+1))
+			}
+			set := strings.Join(sets, ", ")
+			where := strings.Join(conds, " "+kw("AND")+" ")
+			return fmt.Sprintf("%s %s %s %s %s %s$0", kw("UPDATE"), escapeSnippet(table), kw("SET"), set, kw("WHERE"), where)
+		},
+	},
+	{
+		prefix: "join",
+		detail: "JOIN %s ON",
+		body: func(table string, cols []*database.ColumnDesc, kw func(string) string) string {
+			key := cols[0]
+			for _, col := range cols {
+				if col.IsPrimaryKey() {
+					key = col
+					break
+				}
+			}
+			return fmt.Sprintf("%s %s %s %s.${1:%s} = ${2}$0", kw("JOIN"), escapeSnippet(table), kw("ON"), escapeSnippet(table), escapeSnippet(key.Name))
+		},
+	},
+}
+
+// snippetCandidates returns the templates of the statements at the head of a
+// statement, the JOIN templates in a statement referring to tables, and the
+// user-defined snippets. The templates of the tables are only generated once
+// the last word starts with their prefix.
+func (c *Completer) snippetCandidates(lower, head, hasTables bool, lastWord string) []lsp.CompletionItem {
+	kw := func(s string) string {
+		if lower {
+			return strings.ToLower(s)
+		}
+		return s
+	}
+
+	candidates := []lsp.CompletionItem{}
+	if c.DBCache != nil {
+		for _, snippet := range tableSnippets {
+			if snippet.head != head || (!head && !hasTables) || !hasPrefixFold(lastWord, snippet.prefix) {
+				continue
+			}
+			for _, table := range c.DBCache.SortedTables() {
+				cols, ok := c.DBCache.ColumnDescs(table)
+				if !ok || len(cols) == 0 {
+					continue
+				}
+				candidates = append(candidates, snippetCandidate(snippet.prefix+table, fmt.Sprintf(snippet.detail, table), snippet.body(table, cols, kw)))
+			}
+		}
+	}
+
+	for _, snippet := range c.Snippets {
+		description := snippet.Description
+		if description == "" {
+			description = "snippet"
+		}
+		if !strings.Contains(snippet.Body, config.TablePlaceholder) {
+			candidates = append(candidates, snippetCandidate(snippet.Prefix, description, snippet.Body))
+			continue
+		}
+		if c.DBCache == nil || !hasPrefixFold(lastWord, snippet.Prefix) {
+			continue
+		}
+		for _, table := range c.DBCache.SortedTables() {
+			cols, _ := c.DBCache.ColumnDescs(table)
+			names := make([]string, len(cols))
+			for i, col := range cols {
+				names[i] = escapeSnippet(col.Name)
+			}
+			body := strings.Replace(snippet.Body, config.TablePlaceholder, escapeSnippet(table), -1)
+			body = strings.Replace(body, config.ColumnsPlaceholder, strings.Join(names, ", "), -1)
+			candidates = append(candidates, snippetCandidate(snippet.Prefix+table, description, body))
+		}
+	}
+	return candidates
+}
+
+func snippetCandidate(label, detail, body string) lsp.CompletionItem {
+	return lsp.CompletionItem{
+		Label:            label,
+		Kind:             lsp.SnippetCompletion,
+		Detail:           detail,
+		InsertText:       body,
+		InsertTextFormat: lsp.SnippetTextFormat,
+	}
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// escapeSnippet escapes the characters of a name having a meaning in the
+// snippet syntax.
+func escapeSnippet(name string) string {
+	return strings.NewReplacer(`\`, `\\`, `$`, `\$`, `}`, `\}`).Replace(name)
+}
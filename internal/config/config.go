@@ -19,18 +19,109 @@ var (
 	ymlConfigPath = configFilePath("config.yml")
 )
 
+// HistoryFilePath returns the path of the query history file.
+func HistoryFilePath() string {
+	return configFilePath("history.jsonl")
+}
+
 type Config struct {
 	LowercaseKeywords bool                 `json:"lowercaseKeywords" yaml:"lowercaseKeywords"`
+	RowLimit          int                  `json:"rowLimit" yaml:"rowLimit"`
+	JoinConventions   *JoinConventions     `json:"joinConventions" yaml:"joinConventions"`
+	Snippets          []*Snippet           `json:"snippets" yaml:"snippets"`
 	Connections       []*database.DBConfig `json:"connections" yaml:"connections"`
 }
 
+// TablePlaceholder stands for the name of the referenced table in
+// JoinConventions, such as "{table}_id".
+const TablePlaceholder = "{table}"
+
+var (
+	DefaultForeignKeyColumns = []string{TablePlaceholder + "_id"}
+	DefaultPrimaryKeyColumns = []string{"id"}
+)
+
+// JoinConventions are the naming conventions used to suggest join conditions
+// between tables without declared foreign keys.
+type JoinConventions struct {
+	// Disable turns off the suggestions inferred from the conventions
+	Disable bool `json:"disable" yaml:"disable"`
+	// ForeignKeyColumns are the names of the columns referring to another
+	// table, "{table}_id" by default
+	ForeignKeyColumns []string `json:"foreignKeyColumns" yaml:"foreignKeyColumns"`
+	// PrimaryKeyColumns are the names of the columns referred to, "id" by
+	// default
+	PrimaryKeyColumns []string `json:"primaryKeyColumns" yaml:"primaryKeyColumns"`
+	// IgnoreColumns are never joined because of identical names
+	IgnoreColumns []string `json:"ignoreColumns" yaml:"ignoreColumns"`
+}
+
+// ColumnsPlaceholder stands for the columns of the table in the body of a
+// Snippet, separated by commas.
+const ColumnsPlaceholder = "{columns}"
+
+// Snippet is a user-defined completion template in the snippet syntax of
+// LSP, such as "SELECT COUNT(*) FROM ${1:table}". A body containing "{table}"
+// is offered for every table, as the prefix followed by the table name, with
+// "{columns}" replaced by its columns.
+type Snippet struct {
+	// Prefix is the word completed into the snippet
+	Prefix string `json:"prefix" yaml:"prefix"`
+	// Body is the text inserted, with tab stops such as $1 or ${1:default}
+	Body string `json:"body" yaml:"body"`
+	// Description is shown with the completion item. Optional.
+	Description string `json:"description" yaml:"description"`
+}
+
+func (s *Snippet) Validate() error {
+	if s.Prefix == "" {
+		return errors.New("required: snippets[].prefix")
+	}
+	if s.Body == "" {
+		return errors.New("required: snippets[].body")
+	}
+	return nil
+}
+
 func (c *Config) Validate() error {
+	for _, snippet := range c.Snippets {
+		if err := snippet.Validate(); err != nil {
+			return err
+		}
+	}
 	if len(c.Connections) > 0 {
 		return c.Connections[0].Validate()
 	}
 	return nil
 }
 
+// QueryRowLimit returns the maximum number of rows shown per query execution.
+func (c *Config) QueryRowLimit() int {
+	if c.RowLimit <= 0 {
+		return database.DefaultRowLimit
+	}
+	return c.RowLimit
+}
+
+// JoinInference returns the conventions inferring join conditions with the
+// defaults filled in, or nil if the inference is disabled.
+func (c *Config) JoinInference() *JoinConventions {
+	conventions := &JoinConventions{}
+	if c.JoinConventions != nil {
+		if c.JoinConventions.Disable {
+			return nil
+		}
+		*conventions = *c.JoinConventions
+	}
+	if len(conventions.ForeignKeyColumns) == 0 {
+		conventions.ForeignKeyColumns = DefaultForeignKeyColumns
+	}
+	if len(conventions.PrimaryKeyColumns) == 0 {
+		conventions.PrimaryKeyColumns = DefaultPrimaryKeyColumns
+	}
+	return conventions
+}
+
 func NewConfig() *Config {
 	cfg := &Config{}
 	cfg.LowercaseKeywords = false
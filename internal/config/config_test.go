@@ -27,6 +27,17 @@ func TestGetConfig(t *testing.T) {
 			},
 			want: &Config{
 				LowercaseKeywords: true,
+				JoinConventions: &JoinConventions{
+					ForeignKeyColumns: []string{"{table}_id", "{table}_code"},
+					IgnoreColumns:     []string{"created_at", "updated_at"},
+				},
+				Snippets: []*Snippet{
+					{
+						Prefix:      "cnt",
+						Body:        "SELECT COUNT(*) FROM {table}$0",
+						Description: "count rows",
+					},
+				},
 				Connections: []*database.DBConfig{
 					{
 						Alias:  "sqls_mysql",
@@ -157,6 +168,51 @@ func TestGetConfig(t *testing.T) {
 			wantErr: true,
 			errMsg:  "failed validation, required: connections[].sshConfig.privateKey",
 		},
+		{
+			name: "invalid destructive guard",
+			args: args{
+				fp: "invalid_destructive_guard.yml",
+			},
+			want:    nil,
+			wantErr: true,
+			errMsg:  "failed validation, invalid: connections[].destructiveGuard",
+		},
+		{
+			name: "no audit log path",
+			args: args{
+				fp: "no_audit_log_path.yml",
+			},
+			want:    nil,
+			wantErr: true,
+			errMsg:  "failed validation, required: connections[].auditLog.path",
+		},
+		{
+			name: "invalid audit log redact",
+			args: args{
+				fp: "invalid_audit_log_redact.yml",
+			},
+			want:    nil,
+			wantErr: true,
+			errMsg:  "failed validation, invalid: connections[].auditLog.redact",
+		},
+		{
+			name: "no snippet body",
+			args: args{
+				fp: "no_snippet_body.yml",
+			},
+			want:    nil,
+			wantErr: true,
+			errMsg:  "failed validation, required: snippets[].body",
+		},
+		{
+			name: "invalid preview rows",
+			args: args{
+				fp: "invalid_preview_rows.yml",
+			},
+			want:    nil,
+			wantErr: true,
+			errMsg:  "failed validation, invalid: connections[].preview.rows",
+		},
 	}
 	for _, tt := range tests {
 		packageDir, err := os.Getwd()
@@ -183,3 +239,48 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_JoinInference(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		want *JoinConventions
+	}{
+		{
+			name: "default",
+			cfg:  &Config{},
+			want: &JoinConventions{
+				ForeignKeyColumns: []string{"{table}_id"},
+				PrimaryKeyColumns: []string{"id"},
+			},
+		},
+		{
+			name: "configured",
+			cfg: &Config{
+				JoinConventions: &JoinConventions{
+					PrimaryKeyColumns: []string{"{table}_id"},
+					IgnoreColumns:     []string{"created_at"},
+				},
+			},
+			want: &JoinConventions{
+				ForeignKeyColumns: []string{"{table}_id"},
+				PrimaryKeyColumns: []string{"{table}_id"},
+				IgnoreColumns:     []string{"created_at"},
+			},
+		},
+		{
+			name: "disabled",
+			cfg: &Config{
+				JoinConventions: &JoinConventions{Disable: true},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.cfg.JoinInference()); diff != "" {
+				t.Errorf("unmatch (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"log"
 	"sort"
 	"strings"
 )
@@ -38,6 +39,38 @@ func (u *DBCacheGenerator) GenerateDBCachePrimary(ctx context.Context) (*DBCache
 	return dbCache, nil
 }
 
+// GenerateDBCacheExtras loads the objects enriching completion and hover, such
+// as views and routines, into a cache holding only them. They are loaded
+// best-effort: a catalog that cannot be read, for lack of privileges or an
+// older server version, is logged and left empty.
+func (u *DBCacheGenerator) GenerateDBCacheExtras(ctx context.Context, defaultSchema string) *DBCache {
+	dbCache := &DBCache{defaultSchema: defaultSchema}
+	var err error
+	if dbCache.ForeignKeys, err = u.repo.SchemaForeignKeys(ctx, dbCache.defaultSchema); err != nil {
+		logExtraError("foreign keys", err)
+	}
+	if dbCache.SchemaViews, err = u.repo.SchemaViews(ctx); err != nil {
+		logExtraError("views", err)
+	}
+	if dbCache.Routines, err = u.repo.SchemaRoutines(ctx); err != nil {
+		logExtraError("routines", err)
+	}
+	if dbCache.SchemaSequences, err = u.repo.SchemaSequences(ctx); err != nil {
+		logExtraError("sequences", err)
+	}
+	if dbCache.Types, err = u.repo.SchemaTypes(ctx); err != nil {
+		logExtraError("types", err)
+	}
+	if dbCache.TableComments, err = u.genTableCommentCache(ctx); err != nil {
+		logExtraError("table comments", err)
+	}
+	return dbCache
+}
+
+func logExtraError(name string, err error) {
+	log.Printf("db cache: cannot load %s, %s", name, err)
+}
+
 func (u *DBCacheGenerator) GenerateDBCacheSecondary(ctx context.Context) (map[string][]*ColumnDesc, error) {
 	return u.genColumnCacheAll(ctx)
 }
@@ -54,6 +87,18 @@ func (u *DBCacheGenerator) genSchmeaCache(ctx context.Context) (map[string]strin
 	return databaseMap, nil
 }
 
+func (u *DBCacheGenerator) genTableCommentCache(ctx context.Context) (map[string]string, error) {
+	comments, err := u.repo.TableComments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commentMap := map[string]string{}
+	for _, comment := range comments {
+		commentMap[columnDatabaseKey(comment.Schema, comment.Table)] = comment.Comment
+	}
+	return commentMap, nil
+}
+
 func (u *DBCacheGenerator) genColumnCacheCurrent(ctx context.Context, schemaName string) (map[string][]*ColumnDesc, error) {
 	columnDescs, err := u.repo.DescribeDatabaseTableBySchema(ctx, schemaName)
 	if err != nil {
@@ -84,11 +129,34 @@ func genColumnMap(columnDescs []*ColumnDesc) map[string][]*ColumnDesc {
 	return columnMap
 }
 
+// withExtras returns a copy of the cache with the extras of another one.
+func (dc *DBCache) withExtras(extras *DBCache) *DBCache {
+	c := *dc
+	c.ForeignKeys = extras.ForeignKeys
+	c.SchemaViews = extras.SchemaViews
+	c.Routines = extras.Routines
+	c.SchemaSequences = extras.SchemaSequences
+	c.Types = extras.Types
+	c.TableComments = extras.TableComments
+	return &c
+}
+
+// DBCache holds the database objects. It must not be modified once built, as
+// it is read by the handlers while the Worker builds its successor.
 type DBCache struct {
 	defaultSchema     string
 	Schemas           map[string]string
 	SchemaTables      map[string][]string
 	ColumnsWithParent map[string][]*ColumnDesc
+	// ForeignKeys holds the foreign keys of the tables in the default schema
+	ForeignKeys     []*ForeignKeyDesc
+	SchemaViews     map[string][]string
+	Routines        []*RoutineDesc
+	SchemaSequences map[string][]string
+	Types           []*TypeDesc
+	// TableComments holds the comments of the tables and views by schema and
+	// table name
+	TableComments map[string]string
 }
 
 func (dc *DBCache) Database(dbName string) (db string, ok bool) {
@@ -107,10 +175,21 @@ func (dc *DBCache) SortedSchemas() []string {
 
 func (dc *DBCache) SortedTablesByDBName(dbName string) (tbls []string, ok bool) {
 	tbls, ok = dc.SchemaTables[dbName]
-	sort.Strings(tbls)
+	tbls = sortedCopy(tbls)
 	return
 }
 
+// sortedCopy sorts a copy of the names, leaving the cache as is.
+func sortedCopy(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	sorted := make([]string, len(names))
+	copy(sorted, names)
+	sort.Strings(sorted)
+	return sorted
+}
+
 func (dc *DBCache) SortedTables() []string {
 	tbls, _ := dc.SortedTablesByDBName(dc.defaultSchema)
 	return tbls
@@ -139,6 +218,104 @@ func (dc *DBCache) Column(tableName, colName string) (*ColumnDesc, bool) {
 	return nil, false
 }
 
+// TableComment returns the comment of the table or view, or an empty string
+// if it has none. An empty schema stands for the default schema.
+func (dc *DBCache) TableComment(dbName, tableName string) string {
+	return dc.TableComments[columnDatabaseKey(dc.schemaOrDefault(dbName), tableName)]
+}
+
+func (dc *DBCache) SortedViewsByDBName(dbName string) (views []string, ok bool) {
+	views, ok = dc.SchemaViews[dbName]
+	views = sortedCopy(views)
+	return
+}
+
+func (dc *DBCache) SortedViews() []string {
+	views, _ := dc.SortedViewsByDBName(dc.defaultSchema)
+	return views
+}
+
+// IsView reports whether the table is a view. An empty schema stands for the
+// default schema.
+func (dc *DBCache) IsView(dbName, tableName string) bool {
+	return containsFold(dc.SchemaViews[dc.schemaOrDefault(dbName)], tableName)
+}
+
+// SchemaRoutines returns the functions and procedures of the schema. An empty
+// schema stands for the default schema.
+func (dc *DBCache) SchemaRoutines(dbName string) []*RoutineDesc {
+	routines := []*RoutineDesc{}
+	for _, routine := range dc.Routines {
+		if strings.EqualFold(routine.Schema, dc.schemaOrDefault(dbName)) {
+			routines = append(routines, routine)
+		}
+	}
+	return routines
+}
+
+// Routine returns the functions and procedures with the name, more than one
+// if it is overloaded. An empty schema stands for the default schema.
+func (dc *DBCache) Routine(dbName, name string) []*RoutineDesc {
+	routines := []*RoutineDesc{}
+	for _, routine := range dc.SchemaRoutines(dbName) {
+		if strings.EqualFold(routine.Name, name) {
+			routines = append(routines, routine)
+		}
+	}
+	return routines
+}
+
+// IsSequence reports whether the name is a sequence. An empty schema stands
+// for the default schema.
+func (dc *DBCache) IsSequence(dbName, name string) bool {
+	return containsFold(dc.SchemaSequences[dc.schemaOrDefault(dbName)], name)
+}
+
+// Type returns the user-defined type with the name. An empty schema stands
+// for the default schema.
+func (dc *DBCache) Type(dbName, name string) (*TypeDesc, bool) {
+	for _, typ := range dc.Types {
+		if strings.EqualFold(typ.Schema, dc.schemaOrDefault(dbName)) && strings.EqualFold(typ.Name, name) {
+			return typ, true
+		}
+	}
+	return nil, false
+}
+
+func (dc *DBCache) schemaOrDefault(dbName string) string {
+	if dbName == "" {
+		return dc.defaultSchema
+	}
+	return dbName
+}
+
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// JoinForeignKeys returns the foreign keys between two tables in either
+// direction. An empty schema stands for the default schema.
+func (dc *DBCache) JoinForeignKeys(schema1, table1, schema2, table2 string) []*ForeignKeyDesc {
+	fks := []*ForeignKeyDesc{}
+	for _, fk := range dc.ForeignKeys {
+		from := dc.isTable(fk.Schema, fk.Table, schema1, table1) && dc.isTable(fk.RefSchema, fk.RefTable, schema2, table2)
+		to := dc.isTable(fk.Schema, fk.Table, schema2, table2) && dc.isTable(fk.RefSchema, fk.RefTable, schema1, table1)
+		if from || to {
+			fks = append(fks, fk)
+		}
+	}
+	return fks
+}
+
+func (dc *DBCache) isTable(schema, table, expectSchema, expectTable string) bool {
+	return strings.EqualFold(schema, dc.schemaOrDefault(expectSchema)) && strings.EqualFold(table, expectTable)
+}
+
 func columnDatabaseKey(dbName, tableName string) string {
 	return dbName + "\t" + tableName
 }
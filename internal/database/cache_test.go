@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGenerateDBCacheExtrasBestEffort(t *testing.T) {
+	repo := NewMockDBRepository(nil).(*MockDBRepository)
+	denied := errors.New("permission denied")
+	repo.MockSchemaForeignKeys = func(context.Context, string) ([]*ForeignKeyDesc, error) { return nil, denied }
+	repo.MockSchemaViews = func(context.Context) (map[string][]string, error) { return nil, denied }
+	repo.MockSchemaRoutines = func(context.Context) ([]*RoutineDesc, error) { return nil, denied }
+	repo.MockSchemaSequences = func(context.Context) (map[string][]string, error) { return nil, denied }
+	repo.MockSchemaTypes = func(context.Context) ([]*TypeDesc, error) { return nil, denied }
+	repo.MockTableComments = func(context.Context) ([]*TableCommentDesc, error) { return nil, denied }
+
+	cache, err := NewDBCacheUpdater(repo).GenerateDBCachePrimary(context.Background())
+	if err != nil {
+		t.Fatal("extras must not fail the cache:", err)
+	}
+	if len(cache.SortedTables()) == 0 {
+		t.Error("tables must be cached")
+	}
+	cache = cache.withExtras(NewDBCacheUpdater(repo).GenerateDBCacheExtras(context.Background(), cache.defaultSchema))
+	if len(cache.SortedTables()) == 0 {
+		t.Error("tables must be kept with the extras")
+	}
+	if len(cache.SortedViews()) != 0 || len(cache.Routines) != 0 {
+		t.Error("extras failing to load must be left empty")
+	}
+}
+
+func TestWorkerLoadsExtrasInBackground(t *testing.T) {
+	repo := NewMockDBRepository(nil)
+	w := NewWorker()
+	if err := w.updateAllCache(context.Background(), repo); err != nil {
+		t.Fatal(err)
+	}
+	if cache := w.Cache(); cache.SchemaViews != nil || cache.Routines != nil {
+		t.Error("extras must not be loaded while connecting")
+	}
+	w.updateSecondaryCache()
+	if cache := w.Cache(); len(cache.SortedViews()) == 0 || len(cache.Routines) == 0 {
+		t.Error("extras must be loaded by the secondary update")
+	}
+}
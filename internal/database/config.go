@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"regexp"
+	"time"
 
 	"github.com/lighttiger2505/sqls/dialect"
 	"golang.org/x/crypto/ssh"
@@ -18,6 +20,16 @@ const (
 	ProtoUnix Proto = "unix"
 )
 
+// GuardMode controls how destructive statements such as DROP, TRUNCATE and
+// DELETE/UPDATE without a WHERE clause are handled before execution.
+type GuardMode string
+
+const (
+	GuardModeOff   GuardMode = "off"
+	GuardModeWarn  GuardMode = "warn"
+	GuardModeBlock GuardMode = "block"
+)
+
 type DBConfig struct {
 	Alias          string                 `json:"alias" yaml:"alias"`
 	Driver         dialect.DatabaseDriver `json:"driver" yaml:"driver"`
@@ -31,6 +43,19 @@ type DBConfig struct {
 	DBName         string                 `json:"dbName" yaml:"dbName"`
 	Params         map[string]string      `json:"params" yaml:"params"`
 	SSHCfg         *SSHConfig             `json:"sshConfig" yaml:"sshConfig"`
+	GuardMode      GuardMode              `json:"destructiveGuard" yaml:"destructiveGuard"`
+	ReadOnly       bool                   `json:"readOnly" yaml:"readOnly"`
+	AuditLog       *AuditLogConfig        `json:"auditLog" yaml:"auditLog"`
+	Preview        *PreviewConfig         `json:"preview" yaml:"preview"`
+}
+
+// DestructiveGuard returns the guard mode for destructive statements, warn by
+// default and when no connection is configured.
+func (c *DBConfig) DestructiveGuard() GuardMode {
+	if c == nil || c.GuardMode == "" {
+		return GuardModeWarn
+	}
+	return c.GuardMode
 }
 
 func (c *DBConfig) Validate() error {
@@ -38,6 +63,24 @@ func (c *DBConfig) Validate() error {
 		return errors.New("required: connections[].driver")
 	}
 
+	switch c.GuardMode {
+	case "", GuardModeOff, GuardModeWarn, GuardModeBlock:
+	default:
+		return errors.New("invalid: connections[].destructiveGuard")
+	}
+
+	if c.AuditLog != nil {
+		if err := c.AuditLog.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Preview != nil {
+		if err := c.Preview.Validate(); err != nil {
+			return err
+		}
+	}
+
 	switch c.Driver {
 	case
 		dialect.DatabaseDriverMySQL,
@@ -79,6 +122,69 @@ func (c *DBConfig) Validate() error {
 	return nil
 }
 
+// AuditLogConfig is the setting of the append-only log of the statements
+// executed on a connection.
+type AuditLogConfig struct {
+	Path string `json:"path" yaml:"path"`
+	// Redact is the regular expressions of the placeholders, such as :password
+	// or $2, whose values are not written to the log
+	Redact []string `json:"redact" yaml:"redact"`
+}
+
+func (c *AuditLogConfig) Validate() error {
+	if c.Path == "" {
+		return errors.New("required: connections[].auditLog.path")
+	}
+	for _, pattern := range c.Redact {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return errors.New("invalid: connections[].auditLog.redact")
+		}
+	}
+	return nil
+}
+
+const (
+	DefaultPreviewRows    = 5
+	DefaultPreviewTimeout = 1000
+	MaxPreviewRows        = 100
+)
+
+// PreviewConfig enables the sample rows of the tables of a connection, shown
+// in the hover of a table and by the previewTable command.
+type PreviewConfig struct {
+	// Rows is the number of rows sampled from a table
+	Rows int `json:"rows" yaml:"rows"`
+	// Timeout is the time limit of the sampling query in milliseconds
+	Timeout int `json:"timeout" yaml:"timeout"`
+}
+
+// RowLimit returns the number of rows sampled from a table, 5 by default.
+func (c *PreviewConfig) RowLimit() int {
+	if c.Rows == 0 {
+		return DefaultPreviewRows
+	}
+	return c.Rows
+}
+
+// QueryTimeout returns the time limit of the sampling query, 1 second by
+// default.
+func (c *PreviewConfig) QueryTimeout() time.Duration {
+	if c.Timeout == 0 {
+		return DefaultPreviewTimeout * time.Millisecond
+	}
+	return time.Duration(c.Timeout) * time.Millisecond
+}
+
+func (c *PreviewConfig) Validate() error {
+	if c.Rows < 0 || c.Rows > MaxPreviewRows {
+		return errors.New("invalid: connections[].preview.rows")
+	}
+	if c.Timeout < 0 {
+		return errors.New("invalid: connections[].preview.timeout")
+	}
+	return nil
+}
+
 type SSHConfig struct {
 	Host       string `json:"host" yaml:"host"`
 	Port       int    `json:"port" yaml:"port"`
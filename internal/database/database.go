@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/lighttiger2505/sqls/dialect"
@@ -19,6 +20,7 @@ var (
 const (
 	DefaultMaxIdleConns = 10
 	DefaultMaxOpenConns = 5
+	DefaultRowLimit     = 1000
 )
 
 type DBRepository interface {
@@ -30,6 +32,17 @@ type DBRepository interface {
 	SchemaTables(ctx context.Context) (map[string][]string, error)
 	DescribeDatabaseTable(ctx context.Context) ([]*ColumnDesc, error)
 	DescribeDatabaseTableBySchema(ctx context.Context, schemaName string) ([]*ColumnDesc, error)
+	CreateTableStatement(ctx context.Context, schemaName, tableName string) (string, error)
+	TableIndexes(ctx context.Context, schemaName, tableName string) ([]*IndexDesc, error)
+	TableConstraints(ctx context.Context, schemaName, tableName string) ([]*ConstraintDesc, error)
+	TableForeignKeys(ctx context.Context, schemaName, tableName string) ([]*ForeignKeyDesc, error)
+	SchemaForeignKeys(ctx context.Context, schemaName string) ([]*ForeignKeyDesc, error)
+	SchemaViews(ctx context.Context) (map[string][]string, error)
+	SchemaRoutines(ctx context.Context) ([]*RoutineDesc, error)
+	SchemaSequences(ctx context.Context) (map[string][]string, error)
+	SchemaTypes(ctx context.Context) ([]*TypeDesc, error)
+	TableComments(ctx context.Context) ([]*TableCommentDesc, error)
+	TableStats(ctx context.Context, schemaName, tableName string) (*TableStatsDesc, error)
 	Exec(ctx context.Context, query string) (sql.Result, error)
 	Query(ctx context.Context, query string) (*sql.Rows, error)
 }
@@ -48,6 +61,152 @@ type ColumnDesc struct {
 	Key     string
 	Default sql.NullString
 	Extra   string
+	Comment string
+}
+
+const (
+	ConstraintTypeUnique = "UNIQUE"
+	ConstraintTypeCheck  = "CHECK"
+)
+
+// IndexDesc is an index of a table, with its columns or expressions in order.
+type IndexDesc struct {
+	Name    string
+	Columns []string
+	Unique  bool
+	Primary bool
+}
+
+// ConstraintDesc is a unique or check constraint of a table.
+type ConstraintDesc struct {
+	Name       string
+	Type       string
+	Columns    []string
+	Definition string
+}
+
+// ForeignKeyDesc is a foreign key from the columns of a table to the columns
+// of the referenced table.
+type ForeignKeyDesc struct {
+	Name       string
+	Schema     string
+	Table      string
+	Columns    []string
+	RefSchema  string
+	RefTable   string
+	RefColumns []string
+	OnUpdate   string
+	OnDelete   string
+}
+
+const (
+	RoutineTypeFunction  = "FUNCTION"
+	RoutineTypeProcedure = "PROCEDURE"
+)
+
+// RoutineDesc is a stored function or procedure. Arguments are the
+// declarations of the arguments in order, such as "code char(3)".
+type RoutineDesc struct {
+	Schema     string
+	Name       string
+	Type       string
+	Arguments  []string
+	ReturnType string
+}
+
+// Signature returns the declaration of the routine, such as
+// "population(code char(3)) RETURNS int".
+func (rd *RoutineDesc) Signature() string {
+	sig := fmt.Sprintf("%s(%s)", rd.Name, strings.Join(rd.Arguments, ", "))
+	if rd.ReturnType != "" {
+		sig += " RETURNS " + rd.ReturnType
+	}
+	return sig
+}
+
+func (rd *RoutineDesc) sameRoutine(other *RoutineDesc) bool {
+	return rd.Schema == other.Schema && rd.Name == other.Name && rd.Type == other.Type
+}
+
+// TypeDesc is a user-defined type. Definition holds the labels of an enum, the
+// attributes of a composite type or the base type of a domain.
+type TypeDesc struct {
+	Schema     string
+	Name       string
+	Kind       string
+	Definition string
+}
+
+// TableCommentDesc is the comment of a table or a view.
+type TableCommentDesc struct {
+	Schema  string
+	Table   string
+	Comment string
+}
+
+// scanSchemaNames reads the rows of schema and object names into a map of the
+// names by schema.
+func scanSchemaNames(rows *sql.Rows) (map[string][]string, error) {
+	defer rows.Close()
+	names := map[string][]string{}
+	for rows.Next() {
+		var schema, name string
+		if err := rows.Scan(&schema, &name); err != nil {
+			return nil, err
+		}
+		names[schema] = append(names[schema], name)
+	}
+	return names, rows.Err()
+}
+
+// TableStatsDesc is the statistics of a table as estimated by the database.
+// The statistics the database does not provide are null. Sizes are in bytes.
+type TableStatsDesc struct {
+	Rows         sql.NullInt64
+	DataSize     sql.NullInt64
+	IndexSize    sql.NullInt64
+	LastAnalyzed sql.NullString
+}
+
+func scanTableComments(rows *sql.Rows) ([]*TableCommentDesc, error) {
+	defer rows.Close()
+	comments := []*TableCommentDesc{}
+	for rows.Next() {
+		var comment TableCommentDesc
+		if err := rows.Scan(&comment.Schema, &comment.Table, &comment.Comment); err != nil {
+			return nil, err
+		}
+		comments = append(comments, &comment)
+	}
+	return comments, rows.Err()
+}
+
+// SameTypeKind reports whether the values of the columns are of the same kind,
+// such as integers or text, regardless of their size.
+func (cd *ColumnDesc) SameTypeKind(other *ColumnDesc) bool {
+	return columnValueKind(cd.Type) == columnValueKind(other.Type)
+}
+
+// uniqueDefinition returns the definition of a UNIQUE constraint on the
+// columns.
+func uniqueDefinition(driver dialect.DatabaseDriver, columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = QuoteIdentifier(driver, col)
+	}
+	return fmt.Sprintf("UNIQUE (%s)", strings.Join(quoted, ", "))
+}
+
+// IsPrimaryKey reports whether the column is part of the primary key. The
+// drivers describe it as "PRI" (MySQL), "YES" (PostgreSQL) or the position in
+// the key (SQLite).
+func (cd *ColumnDesc) IsPrimaryKey() bool {
+	switch cd.Key {
+	case "PRI", "YES":
+		return true
+	}
+	n, err := strconv.Atoi(cd.Key)
+	return err == nil && n > 0
 }
 
 func (cd *ColumnDesc) OnelineDesc() string {
@@ -64,8 +223,14 @@ func (cd *ColumnDesc) OnelineDesc() string {
 	return strings.Join(items, " ")
 }
 
+// OnelineDescWithName returns the name and the description of the column,
+// followed by its comment as a SQL comment if it has one.
 func (cd *ColumnDesc) OnelineDescWithName() string {
-	return fmt.Sprintf("%s: %s", cd.Name, cd.OnelineDesc())
+	desc := fmt.Sprintf("%s: %s", cd.Name, cd.OnelineDesc())
+	if cd.Comment != "" {
+		desc += " -- " + cd.Comment
+	}
+	return desc
 }
 
 func ColumnDoc(tableName string, colDesc *ColumnDesc) string {
@@ -74,14 +239,34 @@ func ColumnDoc(tableName string, colDesc *ColumnDesc) string {
 	fmt.Fprintln(buf)
 	fmt.Fprintln(buf)
 	fmt.Fprintln(buf, colDesc.OnelineDesc())
+	if colDesc.Comment != "" {
+		fmt.Fprintln(buf)
+		fmt.Fprintln(buf, colDesc.Comment)
+	}
 	return buf.String()
 }
 
-func TableDoc(tableName string, cols []*ColumnDesc) string {
+// TableDoc returns the document of a table with its comment, if any, and its
+// columns.
+func TableDoc(tableName, comment string, cols []*ColumnDesc) string {
+	return relationDoc(tableName+" table", comment, cols)
+}
+
+// ViewDoc returns the document of a view with its comment, if any, and its
+// columns.
+func ViewDoc(viewName, comment string, cols []*ColumnDesc) string {
+	return relationDoc(viewName+" view", comment, cols)
+}
+
+func relationDoc(title, comment string, cols []*ColumnDesc) string {
 	buf := new(bytes.Buffer)
-	fmt.Fprintf(buf, "%s table", tableName)
+	fmt.Fprint(buf, title)
 	fmt.Fprintln(buf)
 	fmt.Fprintln(buf)
+	if comment != "" {
+		fmt.Fprintln(buf, comment)
+		fmt.Fprintln(buf)
+	}
 	for _, col := range cols {
 		fmt.Fprintf(buf, "- %s", col.OnelineDescWithName())
 		fmt.Fprintln(buf)
@@ -89,6 +274,133 @@ func TableDoc(tableName string, cols []*ColumnDesc) string {
 	return buf.String()
 }
 
+func RoutineDoc(routine *RoutineDesc) string {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "%s %s", routine.Name, strings.ToLower(routine.Type))
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, routine.Signature())
+	return buf.String()
+}
+
+// FunctionDoc returns the document of a built-in function with the signature
+// and description of each of its overloads.
+func FunctionDoc(funcs []*dialect.FunctionDesc) string {
+	buf := new(bytes.Buffer)
+	if len(funcs) == 0 {
+		return ""
+	}
+	fmt.Fprintf(buf, "%s function", funcs[0].Name)
+	fmt.Fprintln(buf)
+	for _, fn := range funcs {
+		fmt.Fprintln(buf)
+		fmt.Fprintln(buf, fn.Signature())
+		if fn.Description != "" {
+			fmt.Fprintln(buf)
+			fmt.Fprintln(buf, fn.Description)
+		}
+	}
+	return buf.String()
+}
+
+// KeywordDoc returns the document of a keyword with its syntax summary and
+// description. The syntax spans lines, so it is rendered as a code block.
+func KeywordDoc(kw *dialect.KeywordDesc) string {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "%s keyword", kw.Name)
+	fmt.Fprintln(buf)
+	if kw.Syntax != "" {
+		fmt.Fprintln(buf)
+		fmt.Fprintln(buf, "```sql")
+		fmt.Fprintln(buf, kw.Syntax)
+		fmt.Fprintln(buf, "```")
+	}
+	if kw.Description != "" {
+		fmt.Fprintln(buf)
+		fmt.Fprintln(buf, kw.Description)
+	}
+	return buf.String()
+}
+
+// TableStatsDoc returns the statistics of a table on a line, or an empty
+// string if there are none.
+func TableStatsDoc(stats *TableStatsDesc) string {
+	items := []string{}
+	if stats.Rows.Valid {
+		items = append(items, fmt.Sprintf("about %s rows", formatCount(stats.Rows.Int64)))
+	}
+	if stats.DataSize.Valid {
+		items = append(items, fmt.Sprintf("%s data", formatSize(stats.DataSize.Int64)))
+	}
+	if stats.IndexSize.Valid {
+		items = append(items, fmt.Sprintf("%s indexes", formatSize(stats.IndexSize.Int64)))
+	}
+	if stats.LastAnalyzed.Valid {
+		items = append(items, fmt.Sprintf("analyzed %s", stats.LastAnalyzed.String))
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Statistics: %s\n", strings.Join(items, ", "))
+}
+
+// formatCount returns the number with a comma between each group of three
+// digits.
+func formatCount(n int64) string {
+	if n < 0 {
+		return "-" + formatCount(-n)
+	}
+	s := strconv.FormatInt(n, 10)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// formatSize returns the size in bytes in the largest binary unit it reaches.
+func formatSize(n int64) string {
+	units := []string{"KiB", "MiB", "GiB", "TiB"}
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	size := float64(n) / 1024
+	unit := 0
+	for size >= 1024 && unit < len(units)-1 {
+		size /= 1024
+		unit++
+	}
+	return fmt.Sprintf("%.1f %s", size, units[unit])
+}
+
+func SequenceDoc(sequenceName string) string {
+	return fmt.Sprintf("%s sequence\n", sequenceName)
+}
+
+func TypeDoc(typeDesc *TypeDesc) string {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "%s %s type", typeDesc.Name, typeDesc.Kind)
+	fmt.Fprintln(buf)
+	if typeDesc.Definition != "" {
+		fmt.Fprintln(buf)
+		fmt.Fprintln(buf, typeDesc.Definition)
+	}
+	return buf.String()
+}
+
+func ForeignKeyDoc(fk *ForeignKeyDesc) string {
+	buf := new(bytes.Buffer)
+	if fk.Name != "" {
+		fmt.Fprintf(buf, "%s foreign key", fk.Name)
+	} else {
+		fmt.Fprint(buf, "foreign key")
+	}
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf)
+	fmt.Fprintf(buf, "%s(%s) references %s(%s)", fk.Table, strings.Join(fk.Columns, ", "), fk.RefTable, strings.Join(fk.RefColumns, ", "))
+	fmt.Fprintln(buf)
+	return buf.String()
+}
+
 func SubqueryDoc(name string, views []*parseutil.SubQueryView, dbCache *DBCache) string {
 	buf := new(bytes.Buffer)
 	fmt.Fprintf(buf, "%s subquery", name)
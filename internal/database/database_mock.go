@@ -15,6 +15,17 @@ type MockDBRepository struct {
 	MockDescribeTable                 func(context.Context, string) ([]*ColumnDesc, error)
 	MockDescribeDatabaseTable         func(context.Context) ([]*ColumnDesc, error)
 	MockDescribeDatabaseTableBySchema func(context.Context, string) ([]*ColumnDesc, error)
+	MockCreateTableStatement          func(context.Context, string, string) (string, error)
+	MockTableIndexes                  func(context.Context, string, string) ([]*IndexDesc, error)
+	MockTableConstraints              func(context.Context, string, string) ([]*ConstraintDesc, error)
+	MockTableForeignKeys              func(context.Context, string, string) ([]*ForeignKeyDesc, error)
+	MockSchemaForeignKeys             func(context.Context, string) ([]*ForeignKeyDesc, error)
+	MockSchemaViews                   func(context.Context) (map[string][]string, error)
+	MockSchemaRoutines                func(context.Context) ([]*RoutineDesc, error)
+	MockSchemaSequences               func(context.Context) (map[string][]string, error)
+	MockSchemaTypes                   func(context.Context) ([]*TypeDesc, error)
+	MockTableComments                 func(context.Context) ([]*TableCommentDesc, error)
+	MockTableStats                    func(context.Context, string, string) (*TableStatsDesc, error)
 	MockExec                          func(context.Context, string) (sql.Result, error)
 	MockQuery                         func(context.Context, string) (*sql.Rows, error)
 }
@@ -41,6 +52,7 @@ func NewMockDBRepository(conn *sql.DB) DBRepository {
 			res = append(res, dummyCityColumns...)
 			res = append(res, dummyCountryColumns...)
 			res = append(res, dummyCountryLanguageColumns...)
+			res = append(res, dummyCityListColumns...)
 			return res, nil
 
 		},
@@ -49,9 +61,63 @@ func NewMockDBRepository(conn *sql.DB) DBRepository {
 			res = append(res, dummyCityColumns...)
 			res = append(res, dummyCountryColumns...)
 			res = append(res, dummyCountryLanguageColumns...)
+			res = append(res, dummyCityListColumns...)
 			return res, nil
 
 		},
+		MockCreateTableStatement: func(ctx context.Context, schemaName, tableName string) (string, error) {
+			if tableName != "city" {
+				return "", ErrNotImplementation
+			}
+			return dummyCityCreateTable, nil
+		},
+		MockTableIndexes: func(ctx context.Context, schemaName, tableName string) ([]*IndexDesc, error) {
+			if tableName != "city" {
+				return []*IndexDesc{}, nil
+			}
+			return dummyCityIndexes, nil
+		},
+		MockTableConstraints: func(ctx context.Context, schemaName, tableName string) ([]*ConstraintDesc, error) {
+			if tableName != "city" {
+				return []*ConstraintDesc{}, nil
+			}
+			return dummyCityConstraints, nil
+		},
+		MockTableForeignKeys: func(ctx context.Context, schemaName, tableName string) ([]*ForeignKeyDesc, error) {
+			if tableName != "city" {
+				return []*ForeignKeyDesc{}, nil
+			}
+			return dummyCityForeignKeys, nil
+		},
+		MockSchemaForeignKeys: func(ctx context.Context, schemaName string) ([]*ForeignKeyDesc, error) {
+			res := []*ForeignKeyDesc{}
+			res = append(res, dummyCityForeignKeys...)
+			res = append(res, dummyCountryLanguageForeignKeys...)
+			return res, nil
+		},
+		MockSchemaViews: func(ctx context.Context) (map[string][]string, error) {
+			return dummySchemaViews, nil
+		},
+		MockSchemaRoutines: func(ctx context.Context) ([]*RoutineDesc, error) {
+			return dummyRoutines, nil
+		},
+		MockSchemaSequences: func(ctx context.Context) (map[string][]string, error) {
+			return dummySchemaSequences, nil
+		},
+		MockSchemaTypes: func(ctx context.Context) ([]*TypeDesc, error) {
+			return dummyTypes, nil
+		},
+		MockTableComments: func(ctx context.Context) ([]*TableCommentDesc, error) {
+			return dummyTableComments, nil
+		},
+		MockTableStats: func(ctx context.Context, schemaName, tableName string) (*TableStatsDesc, error) {
+			switch tableName {
+			case "countrylanguage":
+				return dummyCountryLanguageStats, nil
+			default:
+				return &TableStatsDesc{}, nil
+			}
+		},
 		MockExec: func(ctx context.Context, query string) (sql.Result, error) {
 			return &MockResult{
 				MockLastInsertID: func() (int64, error) { return 11, nil },
@@ -100,6 +166,50 @@ func (m *MockDBRepository) DescribeDatabaseTableBySchema(ctx context.Context, sc
 	return m.MockDescribeDatabaseTableBySchema(ctx, schemaName)
 }
 
+func (m *MockDBRepository) CreateTableStatement(ctx context.Context, schemaName, tableName string) (string, error) {
+	return m.MockCreateTableStatement(ctx, schemaName, tableName)
+}
+
+func (m *MockDBRepository) TableIndexes(ctx context.Context, schemaName, tableName string) ([]*IndexDesc, error) {
+	return m.MockTableIndexes(ctx, schemaName, tableName)
+}
+
+func (m *MockDBRepository) TableConstraints(ctx context.Context, schemaName, tableName string) ([]*ConstraintDesc, error) {
+	return m.MockTableConstraints(ctx, schemaName, tableName)
+}
+
+func (m *MockDBRepository) TableForeignKeys(ctx context.Context, schemaName, tableName string) ([]*ForeignKeyDesc, error) {
+	return m.MockTableForeignKeys(ctx, schemaName, tableName)
+}
+
+func (m *MockDBRepository) SchemaForeignKeys(ctx context.Context, schemaName string) ([]*ForeignKeyDesc, error) {
+	return m.MockSchemaForeignKeys(ctx, schemaName)
+}
+
+func (m *MockDBRepository) SchemaViews(ctx context.Context) (map[string][]string, error) {
+	return m.MockSchemaViews(ctx)
+}
+
+func (m *MockDBRepository) SchemaRoutines(ctx context.Context) ([]*RoutineDesc, error) {
+	return m.MockSchemaRoutines(ctx)
+}
+
+func (m *MockDBRepository) SchemaSequences(ctx context.Context) (map[string][]string, error) {
+	return m.MockSchemaSequences(ctx)
+}
+
+func (m *MockDBRepository) SchemaTypes(ctx context.Context) ([]*TypeDesc, error) {
+	return m.MockSchemaTypes(ctx)
+}
+
+func (m *MockDBRepository) TableComments(ctx context.Context) ([]*TableCommentDesc, error) {
+	return m.MockTableComments(ctx)
+}
+
+func (m *MockDBRepository) TableStats(ctx context.Context, schemaName, tableName string) (*TableStatsDesc, error) {
+	return m.MockTableStats(ctx, schemaName, tableName)
+}
+
 func (m *MockDBRepository) Exec(ctx context.Context, query string) (sql.Result, error) {
 	return m.MockExec(ctx, query)
 }
@@ -127,6 +237,126 @@ var dummyTables = []string{
 	"country",
 	"countrylanguage",
 }
+var dummySchemaViews = map[string][]string{
+	"world": []string{
+		"citylist",
+	},
+}
+var dummyRoutines = []*RoutineDesc{
+	{
+		Schema:     "world",
+		Name:       "city_count",
+		Type:       RoutineTypeFunction,
+		Arguments:  []string{"country_code char(3)", "min_population int"},
+		ReturnType: "int",
+	},
+	{
+		Schema:    "world",
+		Name:      "refresh_citylist",
+		Type:      RoutineTypeProcedure,
+		Arguments: []string{"IN country_code char(3)"},
+	},
+}
+var dummySchemaSequences = map[string][]string{
+	"world": []string{
+		"city_id_seq",
+	},
+}
+var dummyTypes = []*TypeDesc{
+	{
+		Schema:     "world",
+		Name:       "continent",
+		Kind:       "enum",
+		Definition: "'Asia', 'Europe', 'North America', 'Africa', 'Oceania', 'Antarctica', 'South America'",
+	},
+}
+var dummyTableComments = []*TableCommentDesc{
+	{
+		Schema:  "world",
+		Table:   "countrylanguage",
+		Comment: "Languages spoken in each country",
+	},
+}
+var dummyCountryLanguageStats = &TableStatsDesc{
+	Rows:         sql.NullInt64{Int64: 984, Valid: true},
+	DataSize:     sql.NullInt64{Int64: 98304, Valid: true},
+	IndexSize:    sql.NullInt64{Int64: 16384, Valid: true},
+	LastAnalyzed: sql.NullString{String: "2020-09-01 12:00:00", Valid: true},
+}
+var dummyCityIndexes = []*IndexDesc{
+	{
+		Name:    "PRIMARY",
+		Columns: []string{"ID"},
+		Unique:  true,
+		Primary: true,
+	},
+	{
+		Name:    "CountryCode",
+		Columns: []string{"CountryCode"},
+	},
+}
+
+var dummyCityConstraints = []*ConstraintDesc{
+	{
+		Name:       "city_chk_1",
+		Type:       ConstraintTypeCheck,
+		Definition: "CHECK (`Population` >= 0)",
+	},
+}
+
+var dummyCityForeignKeys = []*ForeignKeyDesc{
+	{
+		Name:       "city_ibfk_1",
+		Schema:     "world",
+		Table:      "city",
+		Columns:    []string{"CountryCode"},
+		RefSchema:  "world",
+		RefTable:   "country",
+		RefColumns: []string{"Code"},
+		OnUpdate:   "RESTRICT",
+		OnDelete:   "RESTRICT",
+	},
+}
+
+var dummyCountryLanguageForeignKeys = []*ForeignKeyDesc{
+	{
+		Name:       "countryLanguage_ibfk_1",
+		Schema:     "world",
+		Table:      "countrylanguage",
+		Columns:    []string{"CountryCode"},
+		RefSchema:  "world",
+		RefTable:   "country",
+		RefColumns: []string{"Code"},
+		OnUpdate:   "RESTRICT",
+		OnDelete:   "RESTRICT",
+	},
+}
+
+var dummyCityCreateTable = "CREATE TABLE `city` (\n" +
+	"  `ID` int(11) NOT NULL AUTO_INCREMENT,\n" +
+	"  `Name` char(35) NOT NULL DEFAULT '',\n" +
+	"  `CountryCode` char(3) NOT NULL DEFAULT '',\n" +
+	"  `District` char(20) NOT NULL DEFAULT '',\n" +
+	"  `Population` int(11) NOT NULL DEFAULT '0',\n" +
+	"  PRIMARY KEY (`ID`),\n" +
+	"  KEY `CountryCode` (`CountryCode`)\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=latin1;"
+var dummyCityListColumns = []*ColumnDesc{
+	{
+		Schema: "world",
+		Table:  "citylist",
+		Name:   "Name",
+		Type:   "char(35)",
+		Null:   "NO",
+	},
+	{
+		Schema: "world",
+		Table:  "citylist",
+		Name:   "CountryName",
+		Type:   "char(52)",
+		Null:   "NO",
+	},
+}
 var dummyCityColumns = []*ColumnDesc{
 	{
 		Schema: "world",
@@ -429,7 +659,8 @@ var dummyCountryLanguageColumns = []*ColumnDesc{
 			String: "",
 			Valid:  false,
 		},
-		Extra: "",
+		Extra:   "",
+		Comment: "T if the language is official in the country",
 	},
 	{
 		Schema: "world",
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTableStatsDoc(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats *TableStatsDesc
+		want  string
+	}{
+		{
+			name: "all",
+			stats: &TableStatsDesc{
+				Rows:         sql.NullInt64{Int64: 4079, Valid: true},
+				DataSize:     sql.NullInt64{Int64: 409600, Valid: true},
+				IndexSize:    sql.NullInt64{Int64: 3 * 1024 * 1024 * 1024, Valid: true},
+				LastAnalyzed: sql.NullString{String: "2020-09-01 12:00:00", Valid: true},
+			},
+			want: "Statistics: about 4,079 rows, 400.0 KiB data, 3.0 GiB indexes, analyzed 2020-09-01 12:00:00\n",
+		},
+		{
+			name: "sizes only",
+			stats: &TableStatsDesc{
+				DataSize:  sql.NullInt64{Int64: 512, Valid: true},
+				IndexSize: sql.NullInt64{Int64: 0, Valid: true},
+			},
+			want: "Statistics: 512 B data, 0 B indexes\n",
+		},
+		{
+			name:  "none",
+			stats: &TableStatsDesc{},
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, TableStatsDoc(tt.stats)); diff != "" {
+				t.Errorf("unmatch doc (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDBConfigDestructiveGuard(t *testing.T) {
+	var cfg *DBConfig
+	if got := cfg.DestructiveGuard(); got != GuardModeWarn {
+		t.Errorf("the guard of no connection must be %q, got %q", GuardModeWarn, got)
+	}
+	cfg = &DBConfig{GuardMode: GuardModeBlock}
+	if got := cfg.DestructiveGuard(); got != GuardModeBlock {
+		t.Errorf("the guard must be %q, got %q", GuardModeBlock, got)
+	}
+}
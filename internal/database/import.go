@@ -0,0 +1,255 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lighttiger2505/sqls/dialect"
+)
+
+const (
+	DefaultImportBatchSize = 1000
+
+	// maxInsertParams keeps INSERT statements under the lowest limit of bind
+	// parameters among the drivers, the 999 of older SQLite versions
+	maxInsertParams = 999
+)
+
+var driverLoaders = make(map[dialect.DatabaseDriver]Loader)
+
+// Loader inserts a batch of rows into the table within tx.
+type Loader func(ctx context.Context, tx *sql.Tx, table *ImportTable, rows [][]interface{}) error
+
+// ImportTable is the target of an import, with the columns to fill in the
+// order of the values of each row.
+type ImportTable struct {
+	Schema  string
+	Name    string
+	Columns []*ColumnDesc
+}
+
+func (t *ImportTable) columnNames() []string {
+	names := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// RegisterLoader registers a bulk loader for the driver. Drivers without a
+// loader fall back to batched INSERT statements.
+func RegisterLoader(name dialect.DatabaseDriver, loader Loader) {
+	if _, ok := driverLoaders[name]; ok {
+		panic(fmt.Sprintf("driver loader %s already registered", name))
+	}
+	driverLoaders[name] = loader
+}
+
+// LoadRows inserts the batches of rows passed by batches to its load
+// function in a single transaction on db, using the bulk loader of the driver
+// when there is one. Nothing is inserted if any batch fails.
+func LoadRows(ctx context.Context, driver dialect.DatabaseDriver, db *sql.DB, table *ImportTable, batches func(load func(rows [][]interface{}) error) error) error {
+	loader, ok := driverLoaders[driver]
+	if !ok {
+		loader = insertLoader(driver)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	err = batches(func(rows [][]interface{}) error {
+		return loader(ctx, tx, table, rows)
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertLoader returns a loader inserting the rows with multi-row INSERT
+// statements.
+func insertLoader(driver dialect.DatabaseDriver) Loader {
+	return func(ctx context.Context, tx *sql.Tx, table *ImportTable, rows [][]interface{}) error {
+		chunkSize := maxInsertParams / len(table.Columns)
+		if chunkSize == 0 {
+			chunkSize = 1
+		}
+		for len(rows) > 0 {
+			chunk := rows
+			if len(chunk) > chunkSize {
+				chunk = rows[:chunkSize]
+			}
+			if _, err := tx.ExecContext(ctx, insertStatement(driver, table, len(chunk)), flattenRows(chunk)...); err != nil {
+				return err
+			}
+			rows = rows[len(chunk):]
+		}
+		return nil
+	}
+}
+
+func insertStatement(driver dialect.DatabaseDriver, table *ImportTable, rowCount int) string {
+	columns := make([]string, len(table.Columns))
+	for i, col := range table.Columns {
+		columns[i] = QuoteIdentifier(driver, col.Name)
+	}
+
+	buf := new(strings.Builder)
+	fmt.Fprintf(buf, "INSERT INTO %s (%s) VALUES ", QuoteTable(driver, table.Schema, table.Name), strings.Join(columns, ", "))
+	n := 0
+	for i := 0; i < rowCount; i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		placeholders := make([]string, len(table.Columns))
+		for j := range placeholders {
+			n++
+			if driver == dialect.DatabaseDriverPostgreSQL {
+				placeholders[j] = "$" + strconv.Itoa(n)
+			} else {
+				placeholders[j] = "?"
+			}
+		}
+		fmt.Fprintf(buf, "(%s)", strings.Join(placeholders, ", "))
+	}
+	return buf.String()
+}
+
+func flattenRows(rows [][]interface{}) []interface{} {
+	args := []interface{}{}
+	for _, row := range rows {
+		args = append(args, row...)
+	}
+	return args
+}
+
+// QuoteIdentifier quotes the identifier in the style of the driver.
+func QuoteIdentifier(driver dialect.DatabaseDriver, ident string) string {
+	switch driver {
+	case dialect.DatabaseDriverMySQL, dialect.DatabaseDriverMySQL8, dialect.DatabaseDriverMySQL57, dialect.DatabaseDriverMySQL56:
+		return "`" + strings.Replace(ident, "`", "``", -1) + "`"
+	default:
+		return `"` + strings.Replace(ident, `"`, `""`, -1) + `"`
+	}
+}
+
+// PreviewQuery returns the query sampling the first rows of the table.
+func PreviewQuery(driver dialect.DatabaseDriver, schema, table string, limit int) string {
+	return fmt.Sprintf("SELECT * FROM %s LIMIT %d", QuoteTable(driver, schema, table), limit)
+}
+
+// QuoteTable quotes the table name, qualified by the schema if any.
+func QuoteTable(driver dialect.DatabaseDriver, schema, table string) string {
+	if schema == "" {
+		return QuoteIdentifier(driver, table)
+	}
+	return QuoteIdentifier(driver, schema) + "." + QuoteIdentifier(driver, table)
+}
+
+type valueKind int
+
+const (
+	valueKindText valueKind = iota
+	valueKindInteger
+	valueKindFloat
+	valueKindBool
+	valueKindDate
+	valueKindTime
+	valueKindTimestamp
+)
+
+// columnValueKind classifies the declared type of a column, such as
+// "int(11) unsigned", "character varying(20)" or "timestamp with time zone".
+func columnValueKind(typ string) valueKind {
+	typ = strings.ToLower(strings.TrimSpace(typ))
+	if i := strings.Index(typ, "("); i >= 0 {
+		typ = typ[:i]
+	}
+	fields := strings.Fields(typ)
+	if len(fields) == 0 {
+		return valueKindText
+	}
+	switch fields[0] {
+	case "int", "integer", "tinyint", "smallint", "mediumint", "bigint", "int2", "int4", "int8", "serial", "smallserial", "bigserial", "year":
+		return valueKindInteger
+	case "real", "float", "float4", "float8", "double", "decimal", "dec", "numeric", "fixed":
+		return valueKindFloat
+	case "bool", "boolean":
+		return valueKindBool
+	case "date":
+		return valueKindDate
+	case "time", "timetz":
+		return valueKindTime
+	case "datetime", "timestamp", "timestamptz":
+		return valueKindTimestamp
+	}
+	return valueKindText
+}
+
+var (
+	timeLayouts      = []string{"15:04:05.999999999", "15:04:05Z07:00", "15:04"}
+	timestampLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999", "2006-01-02T15:04:05.999999999", "2006-01-02 15:04", "2006-01-02"}
+)
+
+// ConvertValue validates a CSV field against the type of the column and
+// converts it to the value bound to the statement. An empty field is NULL
+// unless the column holds text.
+func ConvertValue(col *ColumnDesc, field string) (interface{}, error) {
+	kind := columnValueKind(col.Type)
+	if field == "" && kind != valueKindText {
+		if col.Null == "NO" {
+			return nil, fmt.Errorf("column %q does not allow NULL", col.Name)
+		}
+		return nil, nil
+	}
+
+	switch kind {
+	case valueKindInteger:
+		v, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q for column %q", field, col.Name)
+		}
+		return v, nil
+	case valueKindFloat:
+		v := strings.TrimSpace(field)
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return nil, fmt.Errorf("invalid number %q for column %q", field, col.Name)
+		}
+		// keep the text so that decimals do not lose precision
+		return v, nil
+	case valueKindBool:
+		v, err := strconv.ParseBool(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q for column %q", field, col.Name)
+		}
+		return v, nil
+	case valueKindDate:
+		if _, err := time.Parse("2006-01-02", strings.TrimSpace(field)); err != nil {
+			return nil, fmt.Errorf("invalid date %q for column %q", field, col.Name)
+		}
+	case valueKindTime:
+		if !parsesAs(timeLayouts, strings.TrimSpace(field)) {
+			return nil, fmt.Errorf("invalid time %q for column %q", field, col.Name)
+		}
+	case valueKindTimestamp:
+		if !parsesAs(timestampLayouts, strings.TrimSpace(field)) {
+			return nil, fmt.Errorf("invalid timestamp %q for column %q", field, col.Name)
+		}
+	}
+	return field, nil
+}
+
+func parsesAs(layouts []string, value string) bool {
+	for _, layout := range layouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/dialect"
+)
+
+func TestConvertValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		col     *ColumnDesc
+		field   string
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:  "integer",
+			col:   &ColumnDesc{Name: "ID", Type: "int(11) unsigned"},
+			field: " 42",
+			want:  int64(42),
+		},
+		{
+			name:    "invalid integer",
+			col:     &ColumnDesc{Name: "ID", Type: "INTEGER"},
+			field:   "4.2",
+			wantErr: true,
+		},
+		{
+			name:  "numeric",
+			col:   &ColumnDesc{Name: "Price", Type: "numeric(10,2)"},
+			field: "12.30",
+			want:  "12.30",
+		},
+		{
+			name:  "boolean",
+			col:   &ColumnDesc{Name: "Active", Type: "boolean"},
+			field: "t",
+			want:  true,
+		},
+		{
+			name:  "timestamp",
+			col:   &ColumnDesc{Name: "CreatedAt", Type: "timestamp without time zone"},
+			field: "2020-06-01 12:00:00",
+			want:  "2020-06-01 12:00:00",
+		},
+		{
+			name:    "invalid date",
+			col:     &ColumnDesc{Name: "Birthday", Type: "date"},
+			field:   "2020/06/01",
+			wantErr: true,
+		},
+		{
+			name:  "null",
+			col:   &ColumnDesc{Name: "Population", Type: "int", Null: "YES"},
+			field: "",
+			want:  nil,
+		},
+		{
+			name:    "not null",
+			col:     &ColumnDesc{Name: "Population", Type: "int", Null: "NO"},
+			field:   "",
+			wantErr: true,
+		},
+		{
+			name:  "empty text",
+			col:   &ColumnDesc{Name: "Name", Type: "character varying(35)", Null: "NO"},
+			field: "",
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertValue(tt.col, tt.field)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ConvertValue() must fail, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch value (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_insertStatement(t *testing.T) {
+	table := &ImportTable{
+		Schema: "world",
+		Name:   "city",
+		Columns: []*ColumnDesc{
+			{Name: "ID"},
+			{Name: "Name"},
+		},
+	}
+	tests := []struct {
+		name   string
+		driver dialect.DatabaseDriver
+		want   string
+	}{
+		{
+			name:   "mysql",
+			driver: dialect.DatabaseDriverMySQL,
+			want:   "INSERT INTO `world`.`city` (`ID`, `Name`) VALUES (?, ?), (?, ?)",
+		},
+		{
+			name:   "postgresql",
+			driver: dialect.DatabaseDriverPostgreSQL,
+			want:   `INSERT INTO "world"."city" ("ID", "Name") VALUES ($1, $2), ($3, $4)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := insertStatement(tt.driver, table, 2)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch statement (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
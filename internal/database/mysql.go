@@ -1,11 +1,16 @@
 package database
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strconv"
+	"strings"
+	"sync/atomic"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/lighttiger2505/sqls/dialect"
@@ -22,6 +27,111 @@ func init() {
 	RegisterFactory("mysql8", NewMySQLDBRepository)
 	RegisterFactory("mysql57", NewMySQLDBRepository)
 	RegisterFactory("mysql56", NewMySQLDBRepository)
+	RegisterLoader("mysql", mysqlLoad)
+	RegisterLoader("mysql8", mysqlLoad)
+	RegisterLoader("mysql57", mysqlLoad)
+	RegisterLoader("mysql56", mysqlLoad)
+}
+
+const (
+	// errors returned when the server refuses LOAD DATA LOCAL INFILE
+	mysqlErrNotAllowedCommand      = 1148
+	mysqlErrClientLocalFileDisable = 3948
+)
+
+var mysqlLoadSeq uint64
+
+// mysqlLoad loads the rows with LOAD DATA LOCAL INFILE, streaming them from a
+// registered reader. It falls back to INSERT statements when the server does
+// not allow local infile.
+func mysqlLoad(ctx context.Context, tx *sql.Tx, table *ImportTable, rows [][]interface{}) error {
+	buf := new(bytes.Buffer)
+	for _, row := range rows {
+		for i, value := range row {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(mysqlLoadField(value))
+		}
+		buf.WriteByte('\n')
+	}
+
+	name := fmt.Sprintf("sqls-import-%d", atomic.AddUint64(&mysqlLoadSeq, 1))
+	mysql.RegisterReaderHandler(name, func() io.Reader { return buf })
+	defer mysql.DeregisterReaderHandler(name)
+
+	columns := make([]string, len(table.Columns))
+	for i, col := range table.Columns {
+		columns[i] = QuoteIdentifier(dialect.DatabaseDriverMySQL, col.Name)
+	}
+	query := fmt.Sprintf(
+		"LOAD DATA LOCAL INFILE 'Reader::%s' INTO TABLE %s FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' ESCAPED BY '' LINES TERMINATED BY '\\n' (%s)",
+		name,
+		QuoteTable(dialect.DatabaseDriverMySQL, table.Schema, table.Name),
+		strings.Join(columns, ", "),
+	)
+	result, err := tx.ExecContext(ctx, query)
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && (mysqlErr.Number == mysqlErrNotAllowedCommand || mysqlErr.Number == mysqlErrClientLocalFileDisable) {
+		return insertLoader(dialect.DatabaseDriverMySQL)(ctx, tx, table, rows)
+	}
+	if err != nil {
+		return err
+	}
+
+	// LOAD DATA LOCAL behaves as IGNORE, skipping the rows with duplicate keys
+	// and truncating invalid values with a warning only
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	warnings, err := mysqlWarnings(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if affected != int64(len(rows)) || len(warnings) > 0 {
+		return fmt.Errorf("%d of %d rows loaded, %s", affected, len(rows), strings.Join(warnings, ", "))
+	}
+	return nil
+}
+
+// mysqlMaxWarnings is the number of warnings reported when a load fails.
+const mysqlMaxWarnings = 5
+
+// mysqlWarnings returns the messages of the first warnings of the last
+// statement.
+func mysqlWarnings(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SHOW WARNINGS LIMIT %d", mysqlMaxWarnings))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	warnings := []string{}
+	for rows.Next() {
+		var level, message string
+		var code int
+		if err := rows.Scan(&level, &code, &message); err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, fmt.Sprintf("%s %d: %s", level, code, message))
+	}
+	return warnings, rows.Err()
+}
+
+// mysqlLoadField formats a value for LOAD DATA. Values are always enclosed in
+// quotes so that only the bare NULL is read as NULL.
+func mysqlLoadField(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	default:
+		return `"` + strings.Replace(fmt.Sprint(v), `"`, `""`, -1) + `"`
+	}
 }
 
 func mysqlOpen(dbConnCfg *DBConfig) (*DBConnection, error) {
@@ -180,6 +290,8 @@ func (db *MySQLDBRepository) SchemaTables(ctx context.Context) (map[string][]str
 		TABLE_NAME
 	FROM
 		information_schema.TABLES
+	WHERE
+		TABLE_TYPE <> 'VIEW'
 	ORDER BY
 		TABLE_SCHEMA,
 		TABLE_NAME
@@ -231,7 +343,8 @@ SELECT
 	IS_NULLABLE,
 	COLUMN_KEY,
 	COLUMN_DEFAULT,
-	EXTRA
+	EXTRA,
+	COLUMN_COMMENT
 FROM information_schema.COLUMNS
 `)
 	if err != nil {
@@ -249,6 +362,7 @@ FROM information_schema.COLUMNS
 			&tableInfo.Key,
 			&tableInfo.Default,
 			&tableInfo.Extra,
+			&tableInfo.Comment,
 		)
 		if err != nil {
 			return nil, err
@@ -270,7 +384,8 @@ SELECT
 	IS_NULLABLE,
 	COLUMN_KEY,
 	COLUMN_DEFAULT,
-	EXTRA
+	EXTRA,
+	COLUMN_COMMENT
 FROM information_schema.COLUMNS
 WHERE information_schema.COLUMNS.TABLE_SCHEMA = ?
 `, schemaName)
@@ -289,6 +404,7 @@ WHERE information_schema.COLUMNS.TABLE_SCHEMA = ?
 			&tableInfo.Key,
 			&tableInfo.Default,
 			&tableInfo.Extra,
+			&tableInfo.Comment,
 		)
 		if err != nil {
 			return nil, err
@@ -298,6 +414,379 @@ WHERE information_schema.COLUMNS.TABLE_SCHEMA = ?
 	return tableInfos, nil
 }
 
+func (db *MySQLDBRepository) CreateTableStatement(ctx context.Context, schemaName, tableName string) (string, error) {
+	rows, err := db.Conn.QueryContext(ctx, fmt.Sprintf("SHOW CREATE TABLE %s", QuoteTable(dialect.DatabaseDriverMySQL, schemaName, tableName)))
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	// views are described with the character set and collation as well
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	values := make([]interface{}, len(columns))
+	for i := range values {
+		values[i] = new(sql.RawBytes)
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+		return "", xerrors.Errorf("table not found, %s", tableName)
+	}
+	if err := rows.Scan(values...); err != nil {
+		return "", err
+	}
+	return string(*values[1].(*sql.RawBytes)) + ";", nil
+}
+
+func (db *MySQLDBRepository) TableIndexes(ctx context.Context, schemaName, tableName string) ([]*IndexDesc, error) {
+	rows, err := db.Conn.QueryContext(ctx, `
+	SELECT
+	  INDEX_NAME,
+	  NON_UNIQUE,
+	  COALESCE(COLUMN_NAME, '')
+	FROM
+	  information_schema.STATISTICS
+	WHERE
+	  TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())
+	  AND TABLE_NAME = ?
+	ORDER BY
+	  INDEX_NAME <> 'PRIMARY',
+	  INDEX_NAME,
+	  SEQ_IN_INDEX
+	`, schemaName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	indexes := []*IndexDesc{}
+	for rows.Next() {
+		var name, column string
+		var nonUnique int
+		if err := rows.Scan(&name, &nonUnique, &column); err != nil {
+			return nil, err
+		}
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != name {
+			indexes = append(indexes, &IndexDesc{
+				Name:    name,
+				Unique:  nonUnique == 0,
+				Primary: name == "PRIMARY",
+			})
+		}
+		index := indexes[len(indexes)-1]
+		index.Columns = append(index.Columns, column)
+	}
+	return indexes, rows.Err()
+}
+
+func (db *MySQLDBRepository) TableConstraints(ctx context.Context, schemaName, tableName string) ([]*ConstraintDesc, error) {
+	rows, err := db.Conn.QueryContext(ctx, `
+	SELECT
+	  tc.CONSTRAINT_NAME,
+	  kcu.COLUMN_NAME
+	FROM
+	  information_schema.TABLE_CONSTRAINTS tc
+	  JOIN information_schema.KEY_COLUMN_USAGE kcu ON
+	    kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA
+	    AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+	    AND kcu.TABLE_NAME = tc.TABLE_NAME
+	WHERE
+	  tc.TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())
+	  AND tc.TABLE_NAME = ?
+	  AND tc.CONSTRAINT_TYPE = 'UNIQUE'
+	ORDER BY
+	  tc.CONSTRAINT_NAME,
+	  kcu.ORDINAL_POSITION
+	`, schemaName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	constraints := []*ConstraintDesc{}
+	for rows.Next() {
+		var name, column string
+		if err := rows.Scan(&name, &column); err != nil {
+			return nil, err
+		}
+		if len(constraints) == 0 || constraints[len(constraints)-1].Name != name {
+			constraints = append(constraints, &ConstraintDesc{
+				Name: name,
+				Type: ConstraintTypeUnique,
+			})
+		}
+		constraint := constraints[len(constraints)-1]
+		constraint.Columns = append(constraint.Columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, constraint := range constraints {
+		constraint.Definition = uniqueDefinition(dialect.DatabaseDriverMySQL, constraint.Columns)
+	}
+
+	checks, err := db.checkConstraints(ctx, schemaName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return append(constraints, checks...), nil
+}
+
+// checkConstraints returns the check constraints of the table, which are
+// enforced and listed in information_schema since MySQL 8.0.16.
+func (db *MySQLDBRepository) checkConstraints(ctx context.Context, schemaName, tableName string) ([]*ConstraintDesc, error) {
+	rows, err := db.Conn.QueryContext(ctx, `
+	SELECT
+	  cc.CONSTRAINT_NAME,
+	  cc.CHECK_CLAUSE
+	FROM
+	  information_schema.TABLE_CONSTRAINTS tc
+	  JOIN information_schema.CHECK_CONSTRAINTS cc ON
+	    cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA
+	    AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+	WHERE
+	  tc.TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())
+	  AND tc.TABLE_NAME = ?
+	  AND tc.CONSTRAINT_TYPE = 'CHECK'
+	ORDER BY
+	  cc.CONSTRAINT_NAME
+	`, schemaName, tableName)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		// ER_UNKNOWN_TABLE, information_schema.CHECK_CONSTRAINTS does not exist
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1109 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+	constraints := []*ConstraintDesc{}
+	for rows.Next() {
+		constraint := &ConstraintDesc{Type: ConstraintTypeCheck}
+		if err := rows.Scan(&constraint.Name, &constraint.Definition); err != nil {
+			return nil, err
+		}
+		constraint.Definition = fmt.Sprintf("CHECK (%s)", constraint.Definition)
+		constraints = append(constraints, constraint)
+	}
+	return constraints, rows.Err()
+}
+
+func (db *MySQLDBRepository) TableForeignKeys(ctx context.Context, schemaName, tableName string) ([]*ForeignKeyDesc, error) {
+	return db.foreignKeys(ctx, schemaName, tableName)
+}
+
+func (db *MySQLDBRepository) SchemaForeignKeys(ctx context.Context, schemaName string) ([]*ForeignKeyDesc, error) {
+	return db.foreignKeys(ctx, schemaName, "")
+}
+
+// foreignKeys returns the foreign keys of the table, or of every table in the
+// schema if tableName is empty.
+func (db *MySQLDBRepository) foreignKeys(ctx context.Context, schemaName, tableName string) ([]*ForeignKeyDesc, error) {
+	rows, err := db.Conn.QueryContext(ctx, `
+	SELECT
+	  kcu.CONSTRAINT_NAME,
+	  kcu.TABLE_SCHEMA,
+	  kcu.TABLE_NAME,
+	  kcu.COLUMN_NAME,
+	  kcu.REFERENCED_TABLE_SCHEMA,
+	  kcu.REFERENCED_TABLE_NAME,
+	  kcu.REFERENCED_COLUMN_NAME,
+	  rc.UPDATE_RULE,
+	  rc.DELETE_RULE
+	FROM
+	  information_schema.KEY_COLUMN_USAGE kcu
+	  JOIN information_schema.REFERENTIAL_CONSTRAINTS rc ON
+	    rc.CONSTRAINT_SCHEMA = kcu.CONSTRAINT_SCHEMA
+	    AND rc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
+	    AND rc.TABLE_NAME = kcu.TABLE_NAME
+	WHERE
+	  kcu.TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())
+	  AND (? = '' OR kcu.TABLE_NAME = ?)
+	ORDER BY
+	  kcu.TABLE_NAME,
+	  kcu.CONSTRAINT_NAME,
+	  kcu.ORDINAL_POSITION
+	`, schemaName, tableName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	fks := []*ForeignKeyDesc{}
+	for rows.Next() {
+		var fk ForeignKeyDesc
+		var column, refColumn string
+		err := rows.Scan(
+			&fk.Name,
+			&fk.Schema,
+			&fk.Table,
+			&column,
+			&fk.RefSchema,
+			&fk.RefTable,
+			&refColumn,
+			&fk.OnUpdate,
+			&fk.OnDelete,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(fks) == 0 || fks[len(fks)-1].Name != fk.Name {
+			fks = append(fks, &fk)
+		}
+		last := fks[len(fks)-1]
+		last.Columns = append(last.Columns, column)
+		last.RefColumns = append(last.RefColumns, refColumn)
+	}
+	return fks, rows.Err()
+}
+
+func (db *MySQLDBRepository) SchemaViews(ctx context.Context) (map[string][]string, error) {
+	rows, err := db.Conn.QueryContext(ctx, `
+	SELECT
+	  TABLE_SCHEMA,
+	  TABLE_NAME
+	FROM
+	  information_schema.TABLES
+	WHERE
+	  TABLE_TYPE = 'VIEW'
+	ORDER BY
+	  TABLE_SCHEMA,
+	  TABLE_NAME
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return scanSchemaNames(rows)
+}
+
+func (db *MySQLDBRepository) SchemaRoutines(ctx context.Context) ([]*RoutineDesc, error) {
+	rows, err := db.Conn.QueryContext(ctx, `
+	SELECT
+	  r.ROUTINE_SCHEMA,
+	  r.ROUTINE_NAME,
+	  r.ROUTINE_TYPE,
+	  COALESCE(r.DTD_IDENTIFIER, ''),
+	  COALESCE(p.PARAMETER_MODE, ''),
+	  COALESCE(p.PARAMETER_NAME, ''),
+	  COALESCE(p.DTD_IDENTIFIER, '')
+	FROM
+	  information_schema.ROUTINES r
+	  LEFT JOIN information_schema.PARAMETERS p ON
+	    p.SPECIFIC_SCHEMA = r.ROUTINE_SCHEMA
+	    AND p.SPECIFIC_NAME = r.SPECIFIC_NAME
+	    AND p.ORDINAL_POSITION > 0
+	WHERE
+	  r.ROUTINE_SCHEMA NOT IN ('information_schema', 'mysql', 'performance_schema', 'sys')
+	ORDER BY
+	  r.ROUTINE_SCHEMA,
+	  r.ROUTINE_NAME,
+	  r.ROUTINE_TYPE,
+	  p.ORDINAL_POSITION
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	routines := []*RoutineDesc{}
+	for rows.Next() {
+		var routine RoutineDesc
+		var mode, name, typ string
+		err := rows.Scan(
+			&routine.Schema,
+			&routine.Name,
+			&routine.Type,
+			&routine.ReturnType,
+			&mode,
+			&name,
+			&typ,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(routines) == 0 || !routines[len(routines)-1].sameRoutine(&routine) {
+			routine.Arguments = []string{}
+			routines = append(routines, &routine)
+		}
+		if name == "" {
+			continue
+		}
+		last := routines[len(routines)-1]
+		arg := name + " " + typ
+		if last.Type == RoutineTypeProcedure && mode != "" {
+			arg = mode + " " + arg
+		}
+		last.Arguments = append(last.Arguments, arg)
+	}
+	return routines, rows.Err()
+}
+
+// SchemaSequences returns no sequences since MySQL has none.
+func (db *MySQLDBRepository) SchemaSequences(ctx context.Context) (map[string][]string, error) {
+	return map[string][]string{}, nil
+}
+
+// SchemaTypes returns no types since MySQL has no user-defined types.
+func (db *MySQLDBRepository) SchemaTypes(ctx context.Context) ([]*TypeDesc, error) {
+	return []*TypeDesc{}, nil
+}
+
+func (db *MySQLDBRepository) TableComments(ctx context.Context) ([]*TableCommentDesc, error) {
+	rows, err := db.Conn.QueryContext(ctx, `
+	SELECT
+	  TABLE_SCHEMA,
+	  TABLE_NAME,
+	  TABLE_COMMENT
+	FROM
+	  information_schema.TABLES
+	WHERE
+	  TABLE_COMMENT <> ''
+	  AND TABLE_TYPE <> 'VIEW'
+	ORDER BY
+	  TABLE_SCHEMA,
+	  TABLE_NAME
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return scanTableComments(rows)
+}
+
+// TableStats returns the statistics of the table kept by the storage engine.
+// The time of the last analysis is read from mysql.innodb_table_stats, and
+// left null when it is not readable.
+func (db *MySQLDBRepository) TableStats(ctx context.Context, schemaName, tableName string) (*TableStatsDesc, error) {
+	var stats TableStatsDesc
+	err := db.Conn.QueryRowContext(ctx, `
+	SELECT
+	  TABLE_ROWS,
+	  DATA_LENGTH,
+	  INDEX_LENGTH
+	FROM
+	  information_schema.TABLES
+	WHERE
+	  TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())
+	  AND TABLE_NAME = ?
+	`, schemaName, tableName).Scan(&stats.Rows, &stats.DataSize, &stats.IndexSize)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Conn.QueryRowContext(ctx, `
+	SELECT
+	  CAST(last_update AS CHAR)
+	FROM
+	  mysql.innodb_table_stats
+	WHERE
+	  database_name = COALESCE(NULLIF(?, ''), DATABASE())
+	  AND table_name = ?
+	`, schemaName, tableName).Scan(&stats.LastAnalyzed)
+	if err != nil {
+		stats.LastAnalyzed = sql.NullString{}
+	}
+	return &stats, nil
+}
+
 func (db *MySQLDBRepository) Exec(ctx context.Context, query string) (sql.Result, error) {
 	return db.Conn.ExecContext(ctx, query)
 }
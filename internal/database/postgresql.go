@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"fmt"
 	"log"
 	"net"
 	"net/url"
@@ -22,6 +23,29 @@ import (
 func init() {
 	RegisterOpen("postgresql", postgreSQLOpen)
 	RegisterFactory("postgresql", NewPostgreSQLDBRepository)
+	RegisterLoader("postgresql", postgreSQLLoad)
+}
+
+// postgreSQLLoad copies the rows into the table with COPY FROM STDIN.
+func postgreSQLLoad(ctx context.Context, tx *sql.Tx, table *ImportTable, rows [][]interface{}) error {
+	query := pq.CopyIn(table.Name, table.columnNames()...)
+	if table.Schema != "" {
+		query = pq.CopyInSchema(table.Schema, table.Name, table.columnNames()...)
+	}
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, row := range rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return err
+		}
+	}
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		return err
+	}
+	return stmt.Close()
 }
 
 func postgreSQLOpen(dbConnCfg *DBConfig) (*DBConnection, error) {
@@ -184,6 +208,8 @@ func (db *PostgreSQLDBRepository) SchemaTables(ctx context.Context) (map[string]
 		table_name
 	FROM
 		information_schema.tables
+	WHERE
+		table_type <> 'VIEW'
 	ORDER BY
 		table_schema,
 		table_name
@@ -250,7 +276,8 @@ func (db *PostgreSQLDBRepository) DescribeDatabaseTable(ctx context.Context) ([]
 			ELSE 'NO'
 		END,
 		c.column_default,
-		''
+		'',
+		COALESCE(col_description(format('%I.%I', c.table_schema, c.table_name)::regclass, c.ordinal_position), '')
 	FROM
 		information_schema.columns c
 	LEFT JOIN
@@ -281,6 +308,7 @@ func (db *PostgreSQLDBRepository) DescribeDatabaseTable(ctx context.Context) ([]
 			&tableInfo.Key,
 			&tableInfo.Default,
 			&tableInfo.Extra,
+			&tableInfo.Comment,
 		)
 		if err != nil {
 			return nil, err
@@ -305,7 +333,8 @@ func (db *PostgreSQLDBRepository) DescribeDatabaseTableBySchema(ctx context.Cont
 			ELSE 'NO'
 		END,
 		c.column_default,
-		''
+		'',
+		COALESCE(col_description(format('%I.%I', c.table_schema, c.table_name)::regclass, c.ordinal_position), '')
 	FROM
 		information_schema.columns c
 	LEFT JOIN
@@ -338,6 +367,7 @@ func (db *PostgreSQLDBRepository) DescribeDatabaseTableBySchema(ctx context.Cont
 			&tableInfo.Key,
 			&tableInfo.Default,
 			&tableInfo.Extra,
+			&tableInfo.Comment,
 		)
 		if err != nil {
 			return nil, err
@@ -347,6 +377,591 @@ func (db *PostgreSQLDBRepository) DescribeDatabaseTableBySchema(ctx context.Cont
 	return tableInfos, nil
 }
 
+// CreateTableStatement reconstructs the statement creating the table from the
+// catalog, since PostgreSQL does not keep it.
+func (db *PostgreSQLDBRepository) CreateTableStatement(ctx context.Context, schemaName, tableName string) (string, error) {
+	if schemaName == "" {
+		currentSchema, err := db.CurrentSchema(ctx)
+		if err != nil {
+			return "", err
+		}
+		schemaName = currentSchema
+	}
+
+	var oid int64
+	var relkind, qualifiedName string
+	err := db.Conn.QueryRowContext(
+		ctx,
+		`
+	SELECT
+		c.oid,
+		c.relkind,
+		quote_ident(n.nspname) || '.' || quote_ident(c.relname)
+	FROM
+		pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON
+		n.oid = c.relnamespace
+	WHERE
+		n.nspname = $1
+		AND c.relname = $2
+	`, schemaName, tableName).Scan(&oid, &relkind, &qualifiedName)
+	if err == sql.ErrNoRows {
+		return "", xerrors.Errorf("table not found, %s.%s", schemaName, tableName)
+	}
+	if err != nil {
+		return "", err
+	}
+	if relkind == "v" || relkind == "m" {
+		var def string
+		if err := db.Conn.QueryRowContext(ctx, "SELECT pg_catalog.pg_get_viewdef($1, true)", oid).Scan(&def); err != nil {
+			return "", err
+		}
+		kind := "VIEW"
+		if relkind == "m" {
+			kind = "MATERIALIZED VIEW"
+		}
+		return fmt.Sprintf("CREATE %s %s AS\n%s", kind, qualifiedName, def), nil
+	}
+
+	defs, err := db.queryStrings(
+		ctx,
+		`
+	SELECT
+		quote_ident(a.attname) || ' ' || pg_catalog.format_type(a.atttypid, a.atttypmod)
+		|| CASE WHEN a.attnotnull THEN ' NOT NULL' ELSE '' END
+		|| COALESCE(' DEFAULT ' || pg_catalog.pg_get_expr(d.adbin, d.adrelid), '')
+	FROM
+		pg_catalog.pg_attribute a
+	LEFT JOIN pg_catalog.pg_attrdef d ON
+		d.adrelid = a.attrelid
+		AND d.adnum = a.attnum
+	WHERE
+		a.attrelid = $1
+		AND a.attnum > 0
+		AND NOT a.attisdropped
+	ORDER BY
+		a.attnum
+	`, oid)
+	if err != nil {
+		return "", err
+	}
+	constraints, err := db.queryStrings(
+		ctx,
+		`
+	SELECT
+		'CONSTRAINT ' || quote_ident(conname) || ' ' || pg_catalog.pg_get_constraintdef(oid, true)
+	FROM
+		pg_catalog.pg_constraint
+	WHERE
+		conrelid = $1
+	ORDER BY
+		contype <> 'p',
+		conname
+	`, oid)
+	if err != nil {
+		return "", err
+	}
+	indexes, err := db.queryStrings(
+		ctx,
+		`
+	SELECT
+		pg_catalog.pg_get_indexdef(i.indexrelid) || ';'
+	FROM
+		pg_catalog.pg_index i
+	LEFT JOIN pg_catalog.pg_constraint con ON
+		con.conindid = i.indexrelid
+		AND con.conrelid = i.indrelid
+	WHERE
+		i.indrelid = $1
+		AND con.oid IS NULL
+	ORDER BY
+		1
+	`, oid)
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(strings.Builder)
+	fmt.Fprintf(buf, "CREATE TABLE %s (\n    ", qualifiedName)
+	buf.WriteString(strings.Join(append(defs, constraints...), ",\n    "))
+	buf.WriteString("\n);")
+	for _, index := range indexes {
+		fmt.Fprintf(buf, "\n\n%s", index)
+	}
+	return buf.String(), nil
+}
+
+// relationOID returns the oid of the table, in the current schema if
+// schemaName is empty.
+func (db *PostgreSQLDBRepository) relationOID(ctx context.Context, schemaName, tableName string) (int64, error) {
+	if schemaName == "" {
+		currentSchema, err := db.CurrentSchema(ctx)
+		if err != nil {
+			return 0, err
+		}
+		schemaName = currentSchema
+	}
+	var oid int64
+	err := db.Conn.QueryRowContext(
+		ctx,
+		`
+	SELECT
+		c.oid
+	FROM
+		pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON
+		n.oid = c.relnamespace
+	WHERE
+		n.nspname = $1
+		AND c.relname = $2
+	`, schemaName, tableName).Scan(&oid)
+	if err == sql.ErrNoRows {
+		return 0, xerrors.Errorf("table not found, %s.%s", schemaName, tableName)
+	}
+	return oid, err
+}
+
+func (db *PostgreSQLDBRepository) TableIndexes(ctx context.Context, schemaName, tableName string) ([]*IndexDesc, error) {
+	oid, err := db.relationOID(ctx, schemaName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Conn.QueryContext(
+		ctx,
+		`
+	SELECT
+		ic.relname,
+		i.indisunique,
+		i.indisprimary,
+		ARRAY(
+			SELECT
+				pg_catalog.pg_get_indexdef(i.indexrelid, k, true)
+			FROM
+				generate_series(1, i.indnatts) k
+			ORDER BY
+				k
+		)
+	FROM
+		pg_catalog.pg_index i
+	JOIN pg_catalog.pg_class ic ON
+		ic.oid = i.indexrelid
+	WHERE
+		i.indrelid = $1
+	ORDER BY
+		NOT i.indisprimary,
+		ic.relname
+	`, oid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	indexes := []*IndexDesc{}
+	for rows.Next() {
+		var index IndexDesc
+		if err := rows.Scan(&index.Name, &index.Unique, &index.Primary, pq.Array(&index.Columns)); err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, &index)
+	}
+	return indexes, rows.Err()
+}
+
+func (db *PostgreSQLDBRepository) TableConstraints(ctx context.Context, schemaName, tableName string) ([]*ConstraintDesc, error) {
+	oid, err := db.relationOID(ctx, schemaName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Conn.QueryContext(
+		ctx,
+		`
+	SELECT
+		con.conname,
+		CASE con.contype WHEN 'u' THEN 'UNIQUE' ELSE 'CHECK' END,
+		ARRAY(
+			SELECT
+				a.attname::text
+			FROM
+				unnest(con.conkey) WITH ORDINALITY AS k(attnum, n)
+			JOIN pg_catalog.pg_attribute a ON
+				a.attrelid = con.conrelid
+				AND a.attnum = k.attnum
+			ORDER BY
+				k.n
+		),
+		pg_catalog.pg_get_constraintdef(con.oid, true)
+	FROM
+		pg_catalog.pg_constraint con
+	WHERE
+		con.conrelid = $1
+		AND con.contype IN ('u', 'c')
+	ORDER BY
+		con.contype DESC,
+		con.conname
+	`, oid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	constraints := []*ConstraintDesc{}
+	for rows.Next() {
+		var constraint ConstraintDesc
+		if err := rows.Scan(&constraint.Name, &constraint.Type, pq.Array(&constraint.Columns), &constraint.Definition); err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, &constraint)
+	}
+	return constraints, rows.Err()
+}
+
+func (db *PostgreSQLDBRepository) TableForeignKeys(ctx context.Context, schemaName, tableName string) ([]*ForeignKeyDesc, error) {
+	return db.foreignKeys(ctx, schemaName, tableName)
+}
+
+func (db *PostgreSQLDBRepository) SchemaForeignKeys(ctx context.Context, schemaName string) ([]*ForeignKeyDesc, error) {
+	return db.foreignKeys(ctx, schemaName, "")
+}
+
+// foreignKeys returns the foreign keys of the table, or of every table in the
+// schema if tableName is empty.
+func (db *PostgreSQLDBRepository) foreignKeys(ctx context.Context, schemaName, tableName string) ([]*ForeignKeyDesc, error) {
+	if schemaName == "" {
+		currentSchema, err := db.CurrentSchema(ctx)
+		if err != nil {
+			return nil, err
+		}
+		schemaName = currentSchema
+	}
+	rows, err := db.Conn.QueryContext(
+		ctx,
+		`
+	SELECT
+		con.conname,
+		n.nspname,
+		c.relname,
+		ARRAY(
+			SELECT
+				a.attname::text
+			FROM
+				unnest(con.conkey) WITH ORDINALITY AS k(attnum, n)
+			JOIN pg_catalog.pg_attribute a ON
+				a.attrelid = con.conrelid
+				AND a.attnum = k.attnum
+			ORDER BY
+				k.n
+		),
+		rn.nspname,
+		rc.relname,
+		ARRAY(
+			SELECT
+				a.attname::text
+			FROM
+				unnest(con.confkey) WITH ORDINALITY AS k(attnum, n)
+			JOIN pg_catalog.pg_attribute a ON
+				a.attrelid = con.confrelid
+				AND a.attnum = k.attnum
+			ORDER BY
+				k.n
+		),
+		CASE con.confupdtype
+			WHEN 'r' THEN 'RESTRICT'
+			WHEN 'c' THEN 'CASCADE'
+			WHEN 'n' THEN 'SET NULL'
+			WHEN 'd' THEN 'SET DEFAULT'
+			ELSE 'NO ACTION'
+		END,
+		CASE con.confdeltype
+			WHEN 'r' THEN 'RESTRICT'
+			WHEN 'c' THEN 'CASCADE'
+			WHEN 'n' THEN 'SET NULL'
+			WHEN 'd' THEN 'SET DEFAULT'
+			ELSE 'NO ACTION'
+		END
+	FROM
+		pg_catalog.pg_constraint con
+	JOIN pg_catalog.pg_class c ON
+		c.oid = con.conrelid
+	JOIN pg_catalog.pg_namespace n ON
+		n.oid = c.relnamespace
+	JOIN pg_catalog.pg_class rc ON
+		rc.oid = con.confrelid
+	JOIN pg_catalog.pg_namespace rn ON
+		rn.oid = rc.relnamespace
+	WHERE
+		n.nspname = $1
+		AND ($2 = '' OR c.relname::text = $2)
+		AND con.contype = 'f'
+	ORDER BY
+		c.relname,
+		con.conname
+	`, schemaName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	fks := []*ForeignKeyDesc{}
+	for rows.Next() {
+		var fk ForeignKeyDesc
+		err := rows.Scan(
+			&fk.Name,
+			&fk.Schema,
+			&fk.Table,
+			pq.Array(&fk.Columns),
+			&fk.RefSchema,
+			&fk.RefTable,
+			pq.Array(&fk.RefColumns),
+			&fk.OnUpdate,
+			&fk.OnDelete,
+		)
+		if err != nil {
+			return nil, err
+		}
+		fks = append(fks, &fk)
+	}
+	return fks, rows.Err()
+}
+
+// SchemaViews returns the views and materialized views by schema.
+func (db *PostgreSQLDBRepository) SchemaViews(ctx context.Context) (map[string][]string, error) {
+	rows, err := db.Conn.QueryContext(
+		ctx,
+		`
+	SELECT
+		n.nspname,
+		c.relname
+	FROM
+		pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON
+		n.oid = c.relnamespace
+	WHERE
+		c.relkind IN ('v', 'm')
+	ORDER BY
+		n.nspname,
+		c.relname
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return scanSchemaNames(rows)
+}
+
+// SchemaRoutines returns the functions and procedures outside of the system
+// schemas. Procedures are told apart by having no result.
+func (db *PostgreSQLDBRepository) SchemaRoutines(ctx context.Context) ([]*RoutineDesc, error) {
+	rows, err := db.Conn.QueryContext(
+		ctx,
+		`
+	SELECT
+		n.nspname,
+		p.proname,
+		COALESCE(pg_catalog.pg_get_function_result(p.oid), ''),
+		ARRAY(
+			SELECT
+				COALESCE(NULLIF(p.proargnames[k.n], '') || ' ', '') || pg_catalog.format_type(k.t, NULL)
+			FROM
+				unnest(COALESCE(p.proallargtypes, p.proargtypes::oid[])) WITH ORDINALITY AS k(t, n)
+			WHERE
+				p.proargmodes IS NULL
+				OR p.proargmodes[k.n] IN ('i', 'b', 'v')
+			ORDER BY
+				k.n
+		)
+	FROM
+		pg_catalog.pg_proc p
+	JOIN pg_catalog.pg_namespace n ON
+		n.oid = p.pronamespace
+	WHERE
+		n.nspname NOT IN ('pg_catalog', 'information_schema')
+		AND n.nspname NOT LIKE 'pg_toast%'
+	ORDER BY
+		n.nspname,
+		p.proname
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	routines := []*RoutineDesc{}
+	for rows.Next() {
+		var routine RoutineDesc
+		err := rows.Scan(
+			&routine.Schema,
+			&routine.Name,
+			&routine.ReturnType,
+			pq.Array(&routine.Arguments),
+		)
+		if err != nil {
+			return nil, err
+		}
+		routine.Type = RoutineTypeFunction
+		if routine.ReturnType == "" {
+			routine.Type = RoutineTypeProcedure
+		}
+		routines = append(routines, &routine)
+	}
+	return routines, rows.Err()
+}
+
+func (db *PostgreSQLDBRepository) SchemaSequences(ctx context.Context) (map[string][]string, error) {
+	rows, err := db.Conn.QueryContext(
+		ctx,
+		`
+	SELECT
+		sequence_schema,
+		sequence_name
+	FROM
+		information_schema.sequences
+	ORDER BY
+		sequence_schema,
+		sequence_name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return scanSchemaNames(rows)
+}
+
+// SchemaTypes returns the enum, composite, domain and range types outside of
+// the system schemas. The row types of tables are left out.
+func (db *PostgreSQLDBRepository) SchemaTypes(ctx context.Context) ([]*TypeDesc, error) {
+	rows, err := db.Conn.QueryContext(
+		ctx,
+		`
+	SELECT
+		n.nspname,
+		t.typname,
+		CASE t.typtype
+			WHEN 'e' THEN 'enum'
+			WHEN 'c' THEN 'composite'
+			WHEN 'd' THEN 'domain'
+			ELSE 'range'
+		END,
+		COALESCE(
+			CASE t.typtype
+				WHEN 'e' THEN (
+					SELECT
+						string_agg(quote_literal(e.enumlabel), ', ' ORDER BY e.enumsortorder)
+					FROM
+						pg_catalog.pg_enum e
+					WHERE
+						e.enumtypid = t.oid
+				)
+				WHEN 'c' THEN (
+					SELECT
+						string_agg(quote_ident(a.attname) || ' ' || pg_catalog.format_type(a.atttypid, a.atttypmod), ', ' ORDER BY a.attnum)
+					FROM
+						pg_catalog.pg_attribute a
+					WHERE
+						a.attrelid = t.typrelid
+						AND a.attnum > 0
+						AND NOT a.attisdropped
+				)
+				WHEN 'd' THEN pg_catalog.format_type(t.typbasetype, t.typtypmod)
+			END,
+			''
+		)
+	FROM
+		pg_catalog.pg_type t
+	JOIN pg_catalog.pg_namespace n ON
+		n.oid = t.typnamespace
+	LEFT JOIN pg_catalog.pg_class c ON
+		c.oid = t.typrelid
+	WHERE
+		n.nspname NOT IN ('pg_catalog', 'information_schema')
+		AND n.nspname NOT LIKE 'pg_toast%'
+		AND (t.typtype IN ('e', 'd', 'r') OR (t.typtype = 'c' AND c.relkind = 'c'))
+	ORDER BY
+		n.nspname,
+		t.typname
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	types := []*TypeDesc{}
+	for rows.Next() {
+		var typ TypeDesc
+		if err := rows.Scan(&typ.Schema, &typ.Name, &typ.Kind, &typ.Definition); err != nil {
+			return nil, err
+		}
+		types = append(types, &typ)
+	}
+	return types, rows.Err()
+}
+
+// TableComments returns the comments of the tables and views outside of the
+// system schemas.
+func (db *PostgreSQLDBRepository) TableComments(ctx context.Context) ([]*TableCommentDesc, error) {
+	rows, err := db.Conn.QueryContext(ctx, `
+	SELECT
+		n.nspname,
+		c.relname,
+		obj_description(c.oid, 'pg_class')
+	FROM
+		pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON
+		n.oid = c.relnamespace
+	WHERE
+		c.relkind IN ('r', 'p', 'v', 'm', 'f')
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		AND obj_description(c.oid, 'pg_class') IS NOT NULL
+	ORDER BY
+		n.nspname,
+		c.relname
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return scanTableComments(rows)
+}
+
+// TableStats returns the row count estimated by the planner, the sizes of the
+// table with its TOAST data and of its indexes, and the time of the last
+// manual or automatic analysis. The row count is null until the table is
+// analyzed.
+func (db *PostgreSQLDBRepository) TableStats(ctx context.Context, schemaName, tableName string) (*TableStatsDesc, error) {
+	oid, err := db.relationOID(ctx, schemaName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	var stats TableStatsDesc
+	err = db.Conn.QueryRowContext(
+		ctx,
+		`
+	SELECT
+		CASE WHEN c.reltuples < 0 OR (c.reltuples = 0 AND c.relpages = 0) THEN NULL ELSE c.reltuples::bigint END,
+		pg_catalog.pg_table_size(c.oid),
+		pg_catalog.pg_indexes_size(c.oid),
+		to_char(GREATEST(s.last_analyze, s.last_autoanalyze), 'YYYY-MM-DD HH24:MI:SS')
+	FROM
+		pg_catalog.pg_class c
+	LEFT JOIN pg_catalog.pg_stat_all_tables s ON
+		s.relid = c.oid
+	WHERE
+		c.oid = $1
+	`, oid).Scan(&stats.Rows, &stats.DataSize, &stats.IndexSize, &stats.LastAnalyzed)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (db *PostgreSQLDBRepository) queryStrings(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := db.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	results := []string{}
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
 func (db *PostgreSQLDBRepository) Exec(ctx context.Context, query string) (sql.Result, error) {
 	return db.Conn.ExecContext(ctx, query)
 }
@@ -225,19 +225,6 @@ var createIgnore = map[string]bool{
 	"UNLOGGED":   true,
 }
 
-func splitMultiSep(s string, sep []string) []string {
-	var ret []string
-	ret = strings.Split(s, sep[0])
-	if len(sep) > 1 {
-		ret2 := []string{}
-		for _, r := range ret {
-			ret2 = append(ret2, splitMultiSep(r, sep[1:])...)
-		}
-		ret = ret2
-	}
-	return ret
-}
-
 // QueryExecType is the default way to determine the "EXEC" prefix for a SQL
 // query and whether or not it should be Exec'd or Query'd.
 func QueryExecType(prefix, sqlstr string) (string, bool) {
@@ -245,16 +232,19 @@ func QueryExecType(prefix, sqlstr string) (string, bool) {
 		return "EXEC", false
 	}
 
-	var pref string
-	sp := splitMultiSep(prefix, []string{" ", "\t", "\n"})
+	// keywords are matched in upper case, so that the type reported does not
+	// depend on the case of the statement
+	sp := strings.Fields(strings.ToUpper(prefix))
 	if len(sp) == 0 {
-		return pref, false
+		return "", false
 	}
-	for _, s := range sp {
-		trimed := strings.TrimSpace(s)
-		if trimed != "" {
-			pref = strings.ToUpper(trimed)
-			break
+	pref := sp[0]
+
+	// a WITH query is typed by the statement following its common table
+	// expressions, such as DELETE
+	if pref == "WITH" {
+		if stmt := cteStatement(prefix); stmt != "" {
+			return QueryExecType(stmt, sqlstr)
 		}
 	}
 
@@ -303,3 +293,53 @@ func QueryExecType(prefix, sqlstr string) (string, bool) {
 	}
 	return pref, false
 }
+
+// cteMainKeywords are the keywords starting the statement that follows the
+// common table expressions of a WITH query.
+var cteMainKeywords = map[string]bool{
+	"SELECT": true,
+	"INSERT": true,
+	"UPDATE": true,
+	"DELETE": true,
+	"MERGE":  true,
+	"VALUES": true,
+	"TABLE":  true,
+}
+
+// cteStatement returns the statement following the common table expressions
+// of a WITH query, such as "DELETE FROM city" of
+// "WITH x AS (SELECT 1) DELETE FROM city", or an empty string if none is
+// found. Words in parentheses and quotes are skipped.
+func cteStatement(query string) string {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && isWordByte(c) && (i == 0 || !isWordByte(query[i-1])):
+			end := i
+			for end < len(query) && isWordByte(query[end]) {
+				end++
+			}
+			if cteMainKeywords[strings.ToUpper(query[i:end])] {
+				return query[i:]
+			}
+			i = end - 1
+		}
+	}
+	return ""
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
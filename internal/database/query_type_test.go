@@ -77,6 +77,34 @@ func TestQueryExecType(t *testing.T) {
 			wantPrefix:   "DELETE",
 			wantExecType: false,
 		},
+		{
+			name:         "drop table",
+			prefix:       "drop table city",
+			sqlstr:       "",
+			wantPrefix:   "DROP TABLE",
+			wantExecType: false,
+		},
+		{
+			name:         "with select",
+			prefix:       "WITH x AS (SELECT 1) SELECT * FROM x",
+			sqlstr:       "",
+			wantPrefix:   "SELECT",
+			wantExecType: true,
+		},
+		{
+			name:         "with delete",
+			prefix:       "WITH x AS (SELECT 1) DELETE FROM city",
+			sqlstr:       "",
+			wantPrefix:   "DELETE",
+			wantExecType: false,
+		},
+		{
+			name:         "with recursive insert",
+			prefix:       "with recursive n(i) as (select 1 union all select i + 1 from n where i < 3), m as (select ')') insert into t select i from n",
+			sqlstr:       "",
+			wantPrefix:   "INSERT",
+			wantExecType: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lighttiger2505/sqls/dialect"
+)
+
+// ErrNoPrimaryKey is returned when an UPDATE statement cannot be keyed by the
+// primary key because the result does not include it.
+var ErrNoPrimaryKey = errors.New("the result does not include the primary key of the table")
+
+// RowStatementGenerator turns rows of a query on a single table into
+// statements that reproduce them.
+type RowStatementGenerator struct {
+	Driver  dialect.DatabaseDriver
+	Schema  string
+	Table   string
+	Columns []string
+	// Descs holds the description of each column, nil if it is unknown
+	Descs []*ColumnDesc
+	// Types holds the database type of each column reported by the driver,
+	// empty if it is unknown
+	Types []string
+}
+
+func (g *RowStatementGenerator) literal(i int, value interface{}) string {
+	var databaseType string
+	if i < len(g.Types) {
+		databaseType = g.Types[i]
+	}
+	return SQLLiteral(g.Driver, g.Descs[i], databaseType, value)
+}
+
+// Insert returns an INSERT statement of the row.
+func (g *RowStatementGenerator) Insert(row []interface{}) string {
+	columns := make([]string, len(g.Columns))
+	values := make([]string, len(g.Columns))
+	for i, col := range g.Columns {
+		columns[i] = QuoteIdentifier(g.Driver, col)
+		values[i] = g.literal(i, row[i])
+	}
+	return fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s);",
+		QuoteTable(g.Driver, g.Schema, g.Table),
+		strings.Join(columns, ", "),
+		strings.Join(values, ", "),
+	)
+}
+
+// Update returns an UPDATE statement setting the columns of the row, keyed by
+// the primary key.
+func (g *RowStatementGenerator) Update(row []interface{}) (string, error) {
+	sets := []string{}
+	conds := []string{}
+	for i, col := range g.Columns {
+		expr := fmt.Sprintf("%s = %s", QuoteIdentifier(g.Driver, col), g.literal(i, row[i]))
+		if g.Descs[i] != nil && g.Descs[i].IsPrimaryKey() {
+			if row[i] == nil {
+				return "", fmt.Errorf("primary key %q is NULL", col)
+			}
+			conds = append(conds, expr)
+		} else {
+			sets = append(sets, expr)
+		}
+	}
+	if len(conds) == 0 {
+		return "", ErrNoPrimaryKey
+	}
+	if len(sets) == 0 {
+		return "", errors.New("the result has no columns to update besides the primary key")
+	}
+	return fmt.Sprintf(
+		"UPDATE %s SET %s WHERE %s;",
+		QuoteTable(g.Driver, g.Schema, g.Table),
+		strings.Join(sets, ", "),
+		strings.Join(conds, " AND "),
+	), nil
+}
+
+// SQLLiteral formats a value scanned from the driver as a literal of the
+// dialect. desc is used to leave numbers unquoted when the driver returns
+// them as text, and may be nil. databaseType is the type of the result column
+// reported by the driver, such as BLOB, used to write binary values as
+// hexadecimal, and may be empty.
+func SQLLiteral(driver dialect.DatabaseDriver, desc *ColumnDesc, databaseType string, value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if driver == dialect.DatabaseDriverPostgreSQL {
+			return strings.ToUpper(strconv.FormatBool(v))
+		}
+		if v {
+			return "1"
+		}
+		return "0"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v)
+	case time.Time:
+		if driver == dialect.DatabaseDriverPostgreSQL {
+			return quoteString(driver, v.Format("2006-01-02 15:04:05.999999999-07:00"))
+		}
+		return quoteString(driver, v.Format("2006-01-02 15:04:05.999999999"))
+	case []byte:
+		if isBinaryType(databaseType) || (desc != nil && isBinaryType(desc.Type)) || !utf8.Valid(v) {
+			return binaryLiteral(driver, v)
+		}
+		return textLiteral(driver, desc, string(v))
+	case string:
+		return textLiteral(driver, desc, v)
+	default:
+		return quoteString(driver, fmt.Sprint(v))
+	}
+}
+
+func textLiteral(driver dialect.DatabaseDriver, desc *ColumnDesc, s string) string {
+	if desc != nil {
+		switch columnValueKind(desc.Type) {
+		case valueKindInteger, valueKindFloat:
+			if _, err := strconv.ParseFloat(s, 64); err == nil {
+				return s
+			}
+		}
+	}
+	return quoteString(driver, s)
+}
+
+// binaryLiteral writes bytes as a hexadecimal literal, X'..' on MySQL and
+// SQLite and a bytea escape on PostgreSQL.
+func binaryLiteral(driver dialect.DatabaseDriver, b []byte) string {
+	if driver == dialect.DatabaseDriverPostgreSQL {
+		return fmt.Sprintf(`'\x%x'::bytea`, b)
+	}
+	return fmt.Sprintf("X'%x'", b)
+}
+
+// isBinaryType reports whether a column type holds binary strings.
+func isBinaryType(typ string) bool {
+	typ = strings.ToLower(strings.TrimSpace(typ))
+	if i := strings.Index(typ, "("); i >= 0 {
+		typ = typ[:i]
+	}
+	switch typ {
+	case "blob", "tinyblob", "mediumblob", "longblob", "binary", "varbinary", "bytea":
+		return true
+	}
+	return false
+}
+
+// quoteString quotes s as a string literal. MySQL treats backslashes in
+// string literals as escape characters, so they are escaped as well.
+func quoteString(driver dialect.DatabaseDriver, s string) string {
+	switch driver {
+	case dialect.DatabaseDriverMySQL, dialect.DatabaseDriverMySQL8, dialect.DatabaseDriverMySQL57, dialect.DatabaseDriverMySQL56:
+		s = strings.Replace(s, `\`, `\\`, -1)
+	}
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
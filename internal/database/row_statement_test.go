@@ -0,0 +1,145 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/dialect"
+)
+
+func TestSQLLiteral(t *testing.T) {
+	tests := []struct {
+		name         string
+		driver       dialect.DatabaseDriver
+		desc         *ColumnDesc
+		databaseType string
+		value        interface{}
+		want         string
+	}{
+		{
+			name:   "null",
+			driver: dialect.DatabaseDriverMySQL,
+			value:  nil,
+			want:   "NULL",
+		},
+		{
+			name:   "mysql string",
+			driver: dialect.DatabaseDriverMySQL,
+			value:  []byte(`It's C:\Windows`),
+			want:   `'It''s C:\\Windows'`,
+		},
+		{
+			name:   "postgresql string",
+			driver: dialect.DatabaseDriverPostgreSQL,
+			value:  `It's C:\Windows`,
+			want:   `'It''s C:\Windows'`,
+		},
+		{
+			name:   "mysql number as text",
+			driver: dialect.DatabaseDriverMySQL,
+			desc:   &ColumnDesc{Type: "int(11)"},
+			value:  []byte("4079"),
+			want:   "4079",
+		},
+		{
+			name:   "digits in text column",
+			driver: dialect.DatabaseDriverMySQL,
+			desc:   &ColumnDesc{Type: "char(3)"},
+			value:  []byte("001"),
+			want:   "'001'",
+		},
+		{
+			name:   "integer",
+			driver: dialect.DatabaseDriverSQLite3,
+			value:  int64(42),
+			want:   "42",
+		},
+		{
+			name:   "postgresql boolean",
+			driver: dialect.DatabaseDriverPostgreSQL,
+			value:  true,
+			want:   "TRUE",
+		},
+		{
+			name:   "sqlite boolean",
+			driver: dialect.DatabaseDriverSQLite3,
+			value:  false,
+			want:   "0",
+		},
+		{
+			name:   "postgresql timestamp",
+			driver: dialect.DatabaseDriverPostgreSQL,
+			value:  time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+			want:   "'2020-06-01 12:00:00+00:00'",
+		},
+		{
+			name:   "mysql timestamp",
+			driver: dialect.DatabaseDriverMySQL,
+			value:  time.Date(2020, 6, 1, 12, 0, 0, 500000000, time.UTC),
+			want:   "'2020-06-01 12:00:00.5'",
+		},
+		{
+			name:         "mysql blob",
+			driver:       dialect.DatabaseDriverMySQL,
+			databaseType: "BLOB",
+			value:        []byte("ab'"),
+			want:         "X'616227'",
+		},
+		{
+			name:   "sqlite invalid utf-8",
+			driver: dialect.DatabaseDriverSQLite3,
+			value:  []byte{0xff, 0x00},
+			want:   "X'ff00'",
+		},
+		{
+			name:   "postgresql bytea",
+			driver: dialect.DatabaseDriverPostgreSQL,
+			desc:   &ColumnDesc{Type: "bytea"},
+			value:  []byte{0xde, 0xad},
+			want:   `'\xdead'::bytea`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SQLLiteral(tt.driver, tt.desc, tt.databaseType, tt.value)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch literal (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRowStatementGenerator(t *testing.T) {
+	generator := &RowStatementGenerator{
+		Driver:  dialect.DatabaseDriverMySQL,
+		Schema:  "world",
+		Table:   "city",
+		Columns: []string{"ID", "Name", "Population"},
+		Descs: []*ColumnDesc{
+			{Name: "ID", Type: "int(11)", Key: "PRI"},
+			{Name: "Name", Type: "char(35)"},
+			{Name: "Population", Type: "int(11)"},
+		},
+	}
+	row := []interface{}{[]byte("1"), []byte("Kabul"), []byte("1780000")}
+
+	wantInsert := "INSERT INTO `world`.`city` (`ID`, `Name`, `Population`) VALUES (1, 'Kabul', 1780000);"
+	if diff := cmp.Diff(wantInsert, generator.Insert(row)); diff != "" {
+		t.Errorf("unmatch insert (- want, + got):\n%s", diff)
+	}
+
+	gotUpdate, err := generator.Update(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantUpdate := "UPDATE `world`.`city` SET `Name` = 'Kabul', `Population` = 1780000 WHERE `ID` = 1;"
+	if diff := cmp.Diff(wantUpdate, gotUpdate); diff != "" {
+		t.Errorf("unmatch update (- want, + got):\n%s", diff)
+	}
+
+	generator.Descs[0] = nil
+	if _, err := generator.Update(row); err != ErrNoPrimaryKey {
+		t.Errorf("Update() without primary key must fail with %q, got %v", ErrNoPrimaryKey, err)
+	}
+}
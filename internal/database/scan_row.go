@@ -31,26 +31,168 @@ func Columns(rows *sql.Rows) ([]string, error) {
 func ScanRows(rows *sql.Rows, columnLength int) ([][]string, error) {
 	stringRows := [][]string{}
 	for rows.Next() {
-		// scan to []interface{}
-		rowBuffer := make([]interface{}, columnLength)
-		for i := range rowBuffer {
-			rowBuffer[i] = new(interface{})
+		stringRow, err := scanRow(rows, columnLength)
+		if err != nil {
+			return nil, err
 		}
-		if err := rows.Scan(rowBuffer...); err != nil {
+		stringRows = append(stringRows, stringRow)
+	}
+	return stringRows, nil
+}
+
+func scanRow(rows *sql.Rows, columnLength int) ([]string, error) {
+	values, err := scanValues(rows, columnLength)
+	if err != nil {
+		return nil, err
+	}
+	return valuesToStrings(values)
+}
+
+// scanValues scans a row to the values returned by the driver.
+func scanValues(rows *sql.Rows, columnLength int) ([]interface{}, error) {
+	rowBuffer := make([]interface{}, columnLength)
+	for i := range rowBuffer {
+		rowBuffer[i] = new(interface{})
+	}
+	if err := rows.Scan(rowBuffer...); err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, columnLength)
+	for i, buf := range rowBuffer {
+		values[i] = *buf.(*interface{})
+	}
+	return values, nil
+}
+
+func valuesToStrings(values []interface{}) ([]string, error) {
+	stringRow := make([]string, len(values))
+	for i, value := range values {
+		val, err := sqlValToString(&value)
+		if err != nil {
 			return nil, err
 		}
+		stringRow[i] = val
+	}
+	return stringRow, nil
+}
+
+// RowCursor reads the result of a query in batches, so that a large result
+// set does not have to be held in memory at once.
+type RowCursor struct {
+	rows    *sql.Rows
+	columns []string
+	pending []interface{}
+	done    bool
+	fetched int
+}
+
+func NewRowCursor(rows *sql.Rows) (*RowCursor, error) {
+	columns, err := Columns(rows)
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+	return &RowCursor{
+		rows:    rows,
+		columns: columns,
+	}, nil
+}
+
+func (c *RowCursor) Columns() []string {
+	return c.columns
+}
+
+// ColumnTypes returns the database types of the columns, such as INT or
+// VARCHAR, or empty strings if the driver does not report them.
+func (c *RowCursor) ColumnTypes() []string {
+	types := make([]string, len(c.columns))
+	columnTypes, err := c.rows.ColumnTypes()
+	if err != nil {
+		return types
+	}
+	for i, columnType := range columnTypes {
+		if i < len(types) {
+			types[i] = columnType.DatabaseTypeName()
+		}
+	}
+	return types
+}
 
-		stringRow := make([]string, columnLength)
-		for i, buf := range rowBuffer {
-			val, err := sqlValToString(buf)
-			if err != nil {
-				return nil, err
-			}
-			stringRow[i] = val
+// Fetch reads at most limit rows from the cursor. A limit less than or equal
+// to zero reads all remaining rows. hasMore reports whether rows are left
+// after the returned batch; once it is false the cursor is closed.
+func (c *RowCursor) Fetch(limit int) (stringRows [][]string, hasMore bool, err error) {
+	valueRows, hasMore, err := c.FetchValues(limit)
+	if err != nil {
+		return nil, false, err
+	}
+	stringRows = make([][]string, len(valueRows))
+	for i, values := range valueRows {
+		stringRow, err := valuesToStrings(values)
+		if err != nil {
+			c.Close()
+			return nil, false, err
 		}
-		stringRows = append(stringRows, stringRow)
+		stringRows[i] = stringRow
 	}
-	return stringRows, nil
+	return stringRows, hasMore, nil
+}
+
+// FetchValues is the same as Fetch but returns the values as scanned from the
+// driver, with NULL as nil.
+func (c *RowCursor) FetchValues(limit int) (valueRows [][]interface{}, hasMore bool, err error) {
+	valueRows = [][]interface{}{}
+	if c.pending != nil {
+		valueRows = append(valueRows, c.pending)
+		c.pending = nil
+	}
+	for !c.done {
+		if !c.rows.Next() {
+			c.done = true
+			break
+		}
+		values, err := scanValues(c.rows, len(c.columns))
+		if err != nil {
+			c.Close()
+			return nil, false, err
+		}
+		if limit > 0 && len(valueRows) >= limit {
+			// keep the read-ahead row for the next batch
+			c.pending = values
+			c.fetched += len(valueRows)
+			return valueRows, true, nil
+		}
+		valueRows = append(valueRows, values)
+	}
+	if err := c.rows.Err(); err != nil {
+		c.Close()
+		return nil, false, err
+	}
+	if err := c.Close(); err != nil {
+		return nil, false, err
+	}
+	c.fetched += len(valueRows)
+	return valueRows, false, nil
+}
+
+// Fetched returns the number of rows fetched from the cursor so far.
+func (c *RowCursor) Fetched() int {
+	return c.fetched
+}
+
+func (c *RowCursor) Close() error {
+	if c == nil {
+		return nil
+	}
+	c.done = true
+	c.pending = nil
+	return c.rows.Close()
+}
+
+// ValueString returns a value scanned from the driver as shown in results.
+func ValueString(value interface{}) (string, error) {
+	return sqlValToString(&value)
 }
 
 func sqlValToString(pointer interface{}) (string, error) {
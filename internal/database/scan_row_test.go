@@ -0,0 +1,77 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func Test_RowCursor_Fetch(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("CREATE TABLE city (id INTEGER, name TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("INSERT INTO city VALUES (1, 'Kabul'), (2, 'Qandahar'), (3, 'Herat'), (4, 'Mazar-e-Sharif'), (5, 'Amsterdam')"); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := db.Query("SELECT id, name FROM city ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cursor, err := NewRowCursor(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"id", "name"}, cursor.Columns()); diff != "" {
+		t.Errorf("unmatch columns (- want, + got):\n%s", diff)
+	}
+
+	tests := []struct {
+		name        string
+		limit       int
+		wantRows    [][]string
+		wantHasMore bool
+	}{
+		{
+			name:        "first batch",
+			limit:       2,
+			wantRows:    [][]string{{"1", "Kabul"}, {"2", "Qandahar"}},
+			wantHasMore: true,
+		},
+		{
+			name:        "second batch",
+			limit:       2,
+			wantRows:    [][]string{{"3", "Herat"}, {"4", "Mazar-e-Sharif"}},
+			wantHasMore: true,
+		},
+		{
+			name:        "last batch",
+			limit:       2,
+			wantRows:    [][]string{{"5", "Amsterdam"}},
+			wantHasMore: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hasMore, err := cursor.Fetch(tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantRows, got); diff != "" {
+				t.Errorf("unmatch rows (- want, + got):\n%s", diff)
+			}
+			if hasMore != tt.wantHasMore {
+				t.Errorf("hasMore got %v, want %v", hasMore, tt.wantHasMore)
+			}
+		})
+	}
+}
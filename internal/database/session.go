@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lighttiger2505/sqls/dialect"
+)
+
+var (
+	ErrTransactionAlreadyOpen = errors.New("transaction is already open")
+	ErrNoTransaction          = errors.New("no transaction is open")
+	ErrTransactionLost        = errors.New("database connection was lost, the open transaction was rolled back")
+)
+
+// Session executes statements on a dedicated connection taken from the pool,
+// so that transactions and session state such as SET statements and
+// temporary tables persist between executions.
+type Session struct {
+	conn     *sql.Conn
+	tx       *sql.Tx
+	readOnly bool
+}
+
+// readOnlySessionQueries make every later transaction of the session read
+// only. Every SQLite connection of the pool is additionally opened with
+// mode=ro and query_only, see sqlite3ReadOnlyDSN.
+var readOnlySessionQueries = map[dialect.DatabaseDriver]string{
+	dialect.DatabaseDriverMySQL:      "SET SESSION TRANSACTION READ ONLY",
+	dialect.DatabaseDriverMySQL8:     "SET SESSION TRANSACTION READ ONLY",
+	dialect.DatabaseDriverMySQL57:    "SET SESSION TRANSACTION READ ONLY",
+	dialect.DatabaseDriverMySQL56:    "SET SESSION TRANSACTION READ ONLY",
+	dialect.DatabaseDriverPostgreSQL: "SET SESSION CHARACTERISTICS AS TRANSACTION READ ONLY",
+	dialect.DatabaseDriverSQLite3:    "PRAGMA query_only = ON",
+}
+
+func NewSession(ctx context.Context, db *sql.DB, cfg *DBConfig) (*Session, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	session := &Session{conn: conn}
+	if cfg != nil && cfg.ReadOnly {
+		session.readOnly = true
+		if query, ok := readOnlySessionQueries[cfg.Driver]; ok {
+			if _, err := conn.ExecContext(ctx, query); err != nil {
+				conn.Close()
+				return nil, err
+			}
+		}
+	}
+	return session, nil
+}
+
+func (s *Session) ReadOnly() bool {
+	return s.readOnly
+}
+
+// Ping verifies the connection of the session is still alive.
+func (s *Session) Ping(ctx context.Context) error {
+	return s.conn.PingContext(ctx)
+}
+
+func (s *Session) InTransaction() bool {
+	return s.tx != nil
+}
+
+func (s *Session) Begin(ctx context.Context) error {
+	if s.tx != nil {
+		return ErrTransactionAlreadyOpen
+	}
+	tx, err := s.conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: s.readOnly})
+	if err != nil {
+		return err
+	}
+	s.tx = tx
+	return nil
+}
+
+func (s *Session) Commit() error {
+	if s.tx == nil {
+		return ErrNoTransaction
+	}
+	tx := s.tx
+	s.tx = nil
+	return tx.Commit()
+}
+
+func (s *Session) Rollback() error {
+	if s.tx == nil {
+		return ErrNoTransaction
+	}
+	tx := s.tx
+	s.tx = nil
+	return tx.Rollback()
+}
+
+func (s *Session) Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if s.tx != nil {
+		return s.tx.ExecContext(ctx, query, args...)
+	}
+	return s.conn.ExecContext(ctx, query, args...)
+}
+
+func (s *Session) Query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if s.tx != nil {
+		return s.tx.QueryContext(ctx, query, args...)
+	}
+	return s.conn.QueryContext(ctx, query, args...)
+}
+
+// Close rolls back the open transaction, if any, and returns the connection
+// to the pool.
+func (s *Session) Close() error {
+	if s == nil {
+		return nil
+	}
+	var rollbackErr error
+	if s.tx != nil {
+		rollbackErr = s.Rollback()
+	}
+	if err := s.conn.Close(); err != nil {
+		return err
+	}
+	return rollbackErr
+}
@@ -5,6 +5,10 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/lighttiger2505/sqls/dialect"
 	_ "github.com/mattn/go-sqlite3"
@@ -16,7 +20,11 @@ func init() {
 }
 
 func sqlite3Open(connCfg *DBConfig) (*DBConnection, error) {
-	conn, err := sql.Open("sqlite3", connCfg.DataSourceName)
+	dsn := connCfg.DataSourceName
+	if connCfg.ReadOnly {
+		dsn = sqlite3ReadOnlyDSN(dsn)
+	}
+	conn, err := sql.Open("sqlite3", dsn)
 	if err != nil {
 		return nil, err
 	}
@@ -27,6 +35,54 @@ func sqlite3Open(connCfg *DBConfig) (*DBConnection, error) {
 	}, nil
 }
 
+// sqlite3ReadOnlyDSN makes every connection of the data source name read
+// only. mode=ro opens the database file read only, replacing any other mode,
+// and _query_only turns on PRAGMA query_only for each connection of the pool,
+// which also guards in-memory databases as they cannot be opened read only.
+// URI parameters are only honored for file: URIs, so a plain path is turned
+// into one.
+func sqlite3ReadOnlyDSN(dsn string) string {
+	path, rawQuery := dsn, ""
+	if i := strings.IndexRune(dsn, '?'); i >= 0 {
+		path, rawQuery = dsn[:i], dsn[i+1:]
+	}
+	memory := path == ":memory:" || path == "file::memory:"
+	params := []string{}
+	for _, param := range strings.Split(rawQuery, "&") {
+		if param == "" {
+			continue
+		}
+		key, value := param, ""
+		if i := strings.IndexRune(param, '='); i >= 0 {
+			key, value = param[:i], param[i+1:]
+		}
+		if k, err := url.QueryUnescape(key); err == nil {
+			key = k
+		}
+		if v, err := url.QueryUnescape(value); err == nil {
+			value = v
+		}
+		switch key {
+		case "mode":
+			if value != "memory" {
+				continue
+			}
+			memory = true
+		case "_query_only":
+			continue
+		}
+		params = append(params, param)
+	}
+	if !memory {
+		if !strings.HasPrefix(path, "file:") {
+			path = "file:" + path
+		}
+		params = append(params, "mode=ro")
+	}
+	params = append(params, "_query_only=1")
+	return path + "?" + strings.Join(params, "&")
+}
+
 type SQLite3DBRepository struct {
 	Conn *sql.DB
 }
@@ -125,6 +181,11 @@ func (db *SQLite3DBRepository) DescribeDatabaseTable(ctx context.Context) ([]*Co
 	if err != nil {
 		return nil, err
 	}
+	views, err := db.views(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tables = append(tables, views...)
 	all := []*ColumnDesc{}
 	for _, table := range tables {
 		descs, err := db.describeTable(ctx, table)
@@ -140,6 +201,457 @@ func (db *SQLite3DBRepository) DescribeDatabaseTableBySchema(ctx context.Context
 	return db.DescribeDatabaseTable(ctx)
 }
 
+// CreateTableStatement returns the statement that created the table followed
+// by the statements of its indexes and triggers.
+func (db *SQLite3DBRepository) CreateTableStatement(ctx context.Context, schemaName, tableName string) (string, error) {
+	rows, err := db.Conn.QueryContext(ctx, `
+	SELECT
+	  sql
+	FROM
+	  sqlite_master
+	WHERE
+	  tbl_name = ?
+	  AND sql IS NOT NULL
+	ORDER BY
+	  type NOT IN ('table', 'view'),
+	  name
+	`, tableName)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	stmts := []string{}
+	for rows.Next() {
+		var stmt string
+		if err := rows.Scan(&stmt); err != nil {
+			return "", err
+		}
+		stmts = append(stmts, stmt+";")
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	if len(stmts) == 0 {
+		return "", fmt.Errorf("table not found, %s", tableName)
+	}
+	return strings.Join(stmts, "\n\n"), nil
+}
+
+// indexList returns the indexes of the table with the origin of each one, "c"
+// for CREATE INDEX, "u" for UNIQUE constraints and "pk" for the primary key.
+func (db *SQLite3DBRepository) indexList(ctx context.Context, tableName string) ([]*IndexDesc, []string, error) {
+	rows, err := db.Conn.QueryContext(ctx, fmt.Sprintf("PRAGMA index_list(%s);", QuoteIdentifier(dialect.DatabaseDriverSQLite3, tableName)))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	indexes := []*IndexDesc{}
+	origins := []string{}
+	for rows.Next() {
+		var seq, unique, partial int
+		var index IndexDesc
+		var origin string
+		if err := rows.Scan(&seq, &index.Name, &unique, &origin, &partial); err != nil {
+			return nil, nil, err
+		}
+		index.Unique = unique != 0
+		index.Primary = origin == "pk"
+		indexes = append(indexes, &index)
+		origins = append(origins, origin)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	rows.Close()
+
+	for _, index := range indexes {
+		columns, err := db.indexColumns(ctx, index.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		index.Columns = columns
+	}
+	return indexes, origins, nil
+}
+
+func (db *SQLite3DBRepository) indexColumns(ctx context.Context, indexName string) ([]string, error) {
+	rows, err := db.Conn.QueryContext(ctx, fmt.Sprintf("PRAGMA index_info(%s);", QuoteIdentifier(dialect.DatabaseDriverSQLite3, indexName)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns := []string{}
+	for rows.Next() {
+		var seqno, cid int
+		var name sql.NullString
+		if err := rows.Scan(&seqno, &cid, &name); err != nil {
+			return nil, err
+		}
+		if !name.Valid {
+			// the key is an expression, which index_info does not show
+			name.String = "<expression>"
+		}
+		columns = append(columns, name.String)
+	}
+	return columns, rows.Err()
+}
+
+func (db *SQLite3DBRepository) TableIndexes(ctx context.Context, schemaName, tableName string) ([]*IndexDesc, error) {
+	indexes, _, err := db.indexList(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	hasPrimary := false
+	for _, index := range indexes {
+		hasPrimary = hasPrimary || index.Primary
+	}
+	if !hasPrimary {
+		// an INTEGER PRIMARY KEY is the rowid itself, without an index
+		pks, err := db.primaryKeyColumns(ctx, tableName)
+		if err != nil {
+			return nil, err
+		}
+		if len(pks) > 0 {
+			indexes = append(indexes, &IndexDesc{Columns: pks, Unique: true, Primary: true})
+		}
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		if indexes[i].Primary != indexes[j].Primary {
+			return indexes[i].Primary
+		}
+		return indexes[i].Name < indexes[j].Name
+	})
+	return indexes, nil
+}
+
+// TableConstraints returns the UNIQUE constraints, which SQLite implements
+// with indexes, and the CHECK constraints found in the CREATE TABLE statement.
+func (db *SQLite3DBRepository) TableConstraints(ctx context.Context, schemaName, tableName string) ([]*ConstraintDesc, error) {
+	indexes, origins, err := db.indexList(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	constraints := []*ConstraintDesc{}
+	for i, index := range indexes {
+		if origins[i] != "u" {
+			continue
+		}
+		constraints = append(constraints, &ConstraintDesc{
+			Name:       index.Name,
+			Type:       ConstraintTypeUnique,
+			Columns:    index.Columns,
+			Definition: uniqueDefinition(dialect.DatabaseDriverSQLite3, index.Columns),
+		})
+	}
+	sort.SliceStable(constraints, func(i, j int) bool {
+		return constraints[i].Name < constraints[j].Name
+	})
+
+	var stmt sql.NullString
+	err = db.Conn.QueryRowContext(ctx, "SELECT sql FROM sqlite_master WHERE type = 'table' AND tbl_name = ?", tableName).Scan(&stmt)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return append(constraints, sqliteCheckConstraints(stmt.String)...), nil
+}
+
+// sqliteCheckConstraints extracts the CHECK constraints from a CREATE TABLE
+// statement, with the name given by a preceding CONSTRAINT clause.
+func sqliteCheckConstraints(stmt string) []*ConstraintDesc {
+	constraints := []*ConstraintDesc{}
+	words := []string{}
+	for i := 0; i < len(stmt); {
+		c := stmt[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			end := sqliteQuoteEnd(stmt, i)
+			words = append(words, strings.Trim(stmt[i:end], "'\"`[]"))
+			i = end
+		case isSQLiteWordChar(c):
+			start := i
+			for i < len(stmt) && isSQLiteWordChar(stmt[i]) {
+				i++
+			}
+			word := stmt[start:i]
+			if !strings.EqualFold(word, "CHECK") {
+				words = append(words, word)
+				continue
+			}
+			for i < len(stmt) && (stmt[i] == ' ' || stmt[i] == '\t' || stmt[i] == '\n' || stmt[i] == '\r') {
+				i++
+			}
+			if i == len(stmt) || stmt[i] != '(' {
+				continue
+			}
+			end := sqliteParenEnd(stmt, i)
+			constraint := &ConstraintDesc{
+				Type:       ConstraintTypeCheck,
+				Definition: "CHECK " + stmt[i:end],
+			}
+			if n := len(words); n >= 2 && strings.EqualFold(words[n-2], "CONSTRAINT") {
+				constraint.Name = words[n-1]
+			}
+			constraints = append(constraints, constraint)
+			words = words[:0]
+			i = end
+		default:
+			if c == ',' {
+				words = words[:0]
+			}
+			i++
+		}
+	}
+	return constraints
+}
+
+func isSQLiteWordChar(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// sqliteQuoteEnd returns the position after the quoted string or identifier
+// starting at i. Doubled quotes are part of the string.
+func sqliteQuoteEnd(s string, i int) int {
+	quote := s[i]
+	if quote == '[' {
+		quote = ']'
+	}
+	for j := i + 1; j < len(s); j++ {
+		if s[j] != quote {
+			continue
+		}
+		if quote != ']' && j+1 < len(s) && s[j+1] == quote {
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return len(s)
+}
+
+// sqliteParenEnd returns the position after the parenthesis matching the one
+// at i.
+func sqliteParenEnd(s string, i int) int {
+	depth := 0
+	for i < len(s) {
+		switch s[i] {
+		case '\'', '"', '`', '[':
+			i = sqliteQuoteEnd(s, i)
+			continue
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+		i++
+	}
+	return len(s)
+}
+
+func (db *SQLite3DBRepository) TableForeignKeys(ctx context.Context, schemaName, tableName string) ([]*ForeignKeyDesc, error) {
+	rows, err := db.Conn.QueryContext(ctx, fmt.Sprintf("PRAGMA foreign_key_list(%s);", QuoteIdentifier(dialect.DatabaseDriverSQLite3, tableName)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	fks := []*ForeignKeyDesc{}
+	lastID := -1
+	for rows.Next() {
+		var id, seq int
+		var refTable, column, match string
+		var refColumn sql.NullString
+		var onUpdate, onDelete string
+		if err := rows.Scan(&id, &seq, &refTable, &column, &refColumn, &onUpdate, &onDelete, &match); err != nil {
+			return nil, err
+		}
+		if id != lastID {
+			fks = append(fks, &ForeignKeyDesc{
+				Table:    tableName,
+				RefTable: refTable,
+				OnUpdate: onUpdate,
+				OnDelete: onDelete,
+			})
+			lastID = id
+		}
+		fk := fks[len(fks)-1]
+		fk.Columns = append(fk.Columns, column)
+		// the referenced column is NULL when the foreign key refers to the
+		// primary key without naming it
+		fk.RefColumns = append(fk.RefColumns, refColumn.String)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, fk := range fks {
+		if fk.RefColumns[0] != "" {
+			continue
+		}
+		pks, err := db.primaryKeyColumns(ctx, fk.RefTable)
+		if err != nil {
+			return nil, err
+		}
+		for i := range fk.RefColumns {
+			if i < len(pks) {
+				fk.RefColumns[i] = pks[i]
+			}
+		}
+	}
+	return fks, nil
+}
+
+func (db *SQLite3DBRepository) SchemaForeignKeys(ctx context.Context, schemaName string) ([]*ForeignKeyDesc, error) {
+	tables, err := db.Tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	all := []*ForeignKeyDesc{}
+	for _, table := range tables {
+		fks, err := db.TableForeignKeys(ctx, schemaName, table)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, fks...)
+	}
+	return all, nil
+}
+
+// primaryKeyColumns returns the columns of the primary key of the table in
+// key order.
+func (db *SQLite3DBRepository) primaryKeyColumns(ctx context.Context, tableName string) ([]string, error) {
+	cols, err := db.describeTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	pks := []*ColumnDesc{}
+	for _, col := range cols {
+		if col.IsPrimaryKey() {
+			pks = append(pks, col)
+		}
+	}
+	sort.SliceStable(pks, func(i, j int) bool {
+		ki, _ := strconv.Atoi(pks[i].Key)
+		kj, _ := strconv.Atoi(pks[j].Key)
+		return ki < kj
+	})
+	names := make([]string, len(pks))
+	for i, pk := range pks {
+		names[i] = pk.Name
+	}
+	return names, nil
+}
+
+func (db *SQLite3DBRepository) SchemaViews(ctx context.Context) (map[string][]string, error) {
+	views, err := db.views(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]string{"": views}, nil
+}
+
+func (db *SQLite3DBRepository) views(ctx context.Context) ([]string, error) {
+	rows, err := db.Conn.QueryContext(ctx, `
+	SELECT
+	  name
+	FROM
+	  sqlite_master
+	WHERE
+	  type = 'view'
+	ORDER BY
+	  name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	views := []string{}
+	for rows.Next() {
+		var view string
+		if err := rows.Scan(&view); err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, rows.Err()
+}
+
+// SchemaRoutines returns no routines since SQLite has no stored functions or
+// procedures.
+func (db *SQLite3DBRepository) SchemaRoutines(ctx context.Context) ([]*RoutineDesc, error) {
+	return []*RoutineDesc{}, nil
+}
+
+// SchemaSequences returns no sequences since SQLite has none.
+func (db *SQLite3DBRepository) SchemaSequences(ctx context.Context) (map[string][]string, error) {
+	return map[string][]string{}, nil
+}
+
+// TableComments returns no comments since SQLite has no comments on tables.
+func (db *SQLite3DBRepository) TableComments(ctx context.Context) ([]*TableCommentDesc, error) {
+	return []*TableCommentDesc{}, nil
+}
+
+// TableStats returns the row count recorded by the last ANALYZE in
+// sqlite_stat1, and the sizes of the table and its indexes from the dbstat
+// virtual table. Each of them is null when its table is missing, since
+// sqlite_stat1 exists only after ANALYZE and dbstat only when SQLite is built
+// with it. SQLite does not record when it analyzed a table.
+func (db *SQLite3DBRepository) TableStats(ctx context.Context, schemaName, tableName string) (*TableStatsDesc, error) {
+	var stats TableStatsDesc
+	var stat string
+	err := db.Conn.QueryRowContext(ctx, `
+	SELECT
+	  stat
+	FROM
+	  sqlite_stat1
+	WHERE
+	  tbl = ?
+	ORDER BY
+	  idx IS NOT NULL
+	LIMIT 1
+	`, tableName).Scan(&stat)
+	switch {
+	case err == nil:
+		// the first integer of the stat is the number of rows
+		fields := strings.Fields(stat)
+		if len(fields) > 0 {
+			if n, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+				stats.Rows = sql.NullInt64{Int64: n, Valid: true}
+			}
+		}
+	case err == sql.ErrNoRows || isNoSuchTable(err):
+	default:
+		return nil, err
+	}
+
+	err = db.Conn.QueryRowContext(ctx, `
+	SELECT
+	  SUM(CASE WHEN m.type = 'table' THEN d.pgsize ELSE 0 END),
+	  SUM(CASE WHEN m.type = 'index' THEN d.pgsize ELSE 0 END)
+	FROM
+	  dbstat d
+	  JOIN sqlite_master m ON m.name = d.name
+	WHERE
+	  m.tbl_name = ?
+	`, tableName).Scan(&stats.DataSize, &stats.IndexSize)
+	if err != nil && !isNoSuchTable(err) {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func isNoSuchTable(err error) bool {
+	return strings.HasPrefix(err.Error(), "no such table")
+}
+
+// SchemaTypes returns no types since SQLite has no user-defined types.
+func (db *SQLite3DBRepository) SchemaTypes(ctx context.Context) ([]*TypeDesc, error) {
+	return []*TypeDesc{}, nil
+}
+
 func (db *SQLite3DBRepository) Exec(ctx context.Context, query string) (sql.Result, error) {
 	return db.Conn.ExecContext(ctx, query)
 }
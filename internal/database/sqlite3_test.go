@@ -0,0 +1,313 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_sqlite3ReadOnlyDSN(t *testing.T) {
+	tests := []struct {
+		name string
+		dsn  string
+		want string
+	}{
+		{
+			name: "path",
+			dsn:  "/tmp/world.db",
+			want: "file:/tmp/world.db?mode=ro&_query_only=1",
+		},
+		{
+			name: "uri",
+			dsn:  "file:/tmp/world.db",
+			want: "file:/tmp/world.db?mode=ro&_query_only=1",
+		},
+		{
+			name: "uri with params",
+			dsn:  "file:/tmp/world.db?cache=shared",
+			want: "file:/tmp/world.db?cache=shared&mode=ro&_query_only=1",
+		},
+		{
+			name: "journal mode",
+			dsn:  "file:/tmp/world.db?_journal_mode=WAL",
+			want: "file:/tmp/world.db?_journal_mode=WAL&mode=ro&_query_only=1",
+		},
+		{
+			name: "writable mode",
+			dsn:  "file:/tmp/world.db?mode=rwc&cache=shared",
+			want: "file:/tmp/world.db?cache=shared&mode=ro&_query_only=1",
+		},
+		{
+			name: "query only off",
+			dsn:  "/tmp/world.db?_query_only=0",
+			want: "file:/tmp/world.db?mode=ro&_query_only=1",
+		},
+		{
+			name: "memory",
+			dsn:  "file:world?mode=memory&cache=shared",
+			want: "file:world?mode=memory&cache=shared&_query_only=1",
+		},
+		{
+			name: "plain memory",
+			dsn:  ":memory:",
+			want: ":memory:?_query_only=1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sqlite3ReadOnlyDSN(tt.dsn); got != tt.want {
+				t.Errorf("sqlite3ReadOnlyDSN() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sqlite3OpenReadOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqls-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ctx := context.Background()
+
+	dsn := "file:" + filepath.Join(dir, "world.db") + "?mode=rwc&_journal_mode=WAL"
+	conn, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.ExecContext(ctx, "CREATE TABLE city (id INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	dbConn, err := sqlite3Open(&DBConfig{Driver: "sqlite3", DataSourceName: dsn, ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dbConn.Close()
+	// hold a connection so that the next statements take other ones of the pool
+	held, err := dbConn.Conn.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer held.Close()
+	for _, stmt := range []string{
+		"INSERT INTO city VALUES (1)",
+		"PRAGMA query_only = OFF; INSERT INTO city VALUES (1)",
+	} {
+		if _, err := dbConn.Conn.ExecContext(ctx, stmt); err == nil {
+			t.Errorf("%q must fail on a read-only connection", stmt)
+		}
+	}
+	if _, err := held.ExecContext(ctx, "INSERT INTO city VALUES (1)"); err == nil {
+		t.Error("every connection of the pool must be read only")
+	}
+
+	memConn, err := sqlite3Open(&DBConfig{Driver: "sqlite3", DataSourceName: "file:open_read_only?mode=memory&cache=shared", ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer memConn.Close()
+	if _, err := memConn.Conn.ExecContext(ctx, "CREATE TABLE city (id INTEGER)"); err == nil {
+		t.Error("in-memory database must be query only")
+	}
+}
+
+func TestSQLite3DBRepository_CreateTableStatement(t *testing.T) {
+	conn, err := sql.Open("sqlite3", "file:create_table_statement?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE city (id INTEGER PRIMARY KEY, name TEXT NOT NULL)",
+		"CREATE INDEX city_name ON city (name)",
+	} {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repo := NewSQLite3DBRepository(conn)
+	got, err := repo.CreateTableStatement(ctx, "", "city")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "CREATE TABLE city (id INTEGER PRIMARY KEY, name TEXT NOT NULL);\n\nCREATE INDEX city_name ON city (name);"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unmatch statement (- want, + got):\n%s", diff)
+	}
+
+	if _, err := repo.CreateTableStatement(ctx, "", "country"); err == nil {
+		t.Error("CreateTableStatement() of a missing table must fail")
+	}
+}
+
+func TestSQLite3DBRepository_TableDescription(t *testing.T) {
+	conn, err := sql.Open("sqlite3", "file:table_description?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE country (code TEXT PRIMARY KEY, name TEXT)",
+		`CREATE TABLE city (
+			id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			country_code TEXT REFERENCES country ON DELETE CASCADE,
+			population INTEGER CHECK (population >= 0),
+			PRIMARY KEY (id),
+			CONSTRAINT "city name" UNIQUE (country_code, name),
+			CONSTRAINT name_length CHECK (length(name) > 0 AND name <> ')')
+		)`,
+		"CREATE INDEX city_population ON city (population)",
+	} {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo := NewSQLite3DBRepository(conn)
+
+	indexes, err := repo.TableIndexes(ctx, "", "city")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantIndexes := []*IndexDesc{
+		{Columns: []string{"id"}, Unique: true, Primary: true},
+		{Name: "city_population", Columns: []string{"population"}},
+		{Name: "sqlite_autoindex_city_1", Columns: []string{"country_code", "name"}, Unique: true},
+	}
+	if diff := cmp.Diff(wantIndexes, indexes); diff != "" {
+		t.Errorf("unmatch indexes (- want, + got):\n%s", diff)
+	}
+
+	constraints, err := repo.TableConstraints(ctx, "", "city")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantConstraints := []*ConstraintDesc{
+		{Name: "sqlite_autoindex_city_1", Type: ConstraintTypeUnique, Columns: []string{"country_code", "name"}, Definition: `UNIQUE ("country_code", "name")`},
+		{Type: ConstraintTypeCheck, Definition: "CHECK (population >= 0)"},
+		{Name: "name_length", Type: ConstraintTypeCheck, Definition: "CHECK (length(name) > 0 AND name <> ')')"},
+	}
+	if diff := cmp.Diff(wantConstraints, constraints); diff != "" {
+		t.Errorf("unmatch constraints (- want, + got):\n%s", diff)
+	}
+
+	fks, err := repo.TableForeignKeys(ctx, "", "city")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFKs := []*ForeignKeyDesc{
+		{
+			Table:      "city",
+			Columns:    []string{"country_code"},
+			RefTable:   "country",
+			RefColumns: []string{"code"},
+			OnUpdate:   "NO ACTION",
+			OnDelete:   "CASCADE",
+		},
+	}
+	if diff := cmp.Diff(wantFKs, fks); diff != "" {
+		t.Errorf("unmatch foreign keys (- want, + got):\n%s", diff)
+	}
+
+	schemaFKs, err := repo.SchemaForeignKeys(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(wantFKs, schemaFKs); diff != "" {
+		t.Errorf("unmatch foreign keys of the schema (- want, + got):\n%s", diff)
+	}
+}
+
+func TestSQLite3DBRepository_GenerateDBCache(t *testing.T) {
+	conn, err := sql.Open("sqlite3", "file:generate_db_cache?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE city (id INTEGER PRIMARY KEY, name TEXT NOT NULL, population INTEGER)",
+		"CREATE VIEW bigcity AS SELECT name, population FROM city WHERE population > 1000000",
+	} {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	generator := NewDBCacheUpdater(NewSQLite3DBRepository(conn))
+	dbCache, err := generator.GenerateDBCachePrimary(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbCache = dbCache.withExtras(generator.GenerateDBCacheExtras(ctx, dbCache.defaultSchema))
+	if diff := cmp.Diff([]string{"city"}, dbCache.SortedTables()); diff != "" {
+		t.Errorf("unmatch tables (- want, + got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"bigcity"}, dbCache.SortedViews()); diff != "" {
+		t.Errorf("unmatch views (- want, + got):\n%s", diff)
+	}
+	if !dbCache.IsView("", "bigcity") || dbCache.IsView("", "city") {
+		t.Error("IsView() must tell the view from the table")
+	}
+	cols, ok := dbCache.ColumnDescs("bigcity")
+	if !ok {
+		t.Fatal("columns of the view are not cached")
+	}
+	names := []string{}
+	for _, col := range cols {
+		names = append(names, col.Name)
+	}
+	if diff := cmp.Diff([]string{"name", "population"}, names); diff != "" {
+		t.Errorf("unmatch view columns (- want, + got):\n%s", diff)
+	}
+	if len(dbCache.Routines) != 0 || len(dbCache.SchemaSequences) != 0 || len(dbCache.Types) != 0 {
+		t.Error("SQLite has no routines, sequences or types")
+	}
+}
+
+func TestSQLite3DBRepository_TableStats(t *testing.T) {
+	conn, err := sql.Open("sqlite3", "file:table_stats?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE city (id INTEGER PRIMARY KEY, name TEXT NOT NULL)",
+		"INSERT INTO city (name) VALUES ('Kabul'), ('Qandahar'), ('Herat')",
+	} {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo := NewSQLite3DBRepository(conn)
+
+	stats, err := repo.TableStats(ctx, "", "city")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Rows.Valid || stats.LastAnalyzed.Valid {
+		t.Errorf("rows must be unknown before ANALYZE, %+v", stats)
+	}
+
+	if _, err := conn.ExecContext(ctx, "ANALYZE"); err != nil {
+		t.Fatal(err)
+	}
+	stats, err = repo.TableStats(ctx, "", "city")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(sql.NullInt64{Int64: 3, Valid: true}, stats.Rows); diff != "" {
+		t.Errorf("unmatch rows (- want, + got):\n%s", diff)
+	}
+}
@@ -6,13 +6,20 @@ import (
 	"sync"
 )
 
+// Worker builds the cache of the database objects. A built DBCache is never
+// modified, the Worker replaces it as a whole, so the handlers can read the
+// snapshot returned by Cache while the Worker updates the cache.
 type Worker struct {
 	dbRepo  DBRepository
 	dbCache *DBCache
+	// generation counts the primary caches, so that the secondary cache of a
+	// previous connection is not applied to the cache of the current one
+	generation int
 
-	done   chan struct{}
-	update chan struct{}
-	lock   sync.Mutex
+	done     chan struct{}
+	update   chan struct{}
+	lock     sync.Mutex
+	stopOnce sync.Once
 }
 
 func NewWorker() *Worker {
@@ -22,22 +29,57 @@ func NewWorker() *Worker {
 	}
 }
 
+// Cache returns the current snapshot of the cache, nil if it is not built.
 func (w *Worker) Cache() *DBCache {
+	w.lock.Lock()
+	defer w.lock.Unlock()
 	return w.dbCache
 }
 
-func (w *Worker) setCache(c *DBCache) {
+// Generation returns a number changing each time the cache is rebuilt, such
+// as on reconnection.
+func (w *Worker) Generation() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.generation
+}
+
+func (w *Worker) setCache(repo DBRepository, c *DBCache) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
+	w.dbRepo = repo
 	w.dbCache = c
+	w.generation++
+}
+
+// setColumnCache replaces the cache with a copy having the columns, unless
+// the cache was rebuilt since the columns were requested.
+func (w *Worker) setColumnCache(generation int, col map[string][]*ColumnDesc) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.dbCache == nil || w.generation != generation {
+		return
+	}
+	c := *w.dbCache
+	c.ColumnsWithParent = col
+	w.dbCache = &c
 }
 
-func (w *Worker) setColumnCache(col map[string][]*ColumnDesc) {
+// setExtrasCache replaces the cache with a copy having the extras, unless
+// the cache was rebuilt since the extras were requested.
+func (w *Worker) setExtrasCache(generation int, extras *DBCache) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
-	if w.dbCache != nil {
-		w.dbCache.ColumnsWithParent = col
+	if w.dbCache == nil || w.generation != generation {
+		return
 	}
+	w.dbCache = w.dbCache.withExtras(extras)
+}
+
+func (w *Worker) target() (DBRepository, *DBCache, int) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.dbRepo, w.dbCache, w.generation
 }
 
 func (w *Worker) Start() {
@@ -49,42 +91,61 @@ func (w *Worker) Start() {
 				log.Println("db worker: done")
 				return
 			case <-w.update:
-				generator := NewDBCacheUpdater(w.dbRepo)
-				col, err := generator.GenerateDBCacheSecondary(context.Background())
-				if err != nil {
-					log.Println(err)
-				}
-				w.setColumnCache(col)
-				log.Println("db worker: Update db chache secondary complete")
+				w.updateSecondaryCache()
 			}
 		}
 	}()
 }
 
+// Stop stops the worker. It may be called more than once.
 func (w *Worker) Stop() {
-	close(w.done)
+	w.stopOnce.Do(func() {
+		close(w.done)
+	})
 }
 
 func (w *Worker) ReCache(ctx context.Context, repo DBRepository) error {
-	w.dbRepo = repo
-	if err := w.updateAllCache(ctx); err != nil {
+	if err := w.updateAllCache(ctx, repo); err != nil {
 		return err
 	}
 	w.updateAdditionalCache()
 	return nil
 }
 
-func (w *Worker) updateAllCache(ctx context.Context) error {
-	generator := NewDBCacheUpdater(w.dbRepo)
+func (w *Worker) updateAllCache(ctx context.Context, repo DBRepository) error {
+	generator := NewDBCacheUpdater(repo)
 	cache, err := generator.GenerateDBCachePrimary(ctx)
 	if err != nil {
 		return err
 	}
-	w.setCache(cache)
+	w.setCache(repo, cache)
 	log.Println("db worker: Update db chache primary complete")
 	return nil
 }
 
+// updateSecondaryCache loads the objects left out of the primary cache, so
+// that connecting does not wait for them: the extras such as views and
+// routines, then the columns of all the schemas.
+func (w *Worker) updateSecondaryCache() {
+	repo, cache, generation := w.target()
+	if cache == nil {
+		return
+	}
+	generator := NewDBCacheUpdater(repo)
+	w.setExtrasCache(generation, generator.GenerateDBCacheExtras(context.Background(), cache.defaultSchema))
+	col, err := generator.GenerateDBCacheSecondary(context.Background())
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	w.setColumnCache(generation, col)
+	log.Println("db worker: Update db chache secondary complete")
+}
+
 func (w *Worker) updateAdditionalCache() {
-	w.update <- struct{}{}
+	select {
+	case w.update <- struct{}{}:
+	default:
+		// an update is already pending, and reads the latest connection
+	}
 }
@@ -0,0 +1,41 @@
+package database
+
+import "testing"
+
+func TestWorkerSetColumnCache(t *testing.T) {
+	w := NewWorker()
+	first := &DBCache{SchemaTables: map[string][]string{"": {"country", "city"}}}
+	w.setCache(nil, first)
+	_, _, generation := w.target()
+
+	col := map[string][]*ColumnDesc{"city": {{Name: "id"}}}
+	w.setColumnCache(generation, col)
+	got := w.Cache()
+	if got == first {
+		t.Fatal("cache must be replaced, not modified")
+	}
+	if first.ColumnsWithParent != nil {
+		t.Error("previous cache must be left as is")
+	}
+	if len(got.ColumnsWithParent) != 1 {
+		t.Errorf("columns must be set, got %v", got.ColumnsWithParent)
+	}
+
+	// columns requested before the cache was rebuilt are dropped
+	second := &DBCache{}
+	w.setCache(nil, second)
+	w.setColumnCache(generation, col)
+	if w.Cache() != second {
+		t.Error("stale columns must not replace the cache")
+	}
+
+	if tables := first.SortedTables(); tables[0] != "city" {
+		t.Errorf("tables must be sorted, got %v", tables)
+	}
+	if first.SchemaTables[""][0] != "country" {
+		t.Error("sorting must leave the cache as is")
+	}
+
+	w.Stop()
+	w.Stop()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 
 	"github.com/lighttiger2505/sqls/internal/completer"
 	"github.com/lighttiger2505/sqls/internal/lsp"
@@ -31,9 +32,32 @@ func (s *Server) handleTextDocumentCompletion(ctx context.Context, conn *jsonrpc
 	} else {
 		c.Driver = ""
 	}
+	if history, err := s.history.Queries(s.historyAlias()); err == nil {
+		c.History = history
+	} else {
+		log.Println("failed to load query history,", err)
+	}
+	c.JoinConventions = s.getConfig().JoinInference()
+	c.SnippetSupport = s.clientCapabilities.TextDocument.Completion.CompletionItem.SnippetSupport
+	c.Snippets = s.getConfig().Snippets
 	completionItems, err := c.Complete(f.Text, params, s.getConfig().LowercaseKeywords)
 	if err != nil {
 		return nil, err
 	}
 	return completionItems, nil
 }
+
+func (s *Server) handleCompletionItemResolve(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (result interface{}, err error) {
+	if req.Params == nil {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams}
+	}
+
+	var item lsp.CompletionItem
+	if err := json.Unmarshal(*req.Params, &item); err != nil {
+		return nil, err
+	}
+
+	c := completer.NewCompleter(s.worker.Cache())
+	c.Driver = s.driver()
+	return c.Resolve(item)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/lighttiger2505/sqls/internal/config"
@@ -53,6 +54,19 @@ var statementCase = []completionTestCase{
 			"Code2",
 		},
 	},
+	{
+		name:  "columns in statement with variable",
+		input: "SELECT c. FROM city AS c WHERE c.ID = @id",
+		line:  0,
+		col:   9,
+		want: []string{
+			"ID",
+			"Name",
+			"CountryCode",
+			"District",
+			"Population",
+		},
+	},
 }
 
 var selectExprCase = []completionTestCase{
@@ -662,6 +676,136 @@ var whereCondition = []completionTestCase{
 	},
 }
 
+var joinConditionCase = []completionTestCase{
+	{
+		name:  "aliased tables",
+		input: "select * from city as c left join country as co on ",
+		line:  0,
+		col:   51,
+		want: []string{
+			"co.Code = c.CountryCode",
+			"Code",
+		},
+	},
+	{
+		name:  "referencing table joined",
+		input: "select * from country join city on ",
+		line:  0,
+		col:   35,
+		want: []string{
+			"city.CountryCode = country.Code",
+		},
+	},
+	{
+		name:  "last join",
+		input: "select * from city c join country co on co.Code = c.CountryCode join countrylanguage cl on ",
+		line:  0,
+		col:   91,
+		want: []string{
+			"cl.CountryCode = co.Code",
+		},
+		bad: []string{
+			"co.Code = c.CountryCode",
+		},
+	},
+	{
+		name:  "filterd",
+		input: "select * from city c join country co on co",
+		line:  0,
+		col:   42,
+		want: []string{
+			"co.Code = c.CountryCode",
+		},
+	},
+	{
+		name:  "inferred without foreign keys",
+		input: "select * from city c join countrylanguage cl on ",
+		line:  0,
+		col:   48,
+		want: []string{
+			"cl.CountryCode = c.CountryCode",
+		},
+		bad: []string{
+			"cl.Percentage = c.Population",
+		},
+	},
+	{
+		name:  "not in where",
+		input: "select * from city c join country co on co.Code = c.CountryCode where ",
+		line:  0,
+		col:   70,
+		bad: []string{
+			"co.Code = c.CountryCode",
+		},
+	},
+}
+
+var viewAndRoutineCase = []completionTestCase{
+	{
+		name:  "from views",
+		input: "select * from ",
+		line:  0,
+		col:   14,
+		want: []string{
+			"city",
+			"citylist",
+		},
+	},
+	{
+		name:  "schema views",
+		input: "select * from world.",
+		line:  0,
+		col:   20,
+		want: []string{
+			"citylist",
+		},
+	},
+	{
+		name:  "view columns",
+		input: "select  from citylist",
+		line:  0,
+		col:   7,
+		want: []string{
+			"Name",
+			"CountryName",
+		},
+	},
+	{
+		name:  "stored functions",
+		input: "select  from city",
+		line:  0,
+		col:   7,
+		want: []string{
+			"city_count",
+		},
+		bad: []string{
+			"refresh_citylist",
+		},
+	},
+	{
+		name:  "view columns in insert columns",
+		input: "insert into citylist (",
+		line:  0,
+		col:   22,
+		want: []string{
+			"Name",
+			"CountryName",
+		},
+	},
+	{
+		name:  "no views in insert columns",
+		input: "insert into city (",
+		line:  0,
+		col:   18,
+		want: []string{
+			"CountryCode",
+		},
+		bad: []string{
+			"citylist",
+		},
+	},
+}
+
 var colNameCase = []completionTestCase{
 	{
 		name:  "ORDER BY columns",
@@ -862,12 +1006,14 @@ func TestCompleteMain(t *testing.T) {
 	tx.addWorkspaceConfig(t, cfg)
 
 	testcaseMap := map[string][]completionTestCase{
-		"statement":       statementCase,
-		"select expr":     selectExprCase,
-		"table reference": tableReferenceCase,
-		"col name":        colNameCase,
-		"case value":      caseValueCase,
-		"subquery":        subQueryCase,
+		"statement":        statementCase,
+		"select expr":      selectExprCase,
+		"table reference":  tableReferenceCase,
+		"col name":         colNameCase,
+		"case value":       caseValueCase,
+		"subquery":         subQueryCase,
+		"join condition":   joinConditionCase,
+		"view and routine": viewAndRoutineCase,
 	}
 
 	for k, v := range testcaseMap {
@@ -912,12 +1058,14 @@ func TestCompleteNoneDBConnection(t *testing.T) {
 	tx.addWorkspaceConfig(t, cfg)
 
 	testcaseMap := map[string][]completionTestCase{
-		"statement":       statementCase,
-		"select expr":     selectExprCase,
-		"table reference": tableReferenceCase,
-		"col name":        colNameCase,
-		"case value":      caseValueCase,
-		"subquery":        subQueryCase,
+		"statement":        statementCase,
+		"select expr":      selectExprCase,
+		"table reference":  tableReferenceCase,
+		"col name":         colNameCase,
+		"case value":       caseValueCase,
+		"subquery":         subQueryCase,
+		"join condition":   joinConditionCase,
+		"view and routine": viewAndRoutineCase,
 	}
 
 	for k, v := range testcaseMap {
@@ -951,6 +1099,195 @@ func TestCompleteNoneDBConnection(t *testing.T) {
 	}
 }
 
+func TestCompletionItemResolve(t *testing.T) {
+	tx := newTestContext()
+	tx.initServer(t)
+	defer tx.tearDown()
+
+	cfg := &config.Config{
+		Connections: []*database.DBConfig{
+			{Driver: "mock"},
+		},
+	}
+	tx.addWorkspaceConfig(t, cfg)
+
+	tx.textDocumentDidOpen(t, testFileURI, "SELECT  FROM countrylanguage")
+	commpletionParams := lsp.CompletionParams{
+		TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{
+				URI: testFileURI,
+			},
+			Position: lsp.Position{
+				Line:      0,
+				Character: 7,
+			},
+		},
+	}
+	var items []lsp.CompletionItem
+	if err := tx.conn.Call(tx.ctx, "textDocument/completion", commpletionParams, &items); err != nil {
+		t.Fatal("conn.Call textDocument/completion:", err)
+	}
+	find := func(label string) lsp.CompletionItem {
+		t.Helper()
+		for _, item := range items {
+			if item.Label == label {
+				if item.Documentation.Value != "" {
+					t.Errorf("documentation of %q must be resolved lazily, got %q", label, item.Documentation.Value)
+				}
+				return item
+			}
+		}
+		t.Fatalf("expected to be included in the results, expect candidate %q", label)
+		return lsp.CompletionItem{}
+	}
+
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{
+			label: "IsOfficial",
+			want:  "countrylanguage.IsOfficial column\n\nenum('T','F') F\n\nT if the language is official in the country\n",
+		},
+		{
+			label: "countrylanguage",
+			want:  "countrylanguage table\n\nLanguages spoken in each country\n\n",
+		},
+		{
+			label: "city_count",
+			want:  "city_count function",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			var got lsp.CompletionItem
+			if err := tx.conn.Call(tx.ctx, "completionItem/resolve", find(tt.label), &got); err != nil {
+				t.Fatal("conn.Call completionItem/resolve:", err)
+			}
+			if got.Documentation.Kind != lsp.Markdown || !strings.HasPrefix(got.Documentation.Value, tt.want) {
+				t.Errorf("unmatch documentation, want prefix %q, got %q", tt.want, got.Documentation.Value)
+			}
+		})
+	}
+}
+
+func TestCompleteSnippets(t *testing.T) {
+	tx := newTestContext()
+	tx.initServer(t)
+	defer tx.tearDown()
+
+	cfg := &config.Config{
+		Snippets: []*config.Snippet{
+			{Prefix: "cnt", Body: "SELECT COUNT(*) FROM {table}$0"},
+			{Prefix: "now", Body: "CURRENT_TIMESTAMP", Description: "current time"},
+		},
+		Connections: []*database.DBConfig{
+			{Driver: "mock"},
+		},
+	}
+	complete := func(t *testing.T, input string) []lsp.CompletionItem {
+		t.Helper()
+		tx.textDocumentDidOpen(t, testFileURI, input)
+		commpletionParams := lsp.CompletionParams{
+			TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+				TextDocument: lsp.TextDocumentIdentifier{
+					URI: testFileURI,
+				},
+				Position: lsp.Position{
+					Line:      0,
+					Character: len(input),
+				},
+			},
+		}
+		var got []lsp.CompletionItem
+		if err := tx.conn.Call(tx.ctx, "textDocument/completion", commpletionParams, &got); err != nil {
+			t.Fatal("conn.Call textDocument/completion:", err)
+		}
+		return got
+	}
+
+	params := lsp.InitializeParams{}
+	params.Capabilities.TextDocument.Completion.CompletionItem.SnippetSupport = true
+	if err := tx.conn.Call(tx.ctx, "initialize", params, nil); err != nil {
+		t.Fatal("conn.Call initialize:", err)
+	}
+	tx.addWorkspaceConfig(t, cfg)
+
+	tests := []struct {
+		name  string
+		input string
+		label string
+		want  string
+	}{
+		{
+			name:  "insert",
+			input: "insci",
+			label: "inscity",
+			want:  "INSERT INTO city (ID, Name, CountryCode, District, Population) VALUES (${1:ID}, ${2:Name}, ${3:CountryCode}, ${4:District}, ${5:Population})$0",
+		},
+		{
+			name:  "select",
+			input: "selcity",
+			label: "selcity",
+			want:  "SELECT ID, Name, CountryCode, District, Population FROM city$0",
+		},
+		{
+			name:  "update",
+			input: "updcty",
+			label: "updcity",
+			want:  "UPDATE city SET Name = ${1:Name}, CountryCode = ${2:CountryCode}, District = ${3:District}, Population = ${4:Population} WHERE ID = ${5:ID}$0",
+		},
+		{
+			name:  "join",
+			input: "SELECT * FROM country joinci",
+			label: "joincity",
+			want:  "JOIN city ON city.${1:ID} = ${2}$0",
+		},
+		{
+			name:  "user snippet of tables",
+			input: "cntcity",
+			label: "cntcity",
+			want:  "SELECT COUNT(*) FROM city$0",
+		},
+		{
+			name:  "user snippet",
+			input: "SELECT no",
+			label: "now",
+			want:  "CURRENT_TIMESTAMP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, item := range complete(t, tt.input) {
+				if item.Label != tt.label {
+					continue
+				}
+				if item.Kind != lsp.SnippetCompletion || item.InsertTextFormat != lsp.SnippetTextFormat {
+					t.Errorf("%q must be a snippet, got kind %d and format %d", tt.label, item.Kind, item.InsertTextFormat)
+				}
+				if item.InsertText != tt.want {
+					t.Errorf("unmatch snippet, want %q, got %q", tt.want, item.InsertText)
+				}
+				return
+			}
+			t.Errorf("expected to be included in the results, expect candidate %q", tt.label)
+		})
+	}
+
+	for _, item := range complete(t, "joinci") {
+		if item.Label == "joincity" {
+			t.Error("JOIN templates must not be offered at the head of a statement")
+		}
+	}
+
+	tx.server.clientCapabilities = lsp.ClientCapabilities{}
+	for _, item := range complete(t, "inscity") {
+		if item.Kind == lsp.SnippetCompletion {
+			t.Errorf("snippets must not be offered unless the client supports them, got %q", item.Label)
+		}
+	}
+}
+
 func testCompletionItem(t *testing.T, expectLabels []string, badLabels []string, gotItems []lsp.CompletionItem) {
 	t.Helper()
 
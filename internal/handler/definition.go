@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lighttiger2505/sqls/ast"
+	"github.com/lighttiger2505/sqls/ast/astutil"
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+	"github.com/lighttiger2505/sqls/parser"
+	"github.com/lighttiger2505/sqls/parser/parseutil"
+	"github.com/lighttiger2505/sqls/token"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+func (s *Server) handleTextDocumentDefinition(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (result interface{}, err error) {
+	if req.Params == nil {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams}
+	}
+
+	var params lsp.DefinitionParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+
+	f, ok := s.files[params.TextDocument.URI]
+	if !ok {
+		return nil, fmt.Errorf("document not found: %s", params.TextDocument.URI)
+	}
+	if s.dbConn == nil {
+		return nil, nil
+	}
+
+	cols, ok, err := definitionTable(f.Text, params.Position, s.worker.Cache())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return []lsp.Location{
+		{
+			URI: s.tableDocumentURI(cols[0].Schema, cols[0].Table),
+		},
+	}, nil
+}
+
+// definitionTable returns the columns of the table named by the identifier at
+// the position.
+func definitionTable(text string, position lsp.Position, dbCache *database.DBCache) ([]*database.ColumnDesc, bool, error) {
+	if dbCache == nil {
+		return nil, false, nil
+	}
+
+	pos := token.Pos{
+		Line: position.Line,
+		Col:  position.Character + 1,
+	}
+	parsed, err := parser.Parse(text)
+	if err != nil {
+		return nil, false, err
+	}
+
+	nodeWalker := parseutil.NewNodeWalker(parsed, pos)
+	identMatcher := astutil.NodeMatcher{
+		NodeTypes: []ast.NodeType{
+			ast.TypeMemberIdentifer,
+			ast.TypeIdentifer,
+		},
+	}
+	focusedIdentNodes := nodeWalker.CurNodeMatches(identMatcher)
+	if len(focusedIdentNodes) == 0 {
+		return nil, false, nil
+	}
+	ident, memIdent := findIdent(focusedIdentNodes)
+	if ident == nil {
+		return nil, false, nil
+	}
+	env, err := collectEnvirontment(parsed, pos)
+	if err != nil {
+		return nil, false, err
+	}
+	ctx := getHoverTypes(nodeWalker, env)
+
+	identName := ident.NoQuateString()
+	switch {
+	case memIdent != nil && identName == memIdent.ParentTok.NoQuateString():
+		// The cursor is on the table of a column, "c[i]ty.Name"
+		if ctx.parent.Type != parentTypeTable {
+			return nil, false, nil
+		}
+		if realName, ok := env.getTableRealName(identName); ok {
+			identName = realName
+		}
+		cols, ok := dbCache.ColumnDescs(identName)
+		return cols, ok, nil
+	case memIdent != nil:
+		// The cursor is on the table of a schema, "world.c[i]ty"
+		if ctx.parent.Type != parentTypeSchema {
+			return nil, false, nil
+		}
+		cols, ok := dbCache.ColumnDatabase(memIdent.ParentTok.NoQuateString(), identName)
+		return cols, ok, nil
+	default:
+		if !hoverTypeIs(ctx.types, hoverTypeTable) {
+			return nil, false, nil
+		}
+		for _, table := range env.tables {
+			if table.Alias == identName {
+				identName = table.Name
+			}
+		}
+		cols, ok := dbCache.ColumnDescs(identName)
+		return cols, ok && len(cols) > 0, nil
+	}
+}
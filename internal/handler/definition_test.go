@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/internal/config"
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+)
+
+func TestDefinition(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	cfg := &config.Config{
+		Connections: []*database.DBConfig{
+			{Alias: "local world", Driver: "mock"},
+		},
+	}
+	tx.addWorkspaceConfig(t, cfg)
+
+	tests := []struct {
+		name  string
+		input string
+		line  int
+		col   int
+		want  []lsp.Location
+	}{
+		{
+			name:  "table",
+			input: "SELECT ID, Name FROM city",
+			line:  0,
+			col:   22,
+			want:  []lsp.Location{{URI: "sqls://local%20world/world/city.sql"}},
+		},
+		{
+			name:  "table alias of column",
+			input: "SELECT ci.Name FROM city AS ci",
+			line:  0,
+			col:   8,
+			want:  []lsp.Location{{URI: "sqls://local%20world/world/city.sql"}},
+		},
+		{
+			name:  "table with schema",
+			input: "SELECT ID FROM world.country",
+			line:  0,
+			col:   23,
+			want:  []lsp.Location{{URI: "sqls://local%20world/world/country.sql"}},
+		},
+		{
+			name:  "column",
+			input: "SELECT ID, Name FROM city",
+			line:  0,
+			col:   9,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx.textDocumentDidOpen(t, testFileURI, tt.input)
+
+			params := lsp.DefinitionParams{
+				TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+					TextDocument: lsp.TextDocumentIdentifier{
+						URI: testFileURI,
+					},
+					Position: lsp.Position{
+						Line:      tt.line,
+						Character: tt.col - 1,
+					},
+				},
+			}
+			var got []lsp.Location
+			if err := tx.conn.Call(tx.ctx, "textDocument/definition", params, &got); err != nil {
+				t.Fatal("conn.Call textDocument/definition:", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch locations (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVirtualTextDocument(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	cfg := &config.Config{
+		Connections: []*database.DBConfig{
+			{Alias: "local world", Driver: "mock"},
+		},
+	}
+	tx.addWorkspaceConfig(t, cfg)
+
+	want := "CREATE TABLE `city` (\n" +
+		"  `ID` int(11) NOT NULL AUTO_INCREMENT,\n" +
+		"  `Name` char(35) NOT NULL DEFAULT '',\n" +
+		"  `CountryCode` char(3) NOT NULL DEFAULT '',\n" +
+		"  `District` char(20) NOT NULL DEFAULT '',\n" +
+		"  `Population` int(11) NOT NULL DEFAULT '0',\n" +
+		"  PRIMARY KEY (`ID`),\n" +
+		"  KEY `CountryCode` (`CountryCode`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=latin1;\n"
+
+	var got string
+	params := lsp.VirtualTextDocumentParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "sqls://local%20world/world/city.sql"},
+	}
+	if err := tx.conn.Call(tx.ctx, "sqls/virtualTextDocument", params, &got); err != nil {
+		t.Fatal("conn.Call sqls/virtualTextDocument:", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unmatch document (- want, + got):\n%s", diff)
+	}
+
+	commandParams := lsp.ExecuteCommandParams{
+		Command:   CommandShowCreateTable,
+		Arguments: []interface{}{"world.city"},
+	}
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", commandParams, &got); err != nil {
+		t.Fatal("conn.Call workspace/executeCommand:", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unmatch command result (- want, + got):\n%s", diff)
+	}
+
+	params.TextDocument.URI = "sqls://production/world/city.sql"
+	if err := tx.conn.Call(tx.ctx, "sqls/virtualTextDocument", params, &got); err == nil {
+		t.Error("document of another connection must not be returned")
+	}
+}
+
+func Test_parseTableDocumentURI(t *testing.T) {
+	tests := []struct {
+		uri     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			uri:  "sqls://local%20world/world/city.sql",
+			want: []string{"local world", "world", "city"},
+		},
+		{
+			uri:  "sqls://chinook/albums.sql",
+			want: []string{"chinook", "", "albums"},
+		},
+		{
+			uri:     "file:///world/city.sql",
+			wantErr: true,
+		},
+		{
+			uri:     "sqls://local/a/b/city.sql",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			connection, schema, table, err := parseTableDocumentURI(tt.uri)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("parseTableDocumentURI() must fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, []string{connection, schema, table}); diff != "" {
+				t.Errorf("unmatch (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
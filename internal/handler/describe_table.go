@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+	"github.com/olekukonko/tablewriter"
+)
+
+func (s *Server) describeTable(ctx context.Context, params lsp.ExecuteCommandParams) (result interface{}, err error) {
+	if len(params.Arguments) == 0 {
+		return nil, fmt.Errorf("required arguments were not provided: <Table>")
+	}
+	name, ok := params.Arguments[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("specify the table as a string")
+	}
+	if s.dbConn == nil {
+		return nil, errors.New("database connection is not open")
+	}
+	cols, err := s.tableColumns(name)
+	if err != nil {
+		return nil, err
+	}
+	schema, table := splitTableName(name)
+	if schema == "" {
+		schema = cols[0].Schema
+	}
+
+	repo, err := s.newDBRepository(ctx)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := repo.TableIndexes(ctx, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	constraints, err := repo.TableConstraints(ctx, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	fks, err := repo.TableForeignKeys(ctx, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	return tableReport(cols, indexes, constraints, fks), nil
+}
+
+// tableReport renders the description of a table as a set of tables.
+func tableReport(cols []*database.ColumnDesc, indexes []*database.IndexDesc, constraints []*database.ConstraintDesc, fks []*database.ForeignKeyDesc) string {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "Table: %s\n\n", qualifiedName(cols[0].Schema, cols[0].Table))
+
+	colRows := [][]string{}
+	for _, col := range cols {
+		colRows = append(colRows, []string{
+			col.Name,
+			col.Type,
+			col.Null,
+			col.Key,
+			col.Default.String,
+			col.Extra,
+		})
+	}
+	writeReportSection(buf, "Columns", []string{"Name", "Type", "Null", "Key", "Default", "Extra"}, colRows)
+
+	indexRows := [][]string{}
+	for _, index := range indexes {
+		indexRows = append(indexRows, []string{
+			index.Name,
+			strings.Join(index.Columns, ", "),
+			yesNo(index.Unique),
+			yesNo(index.Primary),
+		})
+	}
+	writeReportSection(buf, "Indexes", []string{"Name", "Columns", "Unique", "Primary"}, indexRows)
+
+	constraintRows := [][]string{}
+	for _, constraint := range constraints {
+		constraintRows = append(constraintRows, []string{
+			constraint.Name,
+			constraint.Type,
+			constraint.Definition,
+		})
+	}
+	writeReportSection(buf, "Constraints", []string{"Name", "Type", "Definition"}, constraintRows)
+
+	fkRows := [][]string{}
+	for _, fk := range fks {
+		fkRows = append(fkRows, []string{
+			fk.Name,
+			strings.Join(fk.Columns, ", "),
+			fmt.Sprintf("%s(%s)", qualifiedName(fk.RefSchema, fk.RefTable), strings.Join(fk.RefColumns, ", ")),
+			fk.OnUpdate,
+			fk.OnDelete,
+		})
+	}
+	writeReportSection(buf, "Foreign Keys", []string{"Name", "Columns", "References", "On Update", "On Delete"}, fkRows)
+	return buf.String()
+}
+
+func writeReportSection(buf *bytes.Buffer, title string, header []string, rows [][]string) {
+	fmt.Fprintln(buf, title)
+	if len(rows) == 0 {
+		fmt.Fprintln(buf, "(none)")
+	} else {
+		table := tablewriter.NewWriter(buf)
+		table.SetHeader(header)
+		table.SetAutoWrapText(false)
+		table.AppendBulk(rows)
+		table.Render()
+	}
+	fmt.Fprintln(buf, "")
+}
+
+func qualifiedName(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+	return schema + "." + name
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "YES"
+	}
+	return "NO"
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/internal/config"
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+)
+
+func Test_describeTable(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	tx.addWorkspaceConfig(t, &config.Config{
+		Connections: []*database.DBConfig{
+			{Driver: "mock"},
+		},
+	})
+
+	params := lsp.ExecuteCommandParams{
+		Command:   CommandDescribeTable,
+		Arguments: []interface{}{"city"},
+	}
+	var got string
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err != nil {
+		t.Fatal("conn.Call workspace/executeCommand:", err)
+	}
+	want := `Table: world.city
+
+Columns
++-------------+----------+------+-----+---------+----------------+
+|    NAME     |   TYPE   | NULL | KEY | DEFAULT |     EXTRA      |
++-------------+----------+------+-----+---------+----------------+
+| ID          | int(11)  | NO   | PRI | <null>  | auto_increment |
+| Name        | char(35) | NO   |     |         |                |
+| CountryCode | char(3)  | NO   | MUL |         |                |
+| District    | char(20) | NO   |     |         |                |
+| Population  | int(11)  | NO   |     |         |                |
++-------------+----------+------+-----+---------+----------------+
+
+Indexes
++-------------+-------------+--------+---------+
+|    NAME     |   COLUMNS   | UNIQUE | PRIMARY |
++-------------+-------------+--------+---------+
+| PRIMARY     | ID          | YES    | YES     |
+| CountryCode | CountryCode | NO     | NO      |
++-------------+-------------+--------+---------+
+
+Constraints
++------------+-------+---------------------------+
+|    NAME    | TYPE  |        DEFINITION         |
++------------+-------+---------------------------+
+| city_chk_1 | CHECK | CHECK (` + "`Population`" + ` >= 0) |
++------------+-------+---------------------------+
+
+Foreign Keys
++-------------+-------------+---------------------+-----------+-----------+
+|    NAME     |   COLUMNS   |     REFERENCES      | ON UPDATE | ON DELETE |
++-------------+-------------+---------------------+-----------+-----------+
+| city_ibfk_1 | CountryCode | world.country(Code) | RESTRICT  | RESTRICT  |
++-------------+-------------+---------------------+-----------+-----------+
+
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unmatch report (- want, + got):\n%s", diff)
+	}
+
+	params.Arguments = []interface{}{"world.town"}
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err == nil {
+		t.Error("describeTable of an unknown table must fail")
+	}
+}
@@ -8,13 +8,20 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lighttiger2505/sqls/ast"
+	"github.com/lighttiger2505/sqls/ast/astutil"
+	"github.com/lighttiger2505/sqls/dialect"
 	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/history"
 	"github.com/lighttiger2505/sqls/internal/lsp"
 	"github.com/lighttiger2505/sqls/parser"
+	"github.com/lighttiger2505/sqls/parser/parseutil"
+	"github.com/lighttiger2505/sqls/token"
 	"github.com/olekukonko/tablewriter"
 	"github.com/sourcegraph/jsonrpc2"
 	"golang.org/x/xerrors"
@@ -27,6 +34,17 @@ const (
 	CommandShowConnections  = "showConnections"
 	CommandSwitchDatabase   = "switchDatabase"
 	CommandSwitchConnection = "switchConnections"
+	CommandFetchMoreRows    = "fetchMoreRows"
+	CommandBeginTransaction = "beginTransaction"
+	CommandCommit           = "commit"
+	CommandRollback         = "rollback"
+	CommandShowHistory      = "showHistory"
+	CommandRerunHistory     = "rerunHistory"
+	CommandExportQuery      = "exportQuery"
+	CommandImportCSV        = "importCSV"
+	CommandShowCreateTable  = "showCreateTable"
+	CommandDescribeTable    = "describeTable"
+	CommandPreviewTable     = "previewTable"
 )
 
 func (h *Server) handleTextDocumentCodeAction(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (result interface{}, err error) {
@@ -60,6 +78,16 @@ func (h *Server) handleTextDocumentCodeAction(ctx context.Context, conn *jsonrpc
 			Command:   CommandShowConnections,
 			Arguments: []interface{}{},
 		},
+		{
+			Title:     "Show History",
+			Command:   CommandShowHistory,
+			Arguments: []interface{}{},
+		},
+		{
+			Title:     "Rerun History",
+			Command:   CommandRerunHistory,
+			Arguments: []interface{}{},
+		},
 		{
 			Title:     "Switch Database",
 			Command:   CommandSwitchDatabase,
@@ -71,6 +99,33 @@ func (h *Server) handleTextDocumentCodeAction(ctx context.Context, conn *jsonrpc
 			Arguments: []interface{}{},
 		},
 	}
+	if h.cursor != nil {
+		commands = append(commands, lsp.Command{
+			Title:     "Fetch More Rows",
+			Command:   CommandFetchMoreRows,
+			Arguments: []interface{}{},
+		})
+	}
+	if h.inTransaction() {
+		commands = append(commands,
+			lsp.Command{
+				Title:     "Commit",
+				Command:   CommandCommit,
+				Arguments: []interface{}{},
+			},
+			lsp.Command{
+				Title:     "Rollback",
+				Command:   CommandRollback,
+				Arguments: []interface{}{},
+			},
+		)
+	} else {
+		commands = append(commands, lsp.Command{
+			Title:     "Begin Transaction",
+			Command:   CommandBeginTransaction,
+			Arguments: []interface{}{},
+		})
+	}
 	return commands, nil
 }
 
@@ -86,7 +141,9 @@ func (s *Server) handleWorkspaceExecuteCommand(ctx context.Context, conn *jsonrp
 
 	switch params.Command {
 	case CommandExecuteQuery:
-		return s.executeQuery(ctx, params)
+		return s.executeQuery(ctx, conn, params)
+	case CommandFetchMoreRows:
+		return s.fetchMoreRows(ctx, params)
 	case CommandShowDatabases:
 		return s.showDatabases(ctx, params)
 	case CommandShowSchemas:
@@ -97,11 +154,31 @@ func (s *Server) handleWorkspaceExecuteCommand(ctx context.Context, conn *jsonrp
 		return s.switchDatabase(ctx, params)
 	case CommandSwitchConnection:
 		return s.switchConnections(ctx, params)
+	case CommandBeginTransaction:
+		return s.beginTransaction(ctx, params)
+	case CommandCommit:
+		return s.commit(ctx, params)
+	case CommandRollback:
+		return s.rollback(ctx, params)
+	case CommandShowHistory:
+		return s.showHistory(ctx, params)
+	case CommandRerunHistory:
+		return s.rerunHistory(ctx, conn, params)
+	case CommandExportQuery:
+		return s.exportQuery(ctx, conn, params)
+	case CommandImportCSV:
+		return s.importCSV(ctx, conn, params)
+	case CommandShowCreateTable:
+		return s.showCreateTable(ctx, params)
+	case CommandDescribeTable:
+		return s.describeTable(ctx, params)
+	case CommandPreviewTable:
+		return s.previewTable(ctx, params)
 	}
 	return nil, fmt.Errorf("unsupported command: %v", params.Command)
 }
 
-func (s *Server) executeQuery(ctx context.Context, params lsp.ExecuteCommandParams) (result interface{}, err error) {
+func (s *Server) executeQuery(ctx context.Context, conn *jsonrpc2.Conn, params lsp.ExecuteCommandParams) (result interface{}, err error) {
 	// parse execute command arguments
 	if s.dbConn == nil {
 		return nil, errors.New("database connection is not open")
@@ -118,15 +195,7 @@ func (s *Server) executeQuery(ctx context.Context, params lsp.ExecuteCommandPara
 		return nil, fmt.Errorf("document not found, %q", uri)
 	}
 
-	showVertical := false
-	if len(params.Arguments) > 1 {
-		showVerticalFlag, ok := params.Arguments[1].(string)
-		if ok {
-			if showVerticalFlag == "-show-vertical" {
-				showVertical = true
-			}
-		}
-	}
+	format, values := parseExecuteOptions(params.Arguments[1:])
 
 	// extract target query
 	text := f.Text
@@ -139,36 +208,356 @@ func (s *Server) executeQuery(ctx context.Context, params lsp.ExecuteCommandPara
 			params.Range.End.Character,
 		)
 	}
-	stmts, err := getStatements(text)
+	return s.executeStatements(ctx, conn, params, text, format, values)
+}
+
+// outputFormat is how the rows of a query are shown.
+type outputFormat int
+
+const (
+	outputTable outputFormat = iota
+	outputVertical
+	outputInsert
+	outputUpdate
+)
+
+var outputFormatFlags = map[string]outputFormat{
+	"-show-vertical": outputVertical,
+	"-as-insert":     outputInsert,
+	"-as-update":     outputUpdate,
+}
+
+// parseExecuteOptions returns the options given after the target of an
+// execution, the output format flag and the map of placeholder values.
+func parseExecuteOptions(args []interface{}) (format outputFormat, values map[string]interface{}) {
+	values = map[string]interface{}{}
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			if f, ok := outputFormatFlags[v]; ok {
+				format = f
+			}
+		case map[string]interface{}:
+			values = v
+		}
+	}
+	return format, values
+}
+
+// executeStatements executes the statements in text one by one and returns
+// their results.
+func (s *Server) executeStatements(ctx context.Context, conn *jsonrpc2.Conn, params lsp.ExecuteCommandParams, text string, format outputFormat, values map[string]interface{}) (result interface{}, err error) {
+	stmts, err := getStatements(text, s.driver())
 	if err != nil {
 		return nil, err
 	}
 
-	// execute statements
-	buf := new(bytes.Buffer)
+	queries := []string{}
+	targetStmts := []*ast.Statement{}
 	for _, stmt := range stmts {
 		query := strings.TrimSpace(stmt.String())
 		if query == "" {
 			continue
 		}
+		queries = append(queries, query)
+		targetStmts = append(targetStmts, stmt)
+	}
+
+	// refuse statements that are not queries on a read-only connection
+	if err := s.guardReadOnly(queries); err != nil {
+		return nil, err
+	}
+
+	// confirm destructive statements before anything is executed
+	confirmed, err := s.guardDestructiveStatements(ctx, conn, targetStmts)
+	if err != nil {
+		return nil, err
+	}
+	if !confirmed {
+		return "Query canceled, destructive statements were not confirmed", nil
+	}
+
+	// bind values to the placeholders, prompting for the ones not given
+	stmtParams, entered, err := s.bindPlaceholders(ctx, conn, targetStmts, values)
+	if err != nil {
+		return nil, err
+	}
+	if !entered {
+		return "Query canceled, placeholder values were not entered", nil
+	}
 
+	// execute statements
+	progress := lsp.NewWorkDoneProgress(ctx, conn, params.WorkDoneToken, s.clientCapabilities.Window.WorkDoneProgress)
+	if err := progress.Begin(ctx, "Execute Query", fmt.Sprintf("statement 1 of %d", len(queries))); err != nil {
+		return nil, err
+	}
+	begin := time.Now()
+	buf := new(bytes.Buffer)
+	for i, query := range queries {
+		var stmtResult *statementResult
+		start := time.Now()
 		if _, isQuery := database.QueryExecType(query, ""); isQuery {
-			res, err := s.query(ctx, query, showVertical)
-			if err != nil {
-				return nil, err
-			}
-			fmt.Fprintln(buf, res)
+			stmtResult, err = s.query(ctx, query, format, stmtParams[i])
 		} else {
-			res, err := s.exec(ctx, query, showVertical)
-			if err != nil {
+			stmtResult, err = s.exec(ctx, query, stmtParams[i])
+		}
+		if err != nil {
+			progress.End(ctx, fmt.Sprintf("statement %d of %d failed", i+1, len(queries)))
+			return nil, err
+		}
+		s.recordHistory(query, start, stmtResult)
+		res := stmtResult.String()
+		if i == len(queries)-1 {
+			// the cursor of an earlier query is closed by the next statement
+			res = s.withPagingHint(res)
+		}
+
+		message := fmt.Sprintf("statement %d of %d done (%s elapsed)", i+1, len(queries), time.Since(begin).Round(time.Millisecond))
+		if err := progress.Report(ctx, message, (i+1)*100/len(queries)); err != nil {
+			return nil, err
+		}
+		if params.PartialResultToken != nil {
+			// Send the result of each statement as soon as it is ready, the final response is left empty
+			partialResult := &lsp.ProgressParams{
+				Token: params.PartialResultToken,
+				Value: res + "\n",
+			}
+			if err := conn.Notify(ctx, "$/progress", partialResult); err != nil {
 				return nil, err
 			}
-			fmt.Fprintln(buf, res)
+			continue
 		}
+		fmt.Fprintln(buf, res)
+	}
+	if err := progress.End(ctx, fmt.Sprintf("%d statements done in %s", len(queries), time.Since(begin).Round(time.Millisecond))); err != nil {
+		return nil, err
+	}
+	if s.inTransaction() && params.PartialResultToken == nil {
+		fmt.Fprintf(buf, "in transaction, run %q or %q to finish it", CommandCommit, CommandRollback)
+		fmt.Fprintln(buf, "")
 	}
 	return buf.String(), nil
 }
 
+const (
+	actionExecute = "Execute"
+	actionCancel  = "Cancel"
+)
+
+// driver returns the driver of the current connection, or an empty driver if
+// there is no connection.
+func (s *Server) driver() dialect.DatabaseDriver {
+	if s.dbConn == nil {
+		return ""
+	}
+	return s.dbConn.Driver
+}
+
+// guardReadOnly returns an error if the current connection is read only and
+// any of the queries is not a query.
+func (s *Server) guardReadOnly(queries []string) error {
+	if s.curDBCfg == nil || !s.curDBCfg.ReadOnly {
+		return nil
+	}
+	for _, query := range queries {
+		if typ, isQuery := database.QueryExecType(query, query); !isQuery {
+			return fmt.Errorf("%s statement refused, connection is read only", typ)
+		}
+	}
+	return nil
+}
+
+// guardDestructiveStatements reports whether the statements may be executed
+// according to the destructiveGuard setting of the current connection.
+func (s *Server) guardDestructiveStatements(ctx context.Context, conn *jsonrpc2.Conn, stmts []*ast.Statement) (bool, error) {
+	mode := s.curDBCfg.DestructiveGuard()
+	if mode == database.GuardModeOff {
+		return true, nil
+	}
+
+	destructives := []string{}
+	for _, stmt := range stmts {
+		if typ, ok := destructiveType(stmt); ok {
+			destructives = append(destructives, typ)
+		}
+	}
+	if len(destructives) == 0 {
+		return true, nil
+	}
+
+	message := fmt.Sprintf("destructive statements found: %s", strings.Join(destructives, ", "))
+	if mode == database.GuardModeBlock {
+		return false, fmt.Errorf("%s, blocked by destructiveGuard setting", message)
+	}
+	messenger := lsp.NewLspMessenger(conn)
+	item, err := messenger.ShowMessageRequest(ctx, lsp.Warning, message+". Execute anyway?", actionExecute, actionCancel)
+	if err != nil {
+		return false, err
+	}
+	return item != nil && item.Title == actionExecute, nil
+}
+
+// statementParams are the placeholder keys of a statement and the values
+// bound to them, with the query rewritten for the driver and its arguments.
+type statementParams struct {
+	keys   []string
+	values map[string]interface{}
+	query  string
+	bound  []interface{}
+}
+
+func (p *statementParams) args() []interface{} {
+	if p == nil {
+		return nil
+	}
+	return p.bound
+}
+
+// statement returns the query to send to the driver, which is the query as
+// written unless its placeholders were rewritten.
+func (p *statementParams) statement(query string) string {
+	if p == nil || p.query == "" {
+		return query
+	}
+	return p.query
+}
+
+// boundValues returns the values bound to the placeholders by key.
+func (p *statementParams) boundValues() map[string]interface{} {
+	if p == nil {
+		return nil
+	}
+	values := make(map[string]interface{}, len(p.keys))
+	for _, key := range p.keys {
+		values[key] = p.values[key]
+	}
+	return values
+}
+
+// bindPlaceholders returns the params of each statement. Values not found in
+// values are prompted with sqls/showInputRequest, and false is returned if the
+// user cancels the input.
+func (s *Server) bindPlaceholders(ctx context.Context, conn *jsonrpc2.Conn, stmts []*ast.Statement, values map[string]interface{}) ([]*statementParams, bool, error) {
+	stmtKeys := make([][]string, len(stmts))
+	for i, stmt := range stmts {
+		stmtKeys[i] = placeholderKeys(parseutil.ExtractPlaceholders(stmt))
+	}
+
+	messenger := lsp.NewLspMessenger(conn)
+	for _, keys := range stmtKeys {
+		for _, key := range keys {
+			if _, ok := values[key]; ok {
+				continue
+			}
+			value, err := messenger.ShowInputRequest(ctx, fmt.Sprintf("Value of %s", key))
+			if err != nil {
+				return nil, false, fmt.Errorf("value of placeholder %s is not given and cannot be prompted, %w", key, err)
+			}
+			if value == nil {
+				return nil, false, nil
+			}
+			values[key] = *value
+		}
+	}
+
+	stmtParams := make([]*statementParams, len(stmts))
+	for i, keys := range stmtKeys {
+		params := &statementParams{keys: keys, values: values}
+		if len(keys) > 0 {
+			params.query, params.bound = bindStatement(stmts[i], keys, values, s.driver())
+		}
+		stmtParams[i] = params
+	}
+	return stmtParams, true, nil
+}
+
+// placeholderKeys returns the keys used to look up the values of the
+// placeholders. Each anonymous ? is numbered in order of appearance as ?#1,
+// ?#2 and so on, which cannot clash with the explicit ?1 of SQLite3, other
+// placeholders are their own key.
+func placeholderKeys(placeholders []string) []string {
+	keys := make([]string, len(placeholders))
+	anonymous := 0
+	for i, placeholder := range placeholders {
+		if placeholder == "?" {
+			anonymous++
+			keys[i] = fmt.Sprintf("?#%d", anonymous)
+			continue
+		}
+		keys[i] = placeholder
+	}
+	return keys
+}
+
+// bindStatement rewrites the placeholders of the statement in place to the
+// positional ones of the driver, and returns the query and its arguments.
+// Named placeholders are bound by position as well, since the MySQL and
+// PostgreSQL drivers take no named arguments, and so that :name and @name
+// are bound to their own values.
+func bindStatement(stmt *ast.Statement, keys []string, values map[string]interface{}, driver dialect.DatabaseDriver) (string, []interface{}) {
+	placeholders, args := bindArgs(keys, values, driver)
+	for i, tok := range parseutil.ExtractPlaceholderTokens(stmt) {
+		tok.Value = placeholders[i]
+	}
+	return strings.TrimSpace(stmt.String()), args
+}
+
+// bindArgs returns the positional placeholders of the driver replacing the
+// placeholders with the keys, and the arguments bound to them. MySQL takes
+// one argument for each ?, other drivers take one argument for each key
+// referred by number, such as $1 for PostgreSQL and ?1 for SQLite3.
+func bindArgs(keys []string, values map[string]interface{}, driver dialect.DatabaseDriver) ([]string, []interface{}) {
+	placeholders := make([]string, len(keys))
+	args := []interface{}{}
+	positions := map[string]int{}
+	for i, key := range keys {
+		switch driver {
+		case dialect.DatabaseDriverMySQL, dialect.DatabaseDriverMySQL8, dialect.DatabaseDriverMySQL57, dialect.DatabaseDriverMySQL56:
+			args = append(args, values[key])
+			placeholders[i] = "?"
+			continue
+		}
+		n, ok := positions[key]
+		if !ok {
+			args = append(args, values[key])
+			n = len(args)
+			positions[key] = n
+		}
+		if driver == dialect.DatabaseDriverPostgreSQL {
+			placeholders[i] = "$" + strconv.Itoa(n)
+		} else {
+			placeholders[i] = "?" + strconv.Itoa(n)
+		}
+	}
+	return placeholders, args
+}
+
+var whereMatcher = astutil.NodeMatcher{
+	ExpectKeyword: []string{"WHERE"},
+}
+
+// destructiveType returns the type of the statement if it drops or truncates
+// an object, or deletes or updates rows without a WHERE clause.
+func destructiveType(stmt *ast.Statement) (string, bool) {
+	typ, isQuery := database.QueryExecType(strings.TrimSpace(stmt.String()), "")
+	if isQuery {
+		return "", false
+	}
+	switch {
+	case strings.HasPrefix(typ, "DROP"), strings.HasPrefix(typ, "TRUNCATE"):
+		return typ, true
+	case typ == "DELETE", typ == "UPDATE":
+		// only a WHERE clause of the statement itself counts, not one in a subquery
+		for _, node := range stmt.GetTokens() {
+			if whereMatcher.IsMatch(node) {
+				return "", false
+			}
+		}
+		return typ + " without WHERE", true
+	}
+	return "", false
+}
+
 func extractRangeText(text string, startLine, startChar, endLine, endChar int) string {
 	writer := bytes.NewBufferString("")
 	scanner := bufio.NewScanner(strings.NewReader(text))
@@ -196,24 +585,106 @@ func extractRangeText(text string, startLine, startChar, endLine, endChar int) s
 	return writer.String()
 }
 
-func (s *Server) query(ctx context.Context, query string, vertical bool) (string, error) {
-	repo, err := s.newDBRepository(ctx)
+// statementResult is the outcome of a statement executed by the user.
+type statementResult struct {
+	output   string
+	rowCount int64
+	// err is the error returned by the database, shown in place of the output
+	err error
+}
+
+func (r *statementResult) String() string {
+	if r.err != nil {
+		return r.err.Error()
+	}
+	return r.output
+}
+
+func (s *Server) query(ctx context.Context, query string, format outputFormat, params *statementParams) (*statementResult, error) {
+	session, err := s.getSession(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	rows, err := repo.Query(context.Background(), query)
+
+	// A new query discards the rows left over from the previous one
+	if err := s.closeCursor(); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	rows, err := session.Query(context.Background(), params.statement(query), params.args()...)
 	if err != nil {
-		return err.Error(), nil
+		result := &statementResult{err: s.sessionError(err, session.InTransaction())}
+		return result, s.recordAudit(query, params, start, result)
 	}
-	columns, err := database.Columns(rows)
+	cursor, err := database.NewRowCursor(rows)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	var output string
+	var rowCount int
+	if format == outputInsert || format == outputUpdate {
+		statements, err := s.newRowStatements(query, cursor.Columns(), cursor.ColumnTypes(), format == outputUpdate)
+		if err != nil {
+			cursor.Close()
+			return nil, err
+		}
+		output, rowCount, err = s.fetchStatements(cursor, statements)
+	} else {
+		output, rowCount, err = s.fetchRows(cursor, format == outputVertical)
 	}
-	stringRows, err := database.ScanRows(rows, len(columns))
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	result := &statementResult{output: output, rowCount: int64(rowCount)}
+	return result, s.recordAudit(query, params, start, result)
+}
+
+func (s *Server) fetchMoreRows(ctx context.Context, params lsp.ExecuteCommandParams) (result interface{}, err error) {
+	if s.cursor == nil {
+		return nil, errors.New("there are no more rows to fetch")
+	}
+
+	showVertical := false
+	if len(params.Arguments) > 0 {
+		showVerticalFlag, ok := params.Arguments[0].(string)
+		if ok {
+			if showVerticalFlag == "-show-vertical" {
+				showVertical = true
+			}
+		}
 	}
 
+	cursor := s.cursor
+	s.cursor = nil
+	if statements := s.cursorStatements; statements != nil {
+		s.cursorStatements = nil
+		output, _, err := s.fetchStatements(cursor, statements)
+		if err != nil {
+			return nil, err
+		}
+		return s.withPagingHint(output), nil
+	}
+	output, _, err := s.fetchRows(cursor, showVertical)
+	if err != nil {
+		return nil, err
+	}
+	return s.withPagingHint(output), nil
+}
+
+// fetchRows renders the next batch of rows and returns it with the number of
+// rows fetched.
+func (s *Server) fetchRows(cursor *database.RowCursor, vertical bool) (string, int, error) {
+	stringRows, hasMore, err := cursor.Fetch(s.getConfig().QueryRowLimit())
+	if err != nil {
+		return "", 0, err
+	}
+	if hasMore {
+		// Hold the cursor so that the next batch can be fetched on demand
+		s.cursor = cursor
+	}
+
+	columns := cursor.Columns()
 	buf := new(bytes.Buffer)
 	if vertical {
 		table := newVerticalTableWriter(buf)
@@ -233,28 +704,245 @@ func (s *Server) query(ctx context.Context, query string, vertical bool) (string
 	fmt.Fprintf(buf, "%d rows in set", len(stringRows))
 	fmt.Fprintln(buf, "")
 	fmt.Fprintln(buf, "")
-	return buf.String(), nil
+	return buf.String(), len(stringRows), nil
 }
 
-func (s *Server) exec(ctx context.Context, query string, vertical bool) (string, error) {
-	repo, err := s.newDBRepository(ctx)
+// rowStatements renders rows of a query on a single table as INSERT or
+// UPDATE statements.
+type rowStatements struct {
+	generator *database.RowStatementGenerator
+	update    bool
+}
+
+// newRowStatements maps the result columns to the columns of the queried
+// table. Result columns that are not columns of the table, such as computed
+// or aliased ones, are refused when the table is known to the cache.
+func (s *Server) newRowStatements(query string, columns, types []string, update bool) (*rowStatements, error) {
+	parsed, err := parser.Parse(query)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	tables, err := parseutil.ExtractTable(parsed, token.Pos{Line: 0, Col: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(tables) != 1 || tables[0].Name == "" {
+		return nil, errors.New("rows can be turned into statements only for a query on a single table")
+	}
+	table := tables[0]
+
+	var tableCols []*database.ColumnDesc
+	if dbCache := s.worker.Cache(); dbCache != nil {
+		if table.DatabaseSchema == "" {
+			tableCols, _ = dbCache.ColumnDescs(table.Name)
+		} else {
+			tableCols, _ = dbCache.ColumnDatabase(table.DatabaseSchema, table.Name)
+		}
 	}
-	result, err := repo.Exec(context.Background(), query)
+	descs := make([]*database.ColumnDesc, len(columns))
+	hasPrimaryKey := false
+	for i, col := range columns {
+		for _, tableCol := range tableCols {
+			if strings.EqualFold(tableCol.Name, col) {
+				descs[i] = tableCol
+				hasPrimaryKey = hasPrimaryKey || tableCol.IsPrimaryKey()
+				break
+			}
+		}
+	}
+	if len(tableCols) > 0 {
+		unmapped := []string{}
+		for i, desc := range descs {
+			if desc == nil {
+				unmapped = append(unmapped, columns[i])
+			}
+		}
+		if len(unmapped) > 0 {
+			return nil, fmt.Errorf("cannot generate statements for %s, the result columns are not columns of the table: %s", table.Name, strings.Join(unmapped, ", "))
+		}
+	}
+	if update && !hasPrimaryKey {
+		return nil, xerrors.Errorf("cannot generate UPDATE statements for %s, %+v", table.Name, database.ErrNoPrimaryKey)
+	}
+
+	return &rowStatements{
+		generator: &database.RowStatementGenerator{
+			Driver:  s.dbConn.Driver,
+			Schema:  table.DatabaseSchema,
+			Table:   table.Name,
+			Columns: columns,
+			Descs:   descs,
+			Types:   types,
+		},
+		update: update,
+	}, nil
+}
+
+// fetchStatements renders the next batch of rows as statements and returns
+// it with the number of rows fetched.
+func (s *Server) fetchStatements(cursor *database.RowCursor, statements *rowStatements) (string, int, error) {
+	valueRows, hasMore, err := cursor.FetchValues(s.getConfig().QueryRowLimit())
 	if err != nil {
-		return err.Error(), nil
+		return "", 0, err
+	}
+
+	buf := new(bytes.Buffer)
+	for _, row := range valueRows {
+		if !statements.update {
+			fmt.Fprintln(buf, statements.generator.Insert(row))
+			continue
+		}
+		stmt, err := statements.generator.Update(row)
+		if err != nil {
+			cursor.Close()
+			return "", 0, err
+		}
+		fmt.Fprintln(buf, stmt)
+	}
+	if hasMore {
+		s.cursor = cursor
+		s.cursorStatements = statements
+	}
+	fmt.Fprintf(buf, "-- %d rows in set", len(valueRows))
+	fmt.Fprintln(buf, "")
+	fmt.Fprintln(buf, "")
+	return buf.String(), len(valueRows), nil
+}
+
+// withPagingHint adds to the output of a batch of rows the marker of the
+// rows left in the held cursor, if any. database/sql cannot count the rows of
+// a result without reading them, so the marker is open-ended and states the
+// size of the next page instead.
+func (s *Server) withPagingHint(output string) string {
+	if s.cursor == nil {
+		return output
+	}
+	prefix := ""
+	if s.cursorStatements != nil {
+		prefix = "-- "
+	}
+	return fmt.Sprintf(
+		"%s\n%s... more rows after row %d, run %q to fetch the next %d\n\n",
+		strings.TrimSuffix(output, "\n\n"),
+		prefix,
+		s.cursor.Fetched(),
+		CommandFetchMoreRows,
+		s.getConfig().QueryRowLimit(),
+	)
+}
+
+func (s *Server) closeCursor() error {
+	cursor := s.cursor
+	s.cursor = nil
+	s.cursorStatements = nil
+	return cursor.Close()
+}
+
+func (s *Server) exec(ctx context.Context, query string, params *statementParams) (*statementResult, error) {
+	session, err := s.getSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The session connection cannot execute while rows are left unread
+	if err := s.closeCursor(); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	result, err := session.Exec(context.Background(), params.statement(query), params.args()...)
+	if err != nil {
+		stmtResult := &statementResult{err: s.sessionError(err, session.InTransaction())}
+		return stmtResult, s.recordAudit(query, params, start, stmtResult)
 	}
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	buf := new(bytes.Buffer)
 	fmt.Fprintf(buf, "Query OK, %d row affected", rowsAffected)
 	fmt.Fprintln(buf, "")
 	fmt.Fprintln(buf, "")
-	return buf.String(), nil
+	stmtResult := &statementResult{output: buf.String(), rowCount: rowsAffected}
+	return stmtResult, s.recordAudit(query, params, start, stmtResult)
+}
+
+// recordAudit writes the statement to the audit log of the connection, if
+// any. Failing to audit is reported as an error of the execution.
+func (s *Server) recordAudit(query string, params *statementParams, start time.Time, result *statementResult) error {
+	if s.auditLogger == nil {
+		return nil
+	}
+	if err := s.auditLogger.Record(s.curDBCfg.Alias, query, params.boundValues(), start, result.rowCount, result.err); err != nil {
+		return xerrors.Errorf("failed to write audit log, %+v", err)
+	}
+	return nil
+}
+
+func (s *Server) beginTransaction(ctx context.Context, params lsp.ExecuteCommandParams) (result interface{}, err error) {
+	session, err := s.getSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.closeCursor(); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	if err := session.Begin(context.Background()); err != nil {
+		err = s.sessionError(err, false)
+		return nil, s.recordTransactionAudit("BEGIN", start, err)
+	}
+	if err := s.recordTransactionAudit("BEGIN", start, nil); err != nil {
+		return nil, err
+	}
+	return "transaction started", nil
+}
+
+func (s *Server) commit(ctx context.Context, params lsp.ExecuteCommandParams) (result interface{}, err error) {
+	if !s.inTransaction() {
+		return nil, database.ErrNoTransaction
+	}
+	if err := s.closeCursor(); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	if err := s.session.Commit(); err != nil {
+		err = s.sessionError(err, true)
+		return nil, s.recordTransactionAudit("COMMIT", start, err)
+	}
+	if err := s.recordTransactionAudit("COMMIT", start, nil); err != nil {
+		return nil, err
+	}
+	return "transaction committed", nil
+}
+
+func (s *Server) rollback(ctx context.Context, params lsp.ExecuteCommandParams) (result interface{}, err error) {
+	if !s.inTransaction() {
+		return nil, database.ErrNoTransaction
+	}
+	if err := s.closeCursor(); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	if err := s.session.Rollback(); err != nil {
+		err = s.sessionError(err, true)
+		return nil, s.recordTransactionAudit("ROLLBACK", start, err)
+	}
+	if err := s.recordTransactionAudit("ROLLBACK", start, nil); err != nil {
+		return nil, err
+	}
+	return "transaction rolled back", nil
+}
+
+// recordTransactionAudit writes the statement controlling the transaction,
+// such as COMMIT, to the audit log, and returns the error of the statement
+// unless the audit log cannot be written.
+func (s *Server) recordTransactionAudit(statement string, start time.Time, stmtErr error) error {
+	if err := s.recordAudit(statement, nil, start, &statementResult{err: stmtErr}); err != nil {
+		return err
+	}
+	return stmtErr
 }
 
 func (s *Server) showDatabases(ctx context.Context, params lsp.ExecuteCommandParams) (result interface{}, err error) {
@@ -322,6 +1010,100 @@ func (s *Server) showConnections(ctx context.Context, params lsp.ExecuteCommandP
 	return strings.Join(results, "\n"), nil
 }
 
+// historyShowLimit is the maximum number of entries shown by showHistory.
+const historyShowLimit = 100
+
+func (s *Server) recordHistory(query string, start time.Time, result *statementResult) {
+	entry := &history.Entry{
+		Alias:    s.curDBCfg.Alias,
+		DBName:   s.curDBCfg.DBName,
+		Query:    query,
+		Time:     start,
+		Duration: time.Since(start),
+		RowCount: result.rowCount,
+	}
+	if result.err != nil {
+		entry.Error = result.err.Error()
+	}
+	if err := s.history.Append(entry); err != nil {
+		log.Println("failed to record query history,", err)
+	}
+}
+
+// historyAlias returns the alias of the current connection, the history is
+// listed and completed per connection.
+func (s *Server) historyAlias() string {
+	if s.curDBCfg == nil {
+		return ""
+	}
+	return s.curDBCfg.Alias
+}
+
+func (s *Server) showHistory(ctx context.Context, params lsp.ExecuteCommandParams) (result interface{}, err error) {
+	var search string
+	if len(params.Arguments) > 0 {
+		search, _ = params.Arguments[0].(string)
+	}
+	search = strings.ToLower(search)
+
+	entries, err := s.history.Entries(s.historyAlias())
+	if err != nil {
+		return nil, err
+	}
+	results := []string{}
+	for i := len(entries) - 1; i >= 0 && len(results) < historyShowLimit; i-- {
+		entry := entries[i]
+		if search != "" &&
+			!strings.Contains(strings.ToLower(entry.Query), search) &&
+			!strings.Contains(strings.ToLower(entry.DBName), search) {
+			continue
+		}
+		res := fmt.Sprintf(
+			"%d %s %s %s %s %d rows %s",
+			i+1,
+			entry.Time.Format("2006-01-02 15:04:05"),
+			entry.Alias,
+			entry.DBName,
+			entry.Duration.Round(time.Millisecond),
+			entry.RowCount,
+			strings.Join(strings.Fields(entry.Query), " "),
+		)
+		if entry.Error != "" {
+			res += ", error: " + entry.Error
+		}
+		results = append(results, res)
+	}
+	return strings.Join(results, "\n"), nil
+}
+
+func (s *Server) rerunHistory(ctx context.Context, conn *jsonrpc2.Conn, params lsp.ExecuteCommandParams) (result interface{}, err error) {
+	if s.dbConn == nil {
+		return nil, errors.New("database connection is not open")
+	}
+	if len(params.Arguments) == 0 {
+		return nil, fmt.Errorf("required arguments were not provided: <History Index>")
+	}
+	indexStr, ok := params.Arguments[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("specify the history index as a number")
+	}
+	index, err := strconv.Atoi(indexStr)
+	if err != nil {
+		return nil, fmt.Errorf("specify the history index as a number, %s", err)
+	}
+
+	entries, err := s.history.Entries(s.historyAlias())
+	if err != nil {
+		return nil, err
+	}
+	if index < 1 || index > len(entries) {
+		return nil, fmt.Errorf("not found history, index %d", index)
+	}
+
+	format, values := parseExecuteOptions(params.Arguments[1:])
+	return s.executeStatements(ctx, conn, params, entries[index-1].Query, format, values)
+}
+
 func (s *Server) switchConnections(ctx context.Context, params lsp.ExecuteCommandParams) (result interface{}, err error) {
 	if len(params.Arguments) != 1 {
 		return nil, fmt.Errorf("required arguments were not provided: <Connection Index>")
@@ -347,8 +1129,10 @@ func (s *Server) switchConnections(ctx context.Context, params lsp.ExecuteComman
 	return nil, nil
 }
 
-func getStatements(text string) ([]*ast.Statement, error) {
-	parsed, err := parser.Parse(text)
+// getStatements parses the text with the dialect of the driver, so that only
+// the placeholders of the driver are bound.
+func getStatements(text string, driver dialect.DatabaseDriver) ([]*ast.Statement, error) {
+	parsed, err := parser.ParseDialect(text, dialect.DatabaseDialect(driver))
 	if err != nil {
 		return nil, err
 	}
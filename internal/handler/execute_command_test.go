@@ -1,11 +1,21 @@
 package handler
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/lighttiger2505/sqls/dialect"
+	"github.com/lighttiger2505/sqls/internal/audit"
 	"github.com/lighttiger2505/sqls/internal/config"
 	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/history"
 	"github.com/lighttiger2505/sqls/internal/lsp"
+	"github.com/lighttiger2505/sqls/parser/parseutil"
 )
 
 func Test_executeQuery(t *testing.T) {
@@ -55,6 +65,646 @@ func Test_executeQuery(t *testing.T) {
 	// pass error
 }
 
+func Test_executeQueryProgress(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	cfg := &config.Config{
+		Connections: []*database.DBConfig{
+			{
+				Driver:         "sqlite3",
+				DataSourceName: "file:execute_query_progress?mode=memory&cache=shared",
+			},
+		},
+	}
+	tx.addWorkspaceConfig(t, cfg)
+
+	uri := "file:///test.sql"
+	text := "CREATE TABLE city (id INTEGER); INSERT INTO city VALUES (1), (2); SELECT id FROM city;"
+	tx.textDocumentDidOpen(t, uri, text)
+
+	executeCommandParams := lsp.ExecuteCommandParams{
+		WorkDoneProgressParams: lsp.WorkDoneProgressParams{
+			WorkDoneToken: "work-done",
+		},
+		PartialResultParams: lsp.PartialResultParams{
+			PartialResultToken: "partial-result",
+		},
+		Command:   CommandExecuteQuery,
+		Arguments: []interface{}{testFileURI},
+	}
+	var got string
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", executeCommandParams, &got); err != nil {
+		t.Fatal("conn.Call workspace/executeCommand:", err)
+	}
+	if got != "" {
+		t.Errorf("final result must be empty when partial results are sent, got %q", got)
+	}
+
+	workDone := []string{}
+	partialResults := []string{}
+	for _, req := range tx.client.received("$/progress") {
+		var params struct {
+			Token string          `json:"token"`
+			Value json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			t.Fatal(err)
+		}
+		switch params.Token {
+		case "work-done":
+			var value lsp.WorkDoneProgressReport
+			if err := json.Unmarshal(params.Value, &value); err != nil {
+				t.Fatal(err)
+			}
+			workDone = append(workDone, value.Kind)
+		case "partial-result":
+			var value string
+			if err := json.Unmarshal(params.Value, &value); err != nil {
+				t.Fatal(err)
+			}
+			partialResults = append(partialResults, value)
+		}
+	}
+
+	wantWorkDone := []string{"begin", "report", "report", "report", "end"}
+	if diff := cmp.Diff(wantWorkDone, workDone); diff != "" {
+		t.Errorf("unmatch work done progress (- want, + got):\n%s", diff)
+	}
+	wantPartialResults := []string{
+		"Query OK, 0 row affected\n\n\n",
+		"Query OK, 2 row affected\n\n\n",
+		"+----+\n| ID |\n+----+\n|  1 |\n|  2 |\n+----+\n2 rows in set\n\n\n",
+	}
+	if diff := cmp.Diff(wantPartialResults, partialResults); diff != "" {
+		t.Errorf("unmatch partial results (- want, + got):\n%s", diff)
+	}
+}
+
+func Test_executeQueryDestructiveGuard(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	tests := []struct {
+		name  string
+		mode  database.GuardMode
+		reply interface{}
+		want  string
+	}{
+		{
+			name: "off",
+			mode: database.GuardModeOff,
+			want: "Query OK, 0 row affected\n\n\nQuery OK, 0 row affected\n\n\n",
+		},
+		{
+			name:  "warn and execute",
+			mode:  database.GuardModeWarn,
+			reply: lsp.MessageActionItem{Title: "Execute"},
+			want:  "Query OK, 0 row affected\n\n\nQuery OK, 0 row affected\n\n\n",
+		},
+		{
+			name:  "warn and cancel",
+			mode:  database.GuardModeWarn,
+			reply: lsp.MessageActionItem{Title: "Cancel"},
+			want:  "Query canceled, destructive statements were not confirmed",
+		},
+		{
+			name: "warn and dismiss",
+			mode: "",
+			want: "Query canceled, destructive statements were not confirmed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Connections: []*database.DBConfig{
+					{
+						Driver:         "sqlite3",
+						DataSourceName: "file:execute_query_destructive_guard?mode=memory&cache=shared",
+						GuardMode:      tt.mode,
+					},
+				},
+			}
+			tx.server.WSCfg = cfg
+			if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+				t.Fatal(err)
+			}
+			tx.client.setReply("window/showMessageRequest", tt.reply)
+			tx.textDocumentDidOpen(t, testFileURI, "CREATE TEMP TABLE IF NOT EXISTS city (id INTEGER); DELETE FROM city;")
+
+			params := lsp.ExecuteCommandParams{
+				Command:   CommandExecuteQuery,
+				Arguments: []interface{}{testFileURI},
+			}
+			var got string
+			if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err != nil {
+				t.Fatal("conn.Call workspace/executeCommand:", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch result (- want, + got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("block", func(t *testing.T) {
+		tx.server.curDBCfg.GuardMode = database.GuardModeBlock
+		params := lsp.ExecuteCommandParams{
+			Command:   CommandExecuteQuery,
+			Arguments: []interface{}{testFileURI},
+		}
+		var got string
+		err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got)
+		if err == nil {
+			t.Fatal("destructive statement must be blocked")
+		}
+	})
+}
+
+func Test_executeQueryReadOnly(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	tx.server.WSCfg = &config.Config{
+		Connections: []*database.DBConfig{
+			{
+				Driver:         "sqlite3",
+				DataSourceName: "file:execute_query_read_only?mode=memory&cache=shared",
+				ReadOnly:       true,
+			},
+		},
+	}
+	if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "query",
+			input: "SELECT 1 AS one;",
+			want:  "+-----+\n| ONE |\n+-----+\n|   1 |\n+-----+\n1 rows in set\n\n\n",
+		},
+		{
+			name:    "create",
+			input:   "SELECT 1; CREATE TABLE city (id INTEGER);",
+			wantErr: true,
+		},
+		{
+			name:    "pragma assignment",
+			input:   "PRAGMA query_only = OFF;",
+			wantErr: true,
+		},
+		{
+			name:    "delete with common table expression",
+			input:   "WITH x AS (SELECT 1) DELETE FROM city;",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx.textDocumentDidOpen(t, testFileURI, tt.input)
+
+			params := lsp.ExecuteCommandParams{
+				Command:   CommandExecuteQuery,
+				Arguments: []interface{}{testFileURI},
+			}
+			var got string
+			err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("statement must be refused on read-only connection")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("conn.Call workspace/executeCommand:", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch result (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_executeQueryPlaceholders(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	tx.server.WSCfg = &config.Config{
+		Connections: []*database.DBConfig{
+			{
+				Driver:         "sqlite3",
+				DataSourceName: "file:execute_query_placeholders?mode=memory&cache=shared",
+			},
+		},
+	}
+	if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		input  string
+		values map[string]interface{}
+		reply  interface{}
+		want   string
+	}{
+		{
+			name:   "given values",
+			input:  "SELECT ? AS a, ? AS b;",
+			values: map[string]interface{}{"?#1": "Kabul", "?#2": "Herat"},
+			want:   "+-------+-------+\n|   A   |   B   |\n+-------+-------+\n| Kabul | Herat |\n+-------+-------+\n1 rows in set\n\n\n",
+		},
+		{
+			name:   "given named values",
+			input:  "SELECT :name AS a, :name AS b;",
+			values: map[string]interface{}{":name": "Kabul"},
+			want:   "+-------+-------+\n|   A   |   B   |\n+-------+-------+\n| Kabul | Kabul |\n+-------+-------+\n1 rows in set\n\n\n",
+		},
+		{
+			name:  "prompted values",
+			input: "SELECT $1 AS a;",
+			reply: "Herat",
+			want:  "+-------+\n|   A   |\n+-------+\n| Herat |\n+-------+\n1 rows in set\n\n\n",
+		},
+		{
+			name:  "prompt canceled",
+			input: "SELECT $1 AS a;",
+			want:  "Query canceled, placeholder values were not entered",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx.client.setReply("sqls/showInputRequest", tt.reply)
+			tx.textDocumentDidOpen(t, testFileURI, tt.input)
+
+			params := lsp.ExecuteCommandParams{
+				Command:   CommandExecuteQuery,
+				Arguments: []interface{}{testFileURI},
+			}
+			if tt.values != nil {
+				params.Arguments = append(params.Arguments, tt.values)
+			}
+			var got string
+			if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err != nil {
+				t.Fatal("conn.Call workspace/executeCommand:", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch result (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_executeQueryHistory(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	tx.server.WSCfg = &config.Config{
+		Connections: []*database.DBConfig{
+			{
+				Alias:          "staging",
+				Driver:         "sqlite3",
+				DataSourceName: "file:execute_query_history?mode=memory&cache=shared",
+			},
+		},
+	}
+	if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Queries of other connections are neither listed nor completed
+	other := &history.Entry{Alias: "production", Query: "SELECT 9 AS nine FROM city;"}
+	if err := tx.server.history.Append(other); err != nil {
+		t.Fatal(err)
+	}
+
+	tx.textDocumentDidOpen(t, testFileURI, "SELECT 1 AS one;\nSELECT 2 AS two FROM city;")
+	params := lsp.ExecuteCommandParams{
+		Command:   CommandExecuteQuery,
+		Arguments: []interface{}{testFileURI},
+	}
+	var executed string
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &executed); err != nil {
+		t.Fatal("conn.Call workspace/executeCommand:", err)
+	}
+
+	t.Run("record", func(t *testing.T) {
+		entries, err := tx.server.history.Entries("staging")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*history.Entry{
+			{Alias: "staging", Query: "SELECT 1 AS one;", RowCount: 1},
+			{Alias: "staging", Query: "SELECT 2 AS two FROM city;", Error: "no such table: city"},
+		}
+		ignore := cmpopts.IgnoreFields(history.Entry{}, "Time", "Duration")
+		if diff := cmp.Diff(want, entries, ignore); diff != "" {
+			t.Errorf("unmatch history (- want, + got):\n%s", diff)
+		}
+	})
+
+	t.Run("show", func(t *testing.T) {
+		params := lsp.ExecuteCommandParams{
+			Command:   CommandShowHistory,
+			Arguments: []interface{}{"city"},
+		}
+		var got string
+		if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err != nil {
+			t.Fatal("conn.Call workspace/executeCommand:", err)
+		}
+		if !strings.HasPrefix(got, "2 ") || !strings.Contains(got, " staging ") || !strings.HasSuffix(got, " 0 rows SELECT 2 AS two FROM city;, error: no such table: city") {
+			t.Errorf("unexpected history %q", got)
+		}
+	})
+
+	t.Run("rerun", func(t *testing.T) {
+		params := lsp.ExecuteCommandParams{
+			Command:   CommandRerunHistory,
+			Arguments: []interface{}{"1"},
+		}
+		var got string
+		if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err != nil {
+			t.Fatal("conn.Call workspace/executeCommand:", err)
+		}
+		want := "+-----+\n| ONE |\n+-----+\n|   1 |\n+-----+\n1 rows in set\n\n\n"
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unmatch result (- want, + got):\n%s", diff)
+		}
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		tx.textDocumentDidOpen(t, testFileURI, "SELECT 3;\nSEL")
+		completionParams := lsp.CompletionParams{
+			TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+				TextDocument: lsp.TextDocumentIdentifier{
+					URI: testFileURI,
+				},
+				Position: lsp.Position{
+					Line:      1,
+					Character: 3,
+				},
+			},
+		}
+		var items []lsp.CompletionItem
+		if err := tx.conn.Call(tx.ctx, "textDocument/completion", completionParams, &items); err != nil {
+			t.Fatal("conn.Call textDocument/completion:", err)
+		}
+		got := []string{}
+		for _, item := range items {
+			if item.Detail == "history" {
+				got = append(got, item.InsertText)
+			}
+		}
+		want := []string{"SELECT 1 AS one;", "SELECT 2 AS two FROM city;"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unmatch history candidates (- want, + got):\n%s", diff)
+		}
+	})
+}
+
+func Test_executeQueryAuditLog(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	auditLogPath := filepath.Join(tx.historyDir, "audit.log")
+	tx.server.WSCfg = &config.Config{
+		Connections: []*database.DBConfig{
+			{
+				Alias:          "production",
+				Driver:         "sqlite3",
+				DataSourceName: "file:execute_query_audit_log?mode=memory&cache=shared",
+				AuditLog: &database.AuditLogConfig{
+					Path:   auditLogPath,
+					Redact: []string{"(?i)password"},
+				},
+			},
+		},
+	}
+	if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	tx.textDocumentDidOpen(t, testFileURI, "SELECT :name AS name, :password AS password;\nDELETE FROM city WHERE id = 1;")
+	params := lsp.ExecuteCommandParams{
+		Command: CommandExecuteQuery,
+		Arguments: []interface{}{
+			testFileURI,
+			map[string]interface{}{":name": "Kabul", ":password": "secret"},
+		},
+	}
+	var got string
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err != nil {
+		t.Fatal("conn.Call workspace/executeCommand:", err)
+	}
+	// the last rollback has no transaction to finish and is not audited
+	for _, command := range []string{CommandBeginTransaction, CommandCommit, CommandBeginTransaction, CommandRollback, CommandRollback} {
+		var res interface{}
+		tx.conn.Call(tx.ctx, "workspace/executeCommand", lsp.ExecuteCommandParams{Command: command}, &res)
+	}
+
+	b, err := ioutil.ReadFile(auditLogPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := []*audit.Entry{}
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		entry := &audit.Entry{}
+		if err := json.Unmarshal([]byte(line), entry); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	want := []*audit.Entry{
+		{
+			Alias:     "production",
+			Statement: "SELECT :name AS name, :password AS password;",
+			Params:    map[string]string{":name": "Kabul", ":password": "[REDACTED]"},
+			Outcome:   audit.OutcomeOK,
+			RowCount:  1,
+		},
+		{
+			Alias:     "production",
+			Statement: "DELETE FROM city WHERE id = 1;",
+			Outcome:   audit.OutcomeError,
+			Error:     "no such table: city",
+		},
+		{Alias: "production", Statement: "BEGIN", Outcome: audit.OutcomeOK},
+		{Alias: "production", Statement: "COMMIT", Outcome: audit.OutcomeOK},
+		{Alias: "production", Statement: "BEGIN", Outcome: audit.OutcomeOK},
+		{Alias: "production", Statement: "ROLLBACK", Outcome: audit.OutcomeOK},
+	}
+	ignore := cmpopts.IgnoreFields(audit.Entry{}, "Time", "OSUser", "Duration")
+	if diff := cmp.Diff(want, entries, ignore); diff != "" {
+		t.Errorf("unmatch audit log (- want, + got):\n%s", diff)
+	}
+}
+
+func Test_bindArgs(t *testing.T) {
+	values := map[string]interface{}{
+		"?#1":   "a",
+		"?#2":   "b",
+		"?1":    "g",
+		"$1":    "c",
+		"$2":    "d",
+		":name": "e",
+		"@name": "f",
+	}
+	tests := []struct {
+		name             string
+		placeholders     []string
+		driver           dialect.DatabaseDriver
+		wantPlaceholders []string
+		want             []interface{}
+	}{
+		{
+			name:             "anonymous",
+			placeholders:     []string{"?", "?"},
+			driver:           dialect.DatabaseDriverSQLite3,
+			wantPlaceholders: []string{"?1", "?2"},
+			want:             []interface{}{"a", "b"},
+		},
+		{
+			name:             "anonymous and explicit",
+			placeholders:     []string{"?", "?1", "?"},
+			driver:           dialect.DatabaseDriverSQLite3,
+			wantPlaceholders: []string{"?1", "?2", "?3"},
+			want:             []interface{}{"a", "g", "b"},
+		},
+		{
+			name:             "mysql",
+			placeholders:     []string{"?", "?"},
+			driver:           dialect.DatabaseDriverMySQL,
+			wantPlaceholders: []string{"?", "?"},
+			want:             []interface{}{"a", "b"},
+		},
+		{
+			name:             "numbered",
+			placeholders:     []string{"$2", "$1", "$2"},
+			driver:           dialect.DatabaseDriverPostgreSQL,
+			wantPlaceholders: []string{"$1", "$2", "$1"},
+			want:             []interface{}{"d", "c"},
+		},
+		{
+			name:             "named",
+			placeholders:     []string{":name", "@name", ":name"},
+			driver:           dialect.DatabaseDriverSQLite3,
+			wantPlaceholders: []string{"?1", "?2", "?1"},
+			want:             []interface{}{"e", "f"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPlaceholders, got := bindArgs(placeholderKeys(tt.placeholders), values, tt.driver)
+			if diff := cmp.Diff(tt.wantPlaceholders, gotPlaceholders); diff != "" {
+				t.Errorf("unmatch placeholders (- want, + got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch args (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_bindStatement(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		driver dialect.DatabaseDriver
+		want   string
+		args   []interface{}
+	}{
+		{
+			name:   "mysql user variable",
+			input:  "SELECT @total := ? + ?",
+			driver: dialect.DatabaseDriverMySQL,
+			want:   "SELECT @total := ? + ?",
+			args:   []interface{}{"a", "b"},
+		},
+		{
+			name:   "postgresql jsonb operator",
+			input:  "SELECT doc ? 'key' FROM city WHERE ID = $1",
+			driver: dialect.DatabaseDriverPostgreSQL,
+			want:   "SELECT doc ? 'key' FROM city WHERE ID = $1",
+			args:   []interface{}{"c"},
+		},
+		{
+			name:   "sqlite3 named",
+			input:  "SELECT * FROM city WHERE Name = :name OR Name = @name OR ID = :name",
+			driver: dialect.DatabaseDriverSQLite3,
+			want:   "SELECT * FROM city WHERE Name = ?1 OR Name = ?2 OR ID = ?1",
+			args:   []interface{}{"e", "f"},
+		},
+	}
+	values := map[string]interface{}{
+		"?#1":   "a",
+		"?#2":   "b",
+		"$1":    "c",
+		":name": "e",
+		"@name": "f",
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmts, err := getStatements(tt.input, tt.driver)
+			if err != nil {
+				t.Fatal(err)
+			}
+			keys := placeholderKeys(parseutil.ExtractPlaceholders(stmts[0]))
+			got, args := bindStatement(stmts[0], keys, values, tt.driver)
+			if got != tt.want {
+				t.Errorf("got query %q, want %q", got, tt.want)
+			}
+			if diff := cmp.Diff(tt.args, args); diff != "" {
+				t.Errorf("unmatch args (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_destructiveType(t *testing.T) {
+	tests := []struct {
+		input    string
+		want     string
+		wantDest bool
+	}{
+		{"SELECT * FROM city", "", false},
+		{"DROP TABLE city", "DROP TABLE", true},
+		{"drop table city", "DROP TABLE", true},
+		{"TRUNCATE TABLE city", "TRUNCATE", true},
+		{"DELETE FROM city", "DELETE without WHERE", true},
+		{"DELETE FROM city WHERE ID = 1", "", false},
+		{"UPDATE city SET Name = 'Kabul'", "UPDATE without WHERE", true},
+		{"UPDATE city SET Name = (SELECT Name FROM country WHERE Code = 'AFG')", "UPDATE without WHERE", true},
+		{"UPDATE city SET Name = 'Kabul' WHERE ID = 1", "", false},
+		{"INSERT INTO city (ID) VALUES (1)", "", false},
+		{"WITH x AS (SELECT 1) DELETE FROM city", "DELETE without WHERE", true},
+		{"WITH x AS (SELECT ID FROM city WHERE ID = 1) DELETE FROM city", "DELETE without WHERE", true},
+		{"WITH x AS (SELECT 1) DELETE FROM city WHERE ID IN (SELECT * FROM x)", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			stmts, err := getStatements(tt.input, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, gotDest := destructiveType(stmts[0])
+			if got != tt.want || gotDest != tt.wantDest {
+				t.Errorf("got (%q, %v), want (%q, %v)", got, gotDest, tt.want, tt.wantDest)
+			}
+		})
+	}
+}
+
 func Test_extractRangeText(t *testing.T) {
 	type args struct {
 		text      string
@@ -110,3 +760,303 @@ func Test_extractRangeText(t *testing.T) {
 		})
 	}
 }
+
+func Test_executeQueryInSession(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	cfg := &config.Config{
+		Connections: []*database.DBConfig{
+			{
+				Driver:         "sqlite3",
+				DataSourceName: "file:execute_query_in_session?mode=memory&cache=shared",
+			},
+		},
+	}
+	tx.addWorkspaceConfig(t, cfg)
+
+	tests := []struct {
+		name    string
+		command string
+		input   string
+		want    string
+	}{
+		{
+			name:    "temporary table",
+			command: CommandExecuteQuery,
+			input:   "CREATE TEMP TABLE city (id INTEGER); INSERT INTO city VALUES (1);",
+			want:    "Query OK, 0 row affected\n\n\nQuery OK, 1 row affected\n\n\n",
+		},
+		{
+			name:    "begin",
+			command: CommandBeginTransaction,
+			want:    "transaction started",
+		},
+		{
+			name:    "execute in transaction",
+			command: CommandExecuteQuery,
+			input:   "DELETE FROM city WHERE id = 1;",
+			want:    "Query OK, 1 row affected\n\n\nin transaction, run \"commit\" or \"rollback\" to finish it\n",
+		},
+		{
+			name:    "rollback",
+			command: CommandRollback,
+			want:    "transaction rolled back",
+		},
+		{
+			name:    "temporary table survives rollback",
+			command: CommandExecuteQuery,
+			input:   "SELECT id FROM city;",
+			want:    "+----+\n| ID |\n+----+\n|  1 |\n+----+\n1 rows in set\n\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := lsp.ExecuteCommandParams{
+				Command: tt.command,
+			}
+			if tt.command == CommandExecuteQuery {
+				tx.textDocumentDidOpen(t, testFileURI, tt.input)
+				params.Arguments = []interface{}{testFileURI}
+			}
+			var got string
+			if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err != nil {
+				t.Fatal("conn.Call workspace/executeCommand:", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch result (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_executeQueryAsStatements(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	tx.server.WSCfg = &config.Config{
+		Connections: []*database.DBConfig{
+			{
+				Driver:         "sqlite3",
+				DataSourceName: filepath.Join(tx.historyDir, "as_statements.db"),
+			},
+		},
+	}
+	if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+		t.Fatal(err)
+	}
+	tx.textDocumentDidOpen(t, testFileURI, `CREATE TABLE city (id INTEGER PRIMARY KEY, name TEXT, population INTEGER); INSERT INTO city VALUES (1, 'Kabul', 1780000), (2, 'Qandahar "Kandahar"', NULL); CREATE TABLE country (code TEXT);`)
+	params := lsp.ExecuteCommandParams{
+		Command:   CommandExecuteQuery,
+		Arguments: []interface{}{testFileURI},
+	}
+	var got interface{}
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err != nil {
+		t.Fatal("conn.Call workspace/executeCommand:", err)
+	}
+	// refresh the cache to know the primary key of the created table
+	if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		flag    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "insert",
+			input: "SELECT * FROM city ORDER BY id;",
+			flag:  "-as-insert",
+			want: `INSERT INTO "city" ("id", "name", "population") VALUES (1, 'Kabul', 1780000);
+INSERT INTO "city" ("id", "name", "population") VALUES (2, 'Qandahar "Kandahar"', NULL);
+-- 2 rows in set
+
+
+`,
+		},
+		{
+			name:  "update",
+			input: "SELECT name, id FROM city WHERE id = 2;",
+			flag:  "-as-update",
+			want: `UPDATE "city" SET "name" = 'Qandahar "Kandahar"' WHERE "id" = 2;
+-- 1 rows in set
+
+
+`,
+		},
+		{
+			name:    "update without primary key",
+			input:   "SELECT code FROM country;",
+			flag:    "-as-update",
+			wantErr: true,
+		},
+		{
+			name:    "computed column",
+			input:   "SELECT id, population * 2 AS p FROM city;",
+			flag:    "-as-insert",
+			wantErr: true,
+		},
+		{
+			name:    "join",
+			input:   "SELECT * FROM city, country;",
+			flag:    "-as-insert",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx.textDocumentDidOpen(t, testFileURI, tt.input)
+
+			params := lsp.ExecuteCommandParams{
+				Command:   CommandExecuteQuery,
+				Arguments: []interface{}{testFileURI, tt.flag},
+			}
+			var got string
+			err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("statements must not be generated")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("conn.Call workspace/executeCommand:", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch result (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_fetchMoreRows(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	tx.server.WSCfg = &config.Config{
+		RowLimit: 2,
+		Connections: []*database.DBConfig{
+			{
+				Driver:         "sqlite3",
+				DataSourceName: filepath.Join(tx.historyDir, "fetch_more_rows.db"),
+			},
+		},
+	}
+	if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+		t.Fatal(err)
+	}
+	call := func(t *testing.T, command string, arguments ...interface{}) (string, error) {
+		t.Helper()
+		params := lsp.ExecuteCommandParams{
+			Command:   command,
+			Arguments: arguments,
+		}
+		var got string
+		err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got)
+		return got, err
+	}
+
+	t.Run("paging", func(t *testing.T) {
+		tx.textDocumentDidOpen(t, testFileURI, "SELECT 1 AS n UNION ALL SELECT 2 UNION ALL SELECT 3 UNION ALL SELECT 4 UNION ALL SELECT 5;")
+		got, err := call(t, CommandExecuteQuery, testFileURI)
+		if err != nil {
+			t.Fatal("conn.Call workspace/executeCommand:", err)
+		}
+		want := "+---+\n| N |\n+---+\n| 1 |\n| 2 |\n+---+\n2 rows in set\n... more rows after row 2, run \"fetchMoreRows\" to fetch the next 2\n\n\n"
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unmatch first page (- want, + got):\n%s", diff)
+		}
+
+		got, err = call(t, CommandFetchMoreRows)
+		if err != nil {
+			t.Fatal("conn.Call workspace/executeCommand:", err)
+		}
+		want = "+---+\n| N |\n+---+\n| 3 |\n| 4 |\n+---+\n2 rows in set\n... more rows after row 4, run \"fetchMoreRows\" to fetch the next 2\n\n"
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unmatch second page (- want, + got):\n%s", diff)
+		}
+
+		got, err = call(t, CommandFetchMoreRows, "-show-vertical")
+		if err != nil {
+			t.Fatal("conn.Call workspace/executeCommand:", err)
+		}
+		if !strings.Contains(got, "n | 5") || !strings.HasSuffix(got, "1 rows in set\n\n") {
+			t.Errorf("unexpected last page %q", got)
+		}
+
+		if _, err := call(t, CommandFetchMoreRows); err == nil {
+			t.Error("expected an error fetching an exhausted cursor")
+		}
+	})
+
+	hasFetchAction := func(t *testing.T) bool {
+		t.Helper()
+		params := lsp.CodeActionParams{
+			TextDocument: lsp.TextDocumentIdentifier{URI: testFileURI},
+		}
+		var commands []lsp.Command
+		if err := tx.conn.Call(tx.ctx, "textDocument/codeAction", params, &commands); err != nil {
+			t.Fatal("conn.Call textDocument/codeAction:", err)
+		}
+		for _, command := range commands {
+			if command.Command == CommandFetchMoreRows {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("code action", func(t *testing.T) {
+		if hasFetchAction(t) {
+			t.Error("fetching more rows must not be offered without pending rows")
+		}
+		tx.textDocumentDidOpen(t, testFileURI, "SELECT 1 AS n UNION ALL SELECT 2 UNION ALL SELECT 3;")
+		if _, err := call(t, CommandExecuteQuery, testFileURI); err != nil {
+			t.Fatal("conn.Call workspace/executeCommand:", err)
+		}
+		if !hasFetchAction(t) {
+			t.Error("fetching more rows must be offered with pending rows")
+		}
+		if _, err := call(t, CommandFetchMoreRows); err != nil {
+			t.Fatal("conn.Call workspace/executeCommand:", err)
+		}
+		if hasFetchAction(t) {
+			t.Error("fetching more rows must not be offered once the rows are fetched")
+		}
+	})
+
+	t.Run("several statements", func(t *testing.T) {
+		tx.textDocumentDidOpen(t, testFileURI, "SELECT 1 AS n UNION ALL SELECT 2 UNION ALL SELECT 3;\nSELECT 4 AS m UNION ALL SELECT 5 UNION ALL SELECT 6;")
+		got, err := call(t, CommandExecuteQuery, testFileURI)
+		if err != nil {
+			t.Fatal("conn.Call workspace/executeCommand:", err)
+		}
+		if n := strings.Count(got, "more rows"); n != 1 {
+			t.Errorf("the paging marker must be shown for the last statement only, shown %d times in %q", n, got)
+		}
+		if !strings.HasSuffix(got, "... more rows after row 2, run \"fetchMoreRows\" to fetch the next 2\n\n\n") {
+			t.Errorf("the last statement must show the paging marker, got %q", got)
+		}
+	})
+
+	t.Run("closed cursor", func(t *testing.T) {
+		tx.textDocumentDidOpen(t, testFileURI, "SELECT 1 AS n UNION ALL SELECT 2 UNION ALL SELECT 3;")
+		if _, err := call(t, CommandExecuteQuery, testFileURI); err != nil {
+			t.Fatal("conn.Call workspace/executeCommand:", err)
+		}
+		// the next query closes the cursor left by the previous one
+		tx.textDocumentDidOpen(t, testFileURI, "SELECT 1 AS n;")
+		if _, err := call(t, CommandExecuteQuery, testFileURI); err != nil {
+			t.Fatal("conn.Call workspace/executeCommand:", err)
+		}
+		if _, err := call(t, CommandFetchMoreRows); err == nil {
+			t.Error("expected an error fetching a closed cursor")
+		}
+	})
+}
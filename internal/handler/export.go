@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lighttiger2505/sqls/ast"
+	"github.com/lighttiger2505/sqls/ast/astutil"
+	"github.com/lighttiger2505/sqls/dialect"
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+	"github.com/lighttiger2505/sqls/token"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+const (
+	exportFormatCSV       = "csv"
+	exportFormatJSONLines = "json"
+
+	// exportBatchSize is the number of rows written between progress reports
+	exportBatchSize = 1000
+)
+
+func (s *Server) exportQuery(ctx context.Context, conn *jsonrpc2.Conn, params lsp.ExecuteCommandParams) (result interface{}, err error) {
+	// parse execute command arguments
+	if s.dbConn == nil {
+		return nil, errors.New("database connection is not open")
+	}
+	if len(params.Arguments) < 2 {
+		return nil, fmt.Errorf("required arguments were not provided: <File URI> <Output Path>")
+	}
+	uri, ok := params.Arguments[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("specify the file uri as a string")
+	}
+	f, ok := s.files[uri]
+	if !ok {
+		return nil, fmt.Errorf("document not found, %q", uri)
+	}
+	outputPath, ok := params.Arguments[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("specify the output path as a string")
+	}
+	format := exportFormatCSV
+	if ext := strings.ToLower(filepath.Ext(outputPath)); ext == ".json" || ext == ".jsonl" || ext == ".ndjson" {
+		format = exportFormatJSONLines
+	}
+	values := map[string]interface{}{}
+	for _, arg := range params.Arguments[2:] {
+		switch v := arg.(type) {
+		case string:
+			if v != exportFormatCSV && v != exportFormatJSONLines {
+				return nil, fmt.Errorf("invalid export format %q, specify %q or %q", v, exportFormatCSV, exportFormatJSONLines)
+			}
+			format = v
+		case map[string]interface{}:
+			values = v
+		}
+	}
+	path, err := s.workspacePath(outputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// extract target query
+	stmt, err := focusedStatement(f.Text, params.Range, s.driver())
+	if err != nil {
+		return nil, err
+	}
+	query := strings.TrimSpace(stmt.String())
+	if typ, isQuery := database.QueryExecType(query, ""); !isQuery {
+		return nil, fmt.Errorf("only queries can be exported, got %s statement", typ)
+	}
+	stmtParams, entered, err := s.bindPlaceholders(ctx, conn, []*ast.Statement{stmt}, values)
+	if err != nil {
+		return nil, err
+	}
+	if !entered {
+		return "Export canceled, placeholder values were not entered", nil
+	}
+
+	progress := lsp.NewWorkDoneProgress(ctx, conn, params.WorkDoneToken, s.clientCapabilities.Window.WorkDoneProgress)
+	if err := progress.Begin(ctx, "Export Query", fmt.Sprintf("exporting to %s", outputPath)); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	rowCount, exportErr := s.exportRows(ctx, progress, query, stmtParams[0], path, format)
+	stmtResult := &statementResult{rowCount: rowCount, err: exportErr}
+	s.recordHistory(query, start, stmtResult)
+	if err := s.recordAudit(query, stmtParams[0], start, stmtResult); err != nil {
+		return nil, err
+	}
+	if exportErr != nil {
+		progress.End(ctx, "export failed")
+		return nil, exportErr
+	}
+	message := fmt.Sprintf("%d rows exported to %s in %s", rowCount, outputPath, time.Since(start).Round(time.Millisecond))
+	if err := progress.End(ctx, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// exportRows streams the rows of the query to the file at path batch by
+// batch, so that the whole result is never held in memory.
+func (s *Server) exportRows(ctx context.Context, progress *lsp.WorkDoneProgress, query string, params *statementParams, path, format string) (int64, error) {
+	session, err := s.getSession(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.closeCursor(); err != nil {
+		return 0, err
+	}
+
+	rows, err := session.Query(context.Background(), params.statement(query), params.args()...)
+	if err != nil {
+		return 0, s.sessionError(err, session.InTransaction())
+	}
+	cursor, err := database.NewRowCursor(rows)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return 0, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	writer := newRowWriter(file, format)
+	if err := writer.writeHeader(cursor.Columns(), cursor.ColumnTypes()); err != nil {
+		return 0, err
+	}
+	var rowCount int64
+	for {
+		valueRows, hasMore, err := cursor.FetchValues(exportBatchSize)
+		if err != nil {
+			return rowCount, err
+		}
+		for _, values := range valueRows {
+			if err := writer.writeRow(values); err != nil {
+				return rowCount, err
+			}
+		}
+		rowCount += int64(len(valueRows))
+		if err := progress.Report(ctx, fmt.Sprintf("%d rows exported", rowCount), 0); err != nil {
+			return rowCount, err
+		}
+		if !hasMore {
+			break
+		}
+	}
+	if err := writer.flush(); err != nil {
+		return rowCount, err
+	}
+	return rowCount, file.Close()
+}
+
+// workspacePath resolves a path relative to the workspace root. Paths
+// outside of the workspace are refused.
+func (s *Server) workspacePath(path string) (string, error) {
+	if s.rootPath == "" {
+		return "", errors.New("workspace root is unknown, the client did not send rootUri")
+	}
+	if filepath.IsAbs(path) {
+		return "", fmt.Errorf("specify the path relative to the workspace root, %q", path)
+	}
+	cleaned := filepath.Clean(path)
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path is outside of the workspace root, %q", path)
+	}
+	return filepath.Join(s.rootPath, cleaned), nil
+}
+
+// uriToPath returns the file path of a file URI.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+// focusedStatement returns the statement at the cursor when rng is empty, or
+// the only statement in rng or the whole text otherwise, parsed with the
+// dialect of the driver.
+func focusedStatement(text string, rng *lsp.Range, driver dialect.DatabaseDriver) (*ast.Statement, error) {
+	if rng != nil && rng.Start != rng.End {
+		text = extractRangeText(
+			text,
+			rng.Start.Line,
+			rng.Start.Character,
+			rng.End.Line,
+			rng.End.Character,
+		)
+	}
+	stmts, err := getStatements(text, driver)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []*ast.Statement{}
+	for _, stmt := range stmts {
+		if strings.TrimSpace(stmt.String()) == "" {
+			continue
+		}
+		if rng != nil && rng.Start == rng.End {
+			pos := token.Pos{Line: rng.Start.Line, Col: rng.Start.Character}
+			if astutil.IsEnclose(stmt, pos) {
+				return stmt, nil
+			}
+		}
+		candidates = append(candidates, stmt)
+	}
+	if len(candidates) != 1 {
+		return nil, fmt.Errorf("cannot find the statement to export, place the cursor in a statement")
+	}
+	return candidates[0], nil
+}
+
+// rowWriter writes the rows as scanned from the driver, with NULL as nil.
+type rowWriter interface {
+	writeHeader(columns, types []string) error
+	writeRow(values []interface{}) error
+	flush() error
+}
+
+func newRowWriter(w io.Writer, format string) rowWriter {
+	if format == exportFormatJSONLines {
+		return &jsonLinesRowWriter{writer: bufio.NewWriter(w)}
+	}
+	return &csvRowWriter{writer: csv.NewWriter(w)}
+}
+
+// csvRowWriter writes NULL as an empty field.
+type csvRowWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvRowWriter) writeHeader(columns, types []string) error {
+	return w.writer.Write(columns)
+}
+
+func (w *csvRowWriter) writeRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		str, err := database.ValueString(value)
+		if err != nil {
+			return err
+		}
+		record[i] = str
+	}
+	return w.writer.Write(record)
+}
+
+func (w *csvRowWriter) flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// jsonLinesRowWriter writes each row as a JSON object keyed by column name,
+// keeping the order of the columns.
+type jsonLinesRowWriter struct {
+	writer  *bufio.Writer
+	columns [][]byte
+	numeric []bool
+}
+
+func (w *jsonLinesRowWriter) writeHeader(columns, types []string) error {
+	w.columns = make([][]byte, len(columns))
+	w.numeric = make([]bool, len(columns))
+	for i, column := range columns {
+		b, err := marshalJSONString(column)
+		if err != nil {
+			return err
+		}
+		w.columns[i] = b
+		w.numeric[i] = isNumericType(types[i])
+	}
+	return nil
+}
+
+func (w *jsonLinesRowWriter) writeRow(values []interface{}) error {
+	w.writer.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			w.writer.WriteByte(',')
+		}
+		b, err := marshalJSONValue(value, w.numeric[i])
+		if err != nil {
+			return err
+		}
+		w.writer.Write(w.columns[i])
+		w.writer.WriteByte(':')
+		w.writer.Write(b)
+	}
+	w.writer.WriteByte('}')
+	_, err := w.writer.WriteString("\n")
+	return err
+}
+
+func (w *jsonLinesRowWriter) flush() error {
+	return w.writer.Flush()
+}
+
+// marshalJSONValue encodes a value scanned from the driver: NULL as null,
+// numbers and booleans as they are, and the others as strings. Drivers such
+// as MySQL return numbers as text, which are written as numbers when the
+// column is numeric.
+func marshalJSONValue(value interface{}, numeric bool) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return []byte("null"), nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return json.Marshal(v)
+	case []byte:
+		if numeric && json.Valid(v) {
+			return v, nil
+		}
+	}
+	str, err := database.ValueString(value)
+	if err != nil {
+		return nil, err
+	}
+	return marshalJSONString(str)
+}
+
+// isNumericType reports whether the database type of a column holds numbers.
+func isNumericType(databaseType string) bool {
+	typ := strings.TrimPrefix(strings.ToUpper(databaseType), "UNSIGNED ")
+	switch typ {
+	case "INT", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT",
+		"INT2", "INT4", "INT8", "DECIMAL", "NUMERIC", "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "REAL":
+		return true
+	}
+	return false
+}
+
+// marshalJSONString encodes s as a JSON string without escaping HTML
+// characters, so that values such as "<b>" stay readable.
+func marshalJSONString(s string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
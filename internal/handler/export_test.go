@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/internal/config"
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+)
+
+func Test_exportQuery(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	tx.server.rootPath = tx.historyDir
+	tx.server.WSCfg = &config.Config{
+		Connections: []*database.DBConfig{
+			{
+				Driver:         "sqlite3",
+				DataSourceName: "file:export_query?mode=memory&cache=shared",
+			},
+		},
+	}
+	if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+		t.Fatal(err)
+	}
+	tx.textDocumentDidOpen(t, testFileURI, `CREATE TABLE city (id INTEGER, name TEXT); INSERT INTO city VALUES (1, 'Kabul'), (2, 'Qandahar, "Kandahar"'), (3, NULL);`)
+	params := lsp.ExecuteCommandParams{
+		Command:   CommandExecuteQuery,
+		Arguments: []interface{}{testFileURI},
+	}
+	var got interface{}
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err != nil {
+		t.Fatal("conn.Call workspace/executeCommand:", err)
+	}
+
+	text := "SELECT 1;\nSELECT id, name FROM city WHERE id >= :id ORDER BY id;\n"
+	tests := []struct {
+		name      string
+		rng       *lsp.Range
+		arguments []interface{}
+		output    string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name: "csv",
+			rng: &lsp.Range{
+				Start: lsp.Position{Line: 1, Character: 10},
+				End:   lsp.Position{Line: 1, Character: 10},
+			},
+			arguments: []interface{}{testFileURI, "out/city.csv", map[string]interface{}{":id": 1}},
+			output:    "out/city.csv",
+			want:      "id,name\n1,Kabul\n2,\"Qandahar, \"\"Kandahar\"\"\"\n3,\n",
+		},
+		{
+			name: "json lines",
+			rng: &lsp.Range{
+				Start: lsp.Position{Line: 1, Character: 0},
+				End:   lsp.Position{Line: 1, Character: 0},
+			},
+			arguments: []interface{}{testFileURI, "city.jsonl", map[string]interface{}{":id": 2}},
+			output:    "city.jsonl",
+			want:      "{\"id\":2,\"name\":\"Qandahar, \\\"Kandahar\\\"\"}\n{\"id\":3,\"name\":null}\n",
+		},
+		{
+			name: "explicit format",
+			rng: &lsp.Range{
+				Start: lsp.Position{Line: 0, Character: 0},
+				End:   lsp.Position{Line: 0, Character: 9},
+			},
+			arguments: []interface{}{testFileURI, "one.txt", "json"},
+			output:    "one.txt",
+			want:      "{\"1\":1}\n",
+		},
+		{
+			name:      "multiple statements",
+			arguments: []interface{}{testFileURI, "city.csv"},
+			wantErr:   true,
+		},
+		{
+			name: "outside of workspace",
+			rng: &lsp.Range{
+				Start: lsp.Position{Line: 0, Character: 0},
+				End:   lsp.Position{Line: 0, Character: 0},
+			},
+			arguments: []interface{}{testFileURI, "../city.csv"},
+			wantErr:   true,
+		},
+		{
+			name: "absolute path",
+			rng: &lsp.Range{
+				Start: lsp.Position{Line: 0, Character: 0},
+				End:   lsp.Position{Line: 0, Character: 0},
+			},
+			arguments: []interface{}{testFileURI, filepath.Join(tx.historyDir, "city.csv")},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx.textDocumentDidOpen(t, testFileURI, text)
+
+			params := lsp.ExecuteCommandParams{
+				Command:   CommandExportQuery,
+				Arguments: tt.arguments,
+				Range:     tt.rng,
+			}
+			var got string
+			err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("export must be refused")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("conn.Call workspace/executeCommand:", err)
+			}
+			b, err := ioutil.ReadFile(filepath.Join(tx.historyDir, tt.output))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, string(b)); diff != "" {
+				t.Errorf("unmatch exported file (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_marshalJSONValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		numeric bool
+		want    string
+	}{
+		{"null", nil, false, "null"},
+		{"integer", int64(42), false, "42"},
+		{"float", 1.5, false, "1.5"},
+		{"bool", true, false, "true"},
+		{"numeric text", []byte("12.50"), true, "12.50"},
+		{"numeric column not a number", []byte("NaN"), true, `"NaN"`},
+		{"text", []byte("<b>Kabul</b>"), false, `"<b>Kabul</b>"`},
+		{"time", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), false, `"2020-01-02T03:04:05Z"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := marshalJSONValue(tt.value, tt.numeric)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
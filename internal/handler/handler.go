@@ -2,6 +2,8 @@ package handler
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,8 +13,10 @@ import (
 	"github.com/sourcegraph/jsonrpc2"
 	"golang.org/x/xerrors"
 
+	"github.com/lighttiger2505/sqls/internal/audit"
 	"github.com/lighttiger2505/sqls/internal/config"
 	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/history"
 	"github.com/lighttiger2505/sqls/internal/lsp"
 )
 
@@ -25,14 +29,26 @@ type Server struct {
 	DefaultFileCfg  *config.Config
 	WSCfg           *config.Config
 
-	dbConn *database.DBConnection
+	dbConn  *database.DBConnection
+	session *database.Session
+	cursor  *database.RowCursor
+	// cursorStatements renders the rows left in cursor as statements
+	cursorStatements *rowStatements
 
 	curDBCfg           *database.DBConfig
 	curDBName          string
 	curConnectionIndex int
 
-	worker *database.Worker
-	files  map[string]*File
+	worker  *database.Worker
+	files   map[string]*File
+	history *history.Store
+	// stats keeps the statistics of the hovered tables
+	stats tableStatsCache
+
+	auditLogger *audit.Logger
+
+	clientCapabilities lsp.ClientCapabilities
+	rootPath           string
 }
 
 type File struct {
@@ -45,8 +61,9 @@ func NewServer() *Server {
 	worker.Start()
 
 	return &Server{
-		files:  make(map[string]*File),
-		worker: worker,
+		files:   make(map[string]*File),
+		worker:  worker,
+		history: history.NewStore(config.HistoryFilePath()),
 	}
 }
 
@@ -64,6 +81,12 @@ func panicf(r interface{}, format string, v ...interface{}) error {
 }
 
 func (s *Server) Stop() error {
+	if err := s.closeCursor(); err != nil {
+		return err
+	}
+	if err := s.closeSession(); err != nil {
+		return err
+	}
 	if err := s.dbConn.Close(); err != nil {
 		return err
 	}
@@ -104,6 +127,8 @@ func (s *Server) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.
 		return s.handleTextDocumentDidClose(ctx, conn, req)
 	case "textDocument/completion":
 		return s.handleTextDocumentCompletion(ctx, conn, req)
+	case "completionItem/resolve":
+		return s.handleCompletionItemResolve(ctx, conn, req)
 	case "textDocument/hover":
 		return s.handleTextDocumentHover(ctx, conn, req)
 	case "textDocument/codeAction":
@@ -118,6 +143,10 @@ func (s *Server) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.
 		return s.handleTextDocumentRangeFormatting(ctx, conn, req)
 	case "textDocument/signatureHelp":
 		return s.handleTextDocumentSignatureHelp(ctx, conn, req)
+	case "textDocument/definition":
+		return s.handleTextDocumentDefinition(ctx, conn, req)
+	case "sqls/virtualTextDocument":
+		return s.handleVirtualTextDocument(ctx, conn, req)
 	}
 	return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeMethodNotFound, Message: fmt.Sprintf("method not supported: %s", req.Method)}
 }
@@ -131,6 +160,12 @@ func (s *Server) handleInitialize(ctx context.Context, conn *jsonrpc2.Conn, req
 	if err := json.Unmarshal(*req.Params, &params); err != nil {
 		return nil, err
 	}
+	s.clientCapabilities = params.Capabilities
+	if params.RootURI != "" {
+		s.rootPath = uriToPath(params.RootURI)
+	} else {
+		s.rootPath = params.RootPath
+	}
 
 	result = lsp.InitializeResult{
 		Capabilities: lsp.ServerCapabilities{
@@ -139,6 +174,7 @@ func (s *Server) handleInitialize(ctx context.Context, conn *jsonrpc2.Conn, req
 			CodeActionProvider: true,
 			CompletionProvider: &lsp.CompletionOptions{
 				TriggerCharacters: []string{"(", "."},
+				ResolveProvider:   true,
 			},
 			SignatureHelpProvider: &lsp.SignatureHelpOptions{
 				TriggerCharacters:   []string{"(", ","},
@@ -147,7 +183,7 @@ func (s *Server) handleInitialize(ctx context.Context, conn *jsonrpc2.Conn, req
 					WorkDoneProgress: false,
 				},
 			},
-			DefinitionProvider:              false,
+			DefinitionProvider:              true,
 			DocumentFormattingProvider:      true,
 			DocumentRangeFormattingProvider: true,
 		},
@@ -318,7 +354,18 @@ func (s *Server) handleWorkspaceDidChangeConfiguration(ctx context.Context, conn
 	return nil, nil
 }
 
+// reconnectionDB opens the connection of the current settings. It is refused
+// while a transaction is open, as closing the session would roll it back.
 func (s *Server) reconnectionDB(ctx context.Context) error {
+	if s.inTransaction() {
+		return fmt.Errorf("transaction is open, run %q or %q before changing the connection", CommandCommit, CommandRollback)
+	}
+	if err := s.closeCursor(); err != nil {
+		return err
+	}
+	if err := s.closeSession(); err != nil {
+		return err
+	}
 	if err := s.dbConn.Close(); err != nil {
 		return err
 	}
@@ -355,6 +402,16 @@ func (s *Server) newDBConnection(ctx context.Context) (*database.DBConnection, e
 	}
 	s.curDBCfg = connCfg
 
+	// Statements executed on the connection are audited if configured
+	s.auditLogger = nil
+	if connCfg.AuditLog != nil {
+		auditLogger, err := audit.NewLogger(connCfg.AuditLog)
+		if err != nil {
+			return nil, err
+		}
+		s.auditLogger = auditLogger
+	}
+
 	// Connect database
 	conn, err := database.Open(connCfg)
 	if err != nil {
@@ -371,6 +428,76 @@ func (s *Server) newDBRepository(ctx context.Context) (database.DBRepository, er
 	return repo, nil
 }
 
+// getSession returns the session that statements executed by the user run on.
+// The session is opened on first use and kept until the connection changes.
+// A session whose connection was dropped is reopened, unless a transaction is
+// open on it: a failing ping would wait for the transaction to close, so its
+// connection is checked by its statements, see sessionError.
+func (s *Server) getSession(ctx context.Context) (*database.Session, error) {
+	if s.session != nil {
+		if s.session.InTransaction() {
+			return s.session, nil
+		}
+		err := s.session.Ping(ctx)
+		if err == nil {
+			return s.session, nil
+		}
+		if !isConnectionLost(err) {
+			return nil, err
+		}
+		s.discardSession()
+		log.Println("session connection was lost, reopening")
+	}
+	if s.dbConn == nil {
+		return nil, errors.New("database connection is not open")
+	}
+	session, err := database.NewSession(ctx, s.dbConn.Conn, s.curDBCfg)
+	if err != nil {
+		return nil, err
+	}
+	s.session = session
+	return session, nil
+}
+
+// sessionError discards the session when err tells its connection was
+// dropped, so that the next statement runs on a new one. The transaction open
+// on the session, if any, is reported as lost.
+func (s *Server) sessionError(err error, inTransaction bool) error {
+	if !isConnectionLost(err) {
+		return err
+	}
+	s.discardSession()
+	if inTransaction {
+		return database.ErrTransactionLost
+	}
+	return err
+}
+
+func isConnectionLost(err error) bool {
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone)
+}
+
+// discardSession closes the session of a dropped connection, whose errors are
+// only logged as the connection is already gone.
+func (s *Server) discardSession() {
+	if err := s.closeCursor(); err != nil {
+		log.Println("close cursor of lost session:", err)
+	}
+	if err := s.closeSession(); err != nil {
+		log.Println("close lost session:", err)
+	}
+}
+
+func (s *Server) closeSession() error {
+	session := s.session
+	s.session = nil
+	return session.Close()
+}
+
+func (s *Server) inTransaction() bool {
+	return s.session != nil && s.session.InTransaction()
+}
+
 func (s *Server) topConnection() *database.DBConfig {
 	cfg := s.getConfig()
 	if cfg == nil || len(cfg.Connections) == 0 {
@@ -2,40 +2,104 @@ package handler
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io/ioutil"
 	"log"
 	"net"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/jsonrpc2"
 
 	"github.com/lighttiger2505/sqls/internal/config"
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/history"
 	"github.com/lighttiger2505/sqls/internal/lsp"
 )
 
 const testFileURI = "file:///Users/octref/Code/css-test/test.sql"
 
 type TestContext struct {
-	h          jsonrpc2.Handler
+	h          *SerialHandler
 	conn       *jsonrpc2.Conn
 	connServer *jsonrpc2.Conn
 	server     *Server
+	client     *testClient
 	ctx        context.Context
+	historyDir string
 }
 
 func newTestContext() *TestContext {
 	server := NewServer()
-	handler := jsonrpc2.HandlerWithError(server.Handle)
+	handler := NewSerialHandler(jsonrpc2.HandlerWithError(server.Handle))
 	ctx := context.Background()
 	return &TestContext{
 		h:      handler,
 		ctx:    ctx,
 		server: server,
+		client: &testClient{},
 	}
 }
 
+// testClient plays the client side of the connection. It answers requests
+// from the server with the reply registered for the method, null otherwise,
+// and records the notifications it receives.
+type testClient struct {
+	mu            sync.Mutex
+	replies       map[string]interface{}
+	notifications []*jsonrpc2.Request
+}
+
+func (c *testClient) Handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if req.Notif {
+		c.notifications = append(c.notifications, req)
+		return
+	}
+	if err := conn.Reply(ctx, req.ID, c.replies[req.Method]); err != nil {
+		log.Println("reply to server request:", err)
+	}
+}
+
+func (c *testClient) setReply(method string, reply interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.replies == nil {
+		c.replies = map[string]interface{}{}
+	}
+	c.replies[method] = reply
+}
+
+func (c *testClient) received(method string) []*jsonrpc2.Request {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	reqs := []*jsonrpc2.Request{}
+	for _, req := range c.notifications {
+		if req.Method == method {
+			reqs = append(reqs, req)
+		}
+	}
+	return reqs
+}
+
 func (tx *TestContext) setup(t *testing.T) {
 	t.Helper()
+
+	// Keep the query history of tests out of the user's config directory
+	historyDir, err := ioutil.TempDir("", "sqls-test")
+	if err != nil {
+		t.Fatal("create history directory:", err)
+	}
+	tx.historyDir = historyDir
+	tx.server.history = history.NewStore(filepath.Join(historyDir, "history.jsonl"))
+
 	tx.initServer(t)
 }
 
@@ -51,6 +115,13 @@ func (tx *TestContext) tearDown() {
 			log.Fatal("connServer.Close:", err)
 		}
 	}
+
+	tx.h.Close()
+	tx.server.worker.Stop()
+
+	if tx.historyDir != "" {
+		os.RemoveAll(tx.historyDir)
+	}
 }
 
 func (tx *TestContext) initServer(t *testing.T) {
@@ -59,7 +130,7 @@ func (tx *TestContext) initServer(t *testing.T) {
 	// Prepare the server and client connection.
 	client, server := net.Pipe()
 	tx.connServer = jsonrpc2.NewConn(tx.ctx, jsonrpc2.NewBufferedStream(server, jsonrpc2.VSCodeObjectCodec{}), tx.h)
-	tx.conn = jsonrpc2.NewConn(tx.ctx, jsonrpc2.NewBufferedStream(client, jsonrpc2.VSCodeObjectCodec{}), tx.h)
+	tx.conn = jsonrpc2.NewConn(tx.ctx, jsonrpc2.NewBufferedStream(client, jsonrpc2.VSCodeObjectCodec{}), tx.client)
 
 	// Initialize Langage Server
 	params := lsp.InitializeParams{
@@ -81,6 +152,26 @@ func (tx *TestContext) addWorkspaceConfig(t *testing.T, cfg *config.Config) {
 	if err := tx.conn.Call(tx.ctx, "workspace/didChangeConfiguration", didChangeConfigurationParams, nil); err != nil {
 		t.Fatal("conn.Call workspace/didChangeConfiguration:", err)
 	}
+	tx.waitCacheExtras(t)
+}
+
+// waitCacheExtras waits for the worker to load the views, routines and the
+// other extras of the connection in the background.
+func (tx *TestContext) waitCacheExtras(t *testing.T) {
+	t.Helper()
+	if tx.server.dbConn == nil {
+		return
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if cache := tx.server.worker.Cache(); cache != nil && cache.SchemaViews != nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("extras of the cache are not loaded")
+		}
+		time.Sleep(time.Millisecond)
+	}
 }
 
 func (tx *TestContext) textDocumentDidOpen(t *testing.T, uri, input string) {
@@ -109,6 +200,7 @@ func TestInitialized(t *testing.T) {
 			HoverProvider:    true,
 			CompletionProvider: &lsp.CompletionOptions{
 				TriggerCharacters: []string{"(", "."},
+				ResolveProvider:   true,
 			},
 			SignatureHelpProvider: &lsp.SignatureHelpOptions{
 				TriggerCharacters:   []string{"(", ","},
@@ -118,7 +210,7 @@ func TestInitialized(t *testing.T) {
 				},
 			},
 			CodeActionProvider:              true,
-			DefinitionProvider:              false,
+			DefinitionProvider:              true,
 			DocumentFormattingProvider:      true,
 			DocumentRangeFormattingProvider: true,
 		},
@@ -214,3 +306,106 @@ func (tx *TestContext) testFile(t *testing.T, uri, text string) {
 		t.Errorf("not match %s. got: %s", text, f.Text)
 	}
 }
+
+// droppableConnector opens connections that fail with driver.ErrBadConn once
+// dropped, as if the server closed them.
+type droppableConnector struct {
+	mu    sync.Mutex
+	conns []*droppableConn
+}
+
+func (c *droppableConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	conn := &droppableConn{}
+	c.conns = append(c.conns, conn)
+	return conn, nil
+}
+
+func (c *droppableConnector) Driver() driver.Driver { return nil }
+
+func (c *droppableConnector) drop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, conn := range c.conns {
+		conn.dropped = true
+	}
+}
+
+type droppableConn struct {
+	dropped bool
+}
+
+func (c *droppableConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (c *droppableConn) Close() error { return nil }
+func (c *droppableConn) Begin() (driver.Tx, error) {
+	if c.dropped {
+		return nil, driver.ErrBadConn
+	}
+	return c, nil
+}
+func (c *droppableConn) Commit() error   { return nil }
+func (c *droppableConn) Rollback() error { return nil }
+func (c *droppableConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.dropped {
+		return nil, driver.ErrBadConn
+	}
+	return driver.RowsAffected(0), nil
+}
+func (c *droppableConn) Ping(ctx context.Context) error {
+	if c.dropped {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+func TestGetSessionReconnect(t *testing.T) {
+	tx := newTestContext()
+	defer tx.tearDown()
+	ctx := context.Background()
+
+	connector := &droppableConnector{}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	tx.server.dbConn = &database.DBConnection{Conn: db}
+	tx.server.curDBCfg = &database.DBConfig{Driver: "mock"}
+
+	session, err := tx.server.getSession(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the session is reopened when no transaction is open
+	connector.drop()
+	reopened, err := tx.server.getSession(ctx)
+	if err != nil {
+		t.Fatal("session must be reopened:", err)
+	}
+	if reopened == session {
+		t.Fatal("session must not be the dropped one")
+	}
+
+	// a transaction open on a dropped session is reported as lost
+	if err := reopened.Begin(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.server.reconnectionDB(ctx); err == nil {
+		t.Error("reconnection must be refused while a transaction is open")
+	}
+	connector.drop()
+	result, err := tx.server.exec(ctx, "DELETE FROM city", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.err != database.ErrTransactionLost {
+		t.Errorf("got error %v, want %v", result.err, database.ErrTransactionLost)
+	}
+	if tx.server.inTransaction() {
+		t.Error("lost transaction must be discarded")
+	}
+	if _, err := tx.server.getSession(ctx); err != nil {
+		t.Error("next session must be opened:", err)
+	}
+}
@@ -5,9 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/lighttiger2505/sqls/ast"
 	"github.com/lighttiger2505/sqls/ast/astutil"
+	"github.com/lighttiger2505/sqls/dialect"
 	"github.com/lighttiger2505/sqls/internal/database"
 	"github.com/lighttiger2505/sqls/internal/lsp"
 	"github.com/lighttiger2505/sqls/parser"
@@ -33,7 +38,25 @@ func (s *Server) handleTextDocumentHover(ctx context.Context, conn *jsonrpc2.Con
 		return nil, fmt.Errorf("document not found: %s", params.TextDocument.URI)
 	}
 
-	res, err := hover(f.Text, params, s.worker.Cache())
+	var (
+		driver  dialect.DatabaseDriver
+		sources *tableHoverSources
+	)
+	if s.dbConn != nil {
+		driver = s.dbConn.Driver
+		sources = &tableHoverSources{
+			uri: s.tableDocumentURI,
+			stats: func(schema, table string) (*database.TableStatsDesc, error) {
+				return s.tableStats(ctx, schema, table)
+			},
+		}
+		if s.curDBCfg != nil && s.curDBCfg.Preview != nil {
+			sources.preview = func(schema, table string) ([]string, [][]string, error) {
+				return s.previewRows(ctx, schema, table)
+			}
+		}
+	}
+	res, err := hover(f.Text, params, s.worker.Cache(), driver, sources)
 	if err != nil {
 		if err == ErrNoHover {
 			return nil, nil
@@ -43,11 +66,75 @@ func (s *Server) handleTextDocumentHover(ctx context.Context, conn *jsonrpc2.Con
 	return res, nil
 }
 
-func hover(text string, params lsp.HoverParams, dbCache *database.DBCache) (*lsp.Hover, error) {
-	if dbCache == nil {
-		return nil, nil
-	}
+// tableStatsTimeout bounds the query of the statistics of a hovered table.
+const tableStatsTimeout = 2 * time.Second
+
+// tableStatsTTL is how long the statistics of a table are reused.
+const tableStatsTTL = time.Minute
+
+// tableStats returns the statistics of the table on the current connection.
+// They are queried on the first hover of the table, and reused until they
+// expire or the cache of the connection is rebuilt.
+func (s *Server) tableStats(ctx context.Context, schema, table string) (*database.TableStatsDesc, error) {
+	return s.stats.get(s.worker.Generation(), schema, table, func() (*database.TableStatsDesc, error) {
+		ctx, cancel := context.WithTimeout(ctx, tableStatsTimeout)
+		defer cancel()
+		repo, err := s.newDBRepository(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return repo.TableStats(ctx, schema, table)
+	})
+}
 
+// tableStatsCache keeps the statistics of tables, and the errors querying
+// them so that a slow database is not queried again on each hover.
+type tableStatsCache struct {
+	lock       sync.Mutex
+	generation int
+	entries    map[string]*tableStatsEntry
+}
+
+type tableStatsEntry struct {
+	stats   *database.TableStatsDesc
+	err     error
+	expires time.Time
+}
+
+// get returns the statistics of the table, calling fetch when they are not
+// cached for the generation of the database cache.
+func (c *tableStatsCache) get(generation int, schema, table string, fetch func() (*database.TableStatsDesc, error)) (*database.TableStatsDesc, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.entries == nil || c.generation != generation {
+		c.entries = map[string]*tableStatsEntry{}
+		c.generation = generation
+	}
+	key := schema + "." + table
+	now := time.Now()
+	if entry, ok := c.entries[key]; ok && now.Before(entry.expires) {
+		return entry.stats, entry.err
+	}
+	stats, err := fetch()
+	c.entries[key] = &tableStatsEntry{stats: stats, err: err, expires: now.Add(tableStatsTTL)}
+	return stats, err
+}
+
+// tableHoverSources gives the details of a hovered table which are not
+// cached. Each of them is optional.
+type tableHoverSources struct {
+	// uri gives the virtual document linked from the hover.
+	uri func(schema, table string) string
+	// stats gives the statistics of the table.
+	stats func(schema, table string) (*database.TableStatsDesc, error)
+	// preview gives the columns and the first rows of the table.
+	preview func(schema, table string) ([]string, [][]string, error)
+}
+
+// hover returns the hover of the identifier at the position, or else of the
+// built-in function or keyword of the driver at the position. sources, if
+// any, adds the details of the hovered tables.
+func hover(text string, params lsp.HoverParams, dbCache *database.DBCache, driver dialect.DatabaseDriver, sources *tableHoverSources) (*lsp.Hover, error) {
 	pos := token.Pos{
 		Line: params.Position.Line,
 		Col:  params.Position.Character + 1,
@@ -56,9 +143,19 @@ func hover(text string, params lsp.HoverParams, dbCache *database.DBCache) (*lsp
 	if err != nil {
 		return nil, err
 	}
+	nodeWalker := parseutil.NewNodeWalker(parsed, pos)
+
+	if dbCache != nil {
+		res, err := identHover(parsed, pos, nodeWalker, dbCache, sources)
+		if err != ErrNoHover {
+			return res, err
+		}
+	}
+	return builtinHover(parsed, params.Position, nodeWalker, driver)
+}
 
+func identHover(parsed ast.TokenList, pos token.Pos, nodeWalker *parseutil.NodeWalker, dbCache *database.DBCache, sources *tableHoverSources) (*lsp.Hover, error) {
 	// Find identifiers from focused statement
-	nodeWalker := parseutil.NewNodeWalker(parsed, pos)
 	hoverTargetMatcher := astutil.NodeMatcher{
 		NodeTypes: []ast.NodeType{
 			ast.TypeMemberIdentifer,
@@ -76,6 +173,9 @@ func hover(text string, params lsp.HoverParams, dbCache *database.DBCache) (*lsp
 	if err != nil {
 		return nil, err
 	}
+	if sources != nil {
+		hoverEnv.sources = *sources
+	}
 
 	// Check hover type
 	ctx := getHoverTypes(nodeWalker, hoverEnv)
@@ -105,7 +205,11 @@ func hover(text string, params lsp.HoverParams, dbCache *database.DBCache) (*lsp
 	} else if ident != nil && memIdent == nil {
 		// The cursor is on the identifier
 		// example "c[i]ty"
-		hoverContent = hoverContentFromIdent(ctx, ident.NoQuateString(), dbCache, hoverEnv)
+		if isFunctionName(nodeWalker, ident) {
+			hoverContent = hoverContentFromFunction(ident.NoQuateString(), dbCache)
+		} else {
+			hoverContent = hoverContentFromIdent(ctx, ident.NoQuateString(), dbCache, hoverEnv)
+		}
 	}
 	if hoverContent == nil {
 		return nil, ErrNoHover
@@ -136,6 +240,7 @@ type hoverEnvironment struct {
 	aliases    []ast.Node
 	tables     []*parseutil.TableInfo
 	subQueries []*parseutil.SubQueryInfo
+	sources    tableHoverSources
 }
 
 func (e *hoverEnvironment) getTableRealName(aliasName string) (string, bool) {
@@ -219,6 +324,98 @@ func findIdent(nodes []ast.Node) (*ast.Identifer, *ast.MemberIdentifer) {
 	return ident, memIdent
 }
 
+// maxKeywordWords is the number of words of the longest keyword phrase, such
+// as "ON DUPLICATE KEY UPDATE".
+const maxKeywordWords = 4
+
+// builtinHover returns the hover of the built-in function called or the
+// keyword at the position.
+func builtinHover(parsed ast.TokenList, position lsp.Position, nw *parseutil.NodeWalker, driver dialect.DatabaseDriver) (*lsp.Hover, error) {
+	if name := functionNameNode(nw); name != nil {
+		funcs := dialect.LookupFunction(driver, name.String())
+		if len(funcs) == 0 {
+			return nil, ErrNoHover
+		}
+		return markdownHover(database.FunctionDoc(funcs), name.Pos(), name.End()), nil
+	}
+
+	pos := token.Pos{
+		Line: position.Line,
+		Col:  position.Character,
+	}
+	for _, phrase := range parseutil.ExtractWordPhrases(parsed, pos, maxKeywordWords) {
+		if kw, ok := dialect.LookupKeyword(driver, phrase.Words); ok {
+			return markdownHover(database.KeywordDoc(kw), phrase.From, phrase.To), nil
+		}
+	}
+	return nil, ErrNoHover
+}
+
+func markdownHover(value string, from, to token.Pos) *lsp.Hover {
+	return &lsp.Hover{
+		Contents: lsp.MarkupContent{
+			Kind:  lsp.Markdown,
+			Value: value,
+		},
+		Range: lsp.Range{
+			Start: lsp.Position{
+				Line:      from.Line,
+				Character: from.Col,
+			},
+			End: lsp.Position{
+				Line:      to.Line,
+				Character: to.Col,
+			},
+		},
+	}
+}
+
+// functionNameNode returns the name of the called function at the position,
+// or nil if the position is not on one. The name of a function named like a
+// keyword is not an identifier. example "l[e]ft(name, 3)"
+func functionNameNode(nw *parseutil.NodeWalker) ast.Node {
+	functionMatcher := astutil.NodeMatcher{
+		NodeTypes: []ast.NodeType{ast.TypeFunctionLiteral},
+	}
+	if !nw.CurNodeIs(functionMatcher) {
+		return nil
+	}
+	fn := nw.CurNodeButtomMatched(functionMatcher).(*ast.FunctionLiteral)
+	nodes := nw.CurNodes()
+	if len(fn.Toks) == 0 || nodes[len(nodes)-1] != fn.Toks[0] {
+		return nil
+	}
+	return fn.Toks[0]
+}
+
+// isFunctionName reports whether the identifier is the name of a called
+// function. example "c[i]ty_count(code)"
+func isFunctionName(nw *parseutil.NodeWalker, ident *ast.Identifer) bool {
+	functionMatcher := astutil.NodeMatcher{
+		NodeTypes: []ast.NodeType{ast.TypeFunctionLiteral},
+	}
+	if !nw.CurNodeIs(functionMatcher) {
+		return false
+	}
+	fn := nw.CurNodeButtomMatched(functionMatcher).(*ast.FunctionLiteral)
+	return len(fn.Toks) > 0 && fn.Toks[0] == ast.Node(ident)
+}
+
+func hoverContentFromFunction(name string, dbCache *database.DBCache) *lsp.MarkupContent {
+	routines := dbCache.Routine("", name)
+	if len(routines) == 0 {
+		return nil
+	}
+	docs := make([]string, len(routines))
+	for i, routine := range routines {
+		docs[i] = database.RoutineDoc(routine)
+	}
+	return &lsp.MarkupContent{
+		Kind:  lsp.Markdown,
+		Value: strings.Join(docs, "\n"),
+	}
+}
+
 func hoverContentFromIdent(ctx *hoverContext, identName string, dbCache *database.DBCache, hoverEnv *hoverEnvironment) *lsp.MarkupContent {
 	if hoverTypeIs(ctx.types, hoverTypeColumn) {
 		columnName := identName
@@ -253,16 +450,26 @@ func hoverContentFromIdent(ctx *hoverContext, identName string, dbCache *databas
 		// find table
 		cols, ok := dbCache.ColumnDescs(tableName)
 		if ok {
-			return tableHoverInfo(tableName, cols)
+			return tableHoverInfo(tableName, cols, dbCache, hoverEnv)
 		}
 	}
 	if hoverTypeIs(ctx.types, hoverTypeSubQueryColumn) {
 		columnName := identName
-		subQueryView, ok := hoverEnv.getSubQueryViewOne()
-		if !ok {
-			return nil
+		if subQueryView, ok := hoverEnv.getSubQueryViewOne(); ok {
+			return subqueryColumnHoverInfo(columnName, subQueryView, dbCache)
+		}
+	}
+	if dbCache.IsSequence("", identName) {
+		return &lsp.MarkupContent{
+			Kind:  lsp.Markdown,
+			Value: database.SequenceDoc(identName),
+		}
+	}
+	if typeDesc, ok := dbCache.Type("", identName); ok {
+		return &lsp.MarkupContent{
+			Kind:  lsp.Markdown,
+			Value: database.TypeDoc(typeDesc),
 		}
-		return subqueryColumnHoverInfo(columnName, subQueryView, dbCache)
 	}
 	return nil
 }
@@ -280,7 +487,7 @@ func hoverContentFromParentIdent(ctx *hoverContext, identName string, dbCache *d
 		}
 		columns, ok := dbCache.ColumnDescs(tableName)
 		if ok {
-			return tableHoverInfo(tableName, columns)
+			return tableHoverInfo(tableName, columns, dbCache, hoverEnv)
 		}
 	case parentTypeSubQuery:
 		subQueryName := identName
@@ -300,7 +507,7 @@ func hoverContentFromChildIdent(ctx *hoverContext, identName string, dbCache *da
 	case parentTypeSchema:
 		columns, ok := dbCache.ColumnDescs(identName)
 		if ok {
-			return tableHoverInfo(identName, columns)
+			return tableHoverInfo(identName, columns, dbCache, hoverEnv)
 		}
 	case parentTypeTable:
 		tableName := ctx.parent.Name
@@ -329,10 +536,41 @@ func columnHoverInfo(tableName, colName string, colDesc *database.ColumnDesc) *l
 	}
 }
 
-func tableHoverInfo(tableName string, cols []*database.ColumnDesc) *lsp.MarkupContent {
+func tableHoverInfo(tableName string, cols []*database.ColumnDesc, dbCache *database.DBCache, hoverEnv *hoverEnvironment) *lsp.MarkupContent {
+	var schema string
+	if len(cols) > 0 {
+		schema = cols[0].Schema
+	}
+	comment := dbCache.TableComment(schema, tableName)
+	if dbCache.IsView(schema, tableName) {
+		return &lsp.MarkupContent{
+			Kind:  lsp.Markdown,
+			Value: database.ViewDoc(tableName, comment, cols),
+		}
+	}
+	doc := database.TableDoc(tableName, comment, cols)
+	if hoverEnv.sources.stats != nil {
+		stats, err := hoverEnv.sources.stats(schema, tableName)
+		if err != nil {
+			log.Printf("table statistics of %s, %+v\n", tableName, err)
+		} else if statsDoc := database.TableStatsDoc(stats); statsDoc != "" {
+			doc += "\n" + statsDoc
+		}
+	}
+	if hoverEnv.sources.preview != nil {
+		columns, rows, err := hoverEnv.sources.preview(schema, tableName)
+		if err != nil {
+			log.Printf("table preview of %s, %+v\n", tableName, err)
+		} else {
+			doc += "\nSample rows:\n\n" + markdownTable(columns, rows)
+		}
+	}
+	if hoverEnv.sources.uri != nil && len(cols) > 0 {
+		doc += fmt.Sprintf("\n[CREATE TABLE](%s)\n", hoverEnv.sources.uri(cols[0].Schema, tableName))
+	}
 	return &lsp.MarkupContent{
 		Kind:  lsp.Markdown,
-		Value: database.TableDoc(tableName, cols),
+		Value: doc,
 	}
 }
 
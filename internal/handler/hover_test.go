@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/dialect"
 	"github.com/lighttiger2505/sqls/internal/config"
 	"github.com/lighttiger2505/sqls/internal/database"
 	"github.com/lighttiger2505/sqls/internal/lsp"
@@ -44,6 +47,20 @@ var hoverTestCases = []struct {
 		line:   0,
 		col:    8,
 	},
+	{
+		name:   "select ident with variable",
+		input:  "SELECT ID, Name FROM city WHERE ID = @id",
+		output: "city.ID column\n\nint(11) PRI auto_increment\n",
+		line:   0,
+		col:    8,
+	},
+	{
+		name:   "variable",
+		input:  "SELECT ID, Name FROM city WHERE ID = @id",
+		output: "",
+		line:   0,
+		col:    39,
+	},
 	{
 		name:   "select ident tail",
 		input:  "SELECT ID, Name FROM city",
@@ -68,28 +85,28 @@ var hoverTestCases = []struct {
 	{
 		name:   "table ident head",
 		input:  "SELECT ID, Name FROM city",
-		output: "city table\n\n- ID: int(11) PRI auto_increment\n- Name: char(35)\n- CountryCode: char(3) MUL\n- District: char(20)\n- Population: int(11)\n",
+		output: "city table\n\n- ID: int(11) PRI auto_increment\n- Name: char(35)\n- CountryCode: char(3) MUL\n- District: char(20)\n- Population: int(11)\n\n[CREATE TABLE](sqls://mock/world/city.sql)\n",
 		line:   0,
 		col:    22,
 	},
 	{
 		name:   "table ident tail",
 		input:  "SELECT ID, Name FROM city",
-		output: "city table\n\n- ID: int(11) PRI auto_increment\n- Name: char(35)\n- CountryCode: char(3) MUL\n- District: char(20)\n- Population: int(11)\n",
+		output: "city table\n\n- ID: int(11) PRI auto_increment\n- Name: char(35)\n- CountryCode: char(3) MUL\n- District: char(20)\n- Population: int(11)\n\n[CREATE TABLE](sqls://mock/world/city.sql)\n",
 		line:   0,
 		col:    25,
 	},
 	{
 		name:   "select member ident parent head",
 		input:  "SELECT city.ID, city.Name FROM city",
-		output: "city table\n\n- ID: int(11) PRI auto_increment\n- Name: char(35)\n- CountryCode: char(3) MUL\n- District: char(20)\n- Population: int(11)\n",
+		output: "city table\n\n- ID: int(11) PRI auto_increment\n- Name: char(35)\n- CountryCode: char(3) MUL\n- District: char(20)\n- Population: int(11)\n\n[CREATE TABLE](sqls://mock/world/city.sql)\n",
 		line:   0,
 		col:    8,
 	},
 	{
 		name:   "select member ident parent tail",
 		input:  "SELECT city.ID, city.Name FROM city",
-		output: "city table\n\n- ID: int(11) PRI auto_increment\n- Name: char(35)\n- CountryCode: char(3) MUL\n- District: char(20)\n- Population: int(11)\n",
+		output: "city table\n\n- ID: int(11) PRI auto_increment\n- Name: char(35)\n- CountryCode: char(3) MUL\n- District: char(20)\n- Population: int(11)\n\n[CREATE TABLE](sqls://mock/world/city.sql)\n",
 		line:   0,
 		col:    20,
 	},
@@ -117,7 +134,7 @@ var hoverTestCases = []struct {
 	{
 		name:   "select aliased member ident parent",
 		input:  "SELECT ci.ID, ci.Name FROM city AS ci",
-		output: "city table\n\n- ID: int(11) PRI auto_increment\n- Name: char(35)\n- CountryCode: char(3) MUL\n- District: char(20)\n- Population: int(11)\n",
+		output: "city table\n\n- ID: int(11) PRI auto_increment\n- Name: char(35)\n- CountryCode: char(3) MUL\n- District: char(20)\n- Population: int(11)\n\n[CREATE TABLE](sqls://mock/world/city.sql)\n",
 		line:   0,
 		col:    8,
 	},
@@ -199,6 +216,48 @@ FROM city
 		line:   2,
 		col:    6,
 	},
+	{
+		name:   "view",
+		input:  "SELECT Name FROM citylist",
+		output: "citylist view\n\n- Name: char(35)\n- CountryName: char(52)\n",
+		line:   0,
+		col:    19,
+	},
+	{
+		name:   "stored function",
+		input:  "SELECT city_count(Code, 1000) FROM country",
+		output: "city_count function\n\ncity_count(country_code char(3), min_population int) RETURNS int\n",
+		line:   0,
+		col:    10,
+	},
+	{
+		name:   "sequence",
+		input:  "SELECT nextval(city_id_seq)",
+		output: "city_id_seq sequence\n",
+		line:   0,
+		col:    18,
+	},
+	{
+		name:   "user-defined type",
+		input:  "SELECT CAST(NULL AS continent)",
+		output: "continent enum type\n\n'Asia', 'Europe', 'North America', 'Africa', 'Oceania', 'Antarctica', 'South America'\n",
+		line:   0,
+		col:    23,
+	},
+	{
+		name:   "column comment",
+		input:  "SELECT IsOfficial FROM countrylanguage",
+		output: "countrylanguage.IsOfficial column\n\nenum('T','F') F\n\nT if the language is official in the country\n",
+		line:   0,
+		col:    9,
+	},
+	{
+		name:   "table comment and statistics",
+		input:  "SELECT IsOfficial FROM countrylanguage",
+		output: "countrylanguage table\n\nLanguages spoken in each country\n\n- CountryCode: char(3) PRI\n- Language: char(30) PRI\n- IsOfficial: enum('T','F') F -- T if the language is official in the country\n- Percentage: decimal(4,1)\n\nStatistics: about 984 rows, 96.0 KiB data, 16.0 KiB indexes, analyzed 2020-09-01 12:00:00\n\n[CREATE TABLE](sqls://mock/world/countrylanguage.sql)\n",
+		line:   0,
+		col:    25,
+	},
 }
 
 func TestHoverMain(t *testing.T) {
@@ -281,3 +340,140 @@ func TestHoverNoneDBConnection(t *testing.T) {
 		})
 	}
 }
+
+func TestTableStatsCache(t *testing.T) {
+	var cache tableStatsCache
+	calls := 0
+	fetch := func() (*database.TableStatsDesc, error) {
+		calls++
+		return nil, errors.New("timeout")
+	}
+	get := func(generation int, table string) {
+		t.Helper()
+		if _, err := cache.get(generation, "world", table, fetch); err == nil {
+			t.Error("the error of the query must be returned")
+		}
+	}
+
+	get(1, "city")
+	get(1, "city")
+	if calls != 1 {
+		t.Errorf("the statistics must be queried once, queried %d times", calls)
+	}
+	get(1, "country")
+	if calls != 2 {
+		t.Errorf("the statistics of another table must be queried, queried %d times", calls)
+	}
+	get(2, "city")
+	if calls != 3 {
+		t.Errorf("the statistics must be queried again for a new cache, queried %d times", calls)
+	}
+	cache.entries["world.city"].expires = time.Now().Add(-time.Second)
+	get(2, "city")
+	if calls != 4 {
+		t.Errorf("expired statistics must be queried again, queried %d times", calls)
+	}
+}
+
+func TestBuiltinHover(t *testing.T) {
+	testcases := []struct {
+		name      string
+		input     string
+		driver    dialect.DatabaseDriver
+		character int
+		output    string
+		want      lsp.Range
+	}{
+		{
+			name:      "built-in function",
+			input:     "SELECT GROUP_CONCAT(Name) FROM city",
+			driver:    dialect.DatabaseDriverMySQL,
+			character: 10,
+			output:    "GROUP_CONCAT function\n\nGROUP_CONCAT([DISTINCT] expr [ORDER BY ...] [SEPARATOR str_val]) RETURNS text\n\nConcatenation of the non-NULL values from a group, separated by commas by default.\n",
+			want:      lsp.Range{Start: lsp.Position{Character: 7}, End: lsp.Position{Character: 19}},
+		},
+		{
+			name:      "built-in function named like a keyword",
+			input:     "SELECT left(Name, 3) FROM city",
+			driver:    dialect.DatabaseDriverPostgreSQL,
+			character: 8,
+			output:    "LEFT function\n\nLEFT(string text, n integer) RETURNS text\n\nFirst n characters in the string. When n is negative, all but the last |n| characters.\n",
+			want:      lsp.Range{Start: lsp.Position{Character: 7}, End: lsp.Position{Character: 11}},
+		},
+		{
+			name:      "keyword",
+			input:     "SELECT Name FROM city WHERE ID = 1",
+			driver:    dialect.DatabaseDriverSQLite3,
+			character: 23,
+			output:    "WHERE keyword\n\n```sql\nWHERE expr\n```\n\nExcludes the rows for which the expression is false or NULL.\n",
+			want:      lsp.Range{Start: lsp.Position{Character: 22}, End: lsp.Position{Character: 27}},
+		},
+		{
+			name:      "multiple keywords",
+			input:     "SELECT CountryCode FROM city GROUP BY CountryCode",
+			driver:    dialect.DatabaseDriverSQLite3,
+			character: 35,
+			output:    "GROUP BY keyword\n\n```sql\nGROUP BY expr, ... [HAVING expr]\n```\n\nGroups the input rows that have the same values for the expressions into a single result row.\n",
+			want:      lsp.Range{Start: lsp.Position{Character: 29}, End: lsp.Position{Character: 37}},
+		},
+		{
+			name:      "clause",
+			input:     "INSERT INTO city (ID) VALUES (1) ON CONFLICT (ID) DO NOTHING",
+			driver:    dialect.DatabaseDriverPostgreSQL,
+			character: 34,
+			output:    database.KeywordDoc(mustLookupKeyword(t, dialect.DatabaseDriverPostgreSQL, "ON CONFLICT")),
+			want:      lsp.Range{Start: lsp.Position{Character: 33}, End: lsp.Position{Character: 44}},
+		},
+		{
+			name:      "identifier",
+			input:     "SELECT Name FROM city",
+			driver:    dialect.DatabaseDriverSQLite3,
+			character: 8,
+			output:    "",
+		},
+		{
+			name:      "unknown function",
+			input:     "SELECT my_function(Name) FROM city",
+			driver:    dialect.DatabaseDriverMySQL,
+			character: 8,
+			output:    "",
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			params := lsp.HoverParams{
+				TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+					Position: lsp.Position{
+						Line:      0,
+						Character: tt.character,
+					},
+				},
+			}
+			got, err := hover(tt.input, params, nil, tt.driver, nil)
+			if tt.output == "" {
+				if err != ErrNoHover {
+					t.Errorf("found hover, %+v, %+v", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.output, got.Contents.Value); diff != "" {
+				t.Errorf("unmatch hover contents (- want, + got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got.Range); diff != "" {
+				t.Errorf("unmatch hover range (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func mustLookupKeyword(t *testing.T, driver dialect.DatabaseDriver, name string) *dialect.KeywordDesc {
+	t.Helper()
+	kw, ok := dialect.LookupKeyword(driver, name)
+	if !ok {
+		t.Fatalf("keyword not found: %s", name)
+	}
+	return kw
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+func (s *Server) importCSV(ctx context.Context, conn *jsonrpc2.Conn, params lsp.ExecuteCommandParams) (result interface{}, err error) {
+	// parse execute command arguments
+	if s.dbConn == nil {
+		return nil, errors.New("database connection is not open")
+	}
+	if len(params.Arguments) < 2 {
+		return nil, fmt.Errorf("required arguments were not provided: <CSV Path> <Table>")
+	}
+	csvPath, ok := params.Arguments[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("specify the csv path as a string")
+	}
+	tableName, ok := params.Arguments[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("specify the table as a string")
+	}
+	path, err := s.workspacePath(csvPath)
+	if err != nil {
+		return nil, err
+	}
+	if s.curDBCfg != nil && s.curDBCfg.ReadOnly {
+		return nil, errors.New("import refused, connection is read only")
+	}
+	if s.inTransaction() {
+		return nil, errors.New("transaction is open, commit or rollback it before importing")
+	}
+
+	table, err := s.importTable(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate all the records first, so that a malformed file imports nothing
+	header, err := readCSVHeader(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := table.mapHeader(header); err != nil {
+		return nil, err
+	}
+	if err := table.readBatches(path, func([][]interface{}) error { return nil }); err != nil {
+		return nil, err
+	}
+
+	progress := lsp.NewWorkDoneProgress(ctx, conn, params.WorkDoneToken, s.clientCapabilities.Window.WorkDoneProgress)
+	if err := progress.Begin(ctx, "Import CSV", fmt.Sprintf("importing %s into %s", csvPath, tableName)); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	var rowCount int64
+	importErr := database.LoadRows(context.Background(), s.dbConn.Driver, s.dbConn.Conn, table.target, func(load func([][]interface{}) error) error {
+		return table.readBatches(path, func(rows [][]interface{}) error {
+			if err := load(rows); err != nil {
+				return fmt.Errorf("batch of records %d to %d, %s", rowCount+1, rowCount+int64(len(rows)), err)
+			}
+			rowCount += int64(len(rows))
+			return progress.Report(ctx, fmt.Sprintf("%d rows loaded", rowCount), 0)
+		})
+	})
+	if importErr != nil {
+		// the rows loaded before the failure are rolled back with the rest
+		rowCount = 0
+	}
+	statement := fmt.Sprintf("IMPORT CSV %s INTO %s", csvPath, tableName)
+	if err := s.recordAudit(statement, nil, start, &statementResult{rowCount: rowCount, err: importErr}); err != nil {
+		return nil, err
+	}
+	if importErr != nil {
+		progress.End(ctx, "import failed")
+		return nil, fmt.Errorf("import failed and was rolled back, no rows were imported, %s", importErr)
+	}
+	message := fmt.Sprintf("%d rows imported into %s in %s", rowCount, tableName, time.Since(start).Round(time.Millisecond))
+	if err := progress.End(ctx, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// csvImportTable maps the columns of a CSV file to the columns of the table.
+type csvImportTable struct {
+	columns []*database.ColumnDesc
+	target  *database.ImportTable
+}
+
+// importTable looks up the columns of the table, given as "table" or
+// "schema.table", in the database cache.
+func (s *Server) importTable(name string) (*csvImportTable, error) {
+	cols, err := s.tableColumns(name)
+	if err != nil {
+		return nil, err
+	}
+	schema, table := splitTableName(name)
+	return &csvImportTable{
+		columns: cols,
+		target:  &database.ImportTable{Schema: schema, Name: table},
+	}, nil
+}
+
+// mapHeader selects the columns named by the header, ignoring case.
+func (t *csvImportTable) mapHeader(header []string) error {
+	mapped := make([]*database.ColumnDesc, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		for _, col := range t.columns {
+			if strings.EqualFold(col.Name, name) {
+				mapped[i] = col
+				break
+			}
+		}
+		if mapped[i] == nil {
+			return fmt.Errorf("column not found in %s, %q", t.target.Name, name)
+		}
+		for _, prev := range mapped[:i] {
+			if prev == mapped[i] {
+				return fmt.Errorf("column appears more than once in the header, %q", name)
+			}
+		}
+	}
+	t.target.Columns = mapped
+	return nil
+}
+
+// readBatches reads the records after the header, converts them to the types
+// of the columns and passes them to fn in batches.
+func (t *csvImportTable) readBatches(path string, fn func(rows [][]interface{}) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	if _, err := reader.Read(); err != nil {
+		return err
+	}
+	rows := [][]interface{}{}
+	for record := 1; ; record++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		row := make([]interface{}, len(fields))
+		for i, field := range fields {
+			value, err := database.ConvertValue(t.target.Columns[i], field)
+			if err != nil {
+				return fmt.Errorf("record %d, %s", record, err)
+			}
+			row[i] = value
+		}
+		rows = append(rows, row)
+		if len(rows) == database.DefaultImportBatchSize {
+			if err := fn(rows); err != nil {
+				return err
+			}
+			rows = [][]interface{}{}
+		}
+	}
+	if len(rows) > 0 {
+		return fn(rows)
+	}
+	return nil
+}
+
+func readCSVHeader(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	header, err := csv.NewReader(file).Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("csv file is empty, %q", path)
+	}
+	return header, err
+}
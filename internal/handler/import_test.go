@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/internal/config"
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+)
+
+func Test_importCSV(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	tx.server.rootPath = tx.historyDir
+	tx.server.WSCfg = &config.Config{
+		Connections: []*database.DBConfig{
+			{
+				Driver:         "sqlite3",
+				DataSourceName: filepath.Join(tx.historyDir, "import.db"),
+			},
+		},
+	}
+	if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+		t.Fatal(err)
+	}
+	tx.textDocumentDidOpen(t, testFileURI, "CREATE TABLE city (id INTEGER NOT NULL, name TEXT, population INTEGER CHECK (population >= 0), founded DATE);")
+	params := lsp.ExecuteCommandParams{
+		Command:   CommandExecuteQuery,
+		Arguments: []interface{}{testFileURI},
+	}
+	var got interface{}
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err != nil {
+		t.Fatal("conn.Call workspace/executeCommand:", err)
+	}
+	// refresh the cache to know the columns of the created table
+	if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// the database rejects the last record, after the first batch was loaded
+	rejected := new(strings.Builder)
+	rejected.WriteString("id,population\n")
+	for i := 0; i < database.DefaultImportBatchSize; i++ {
+		fmt.Fprintf(rejected, "%d,100\n", i+3)
+	}
+	rejected.WriteString("0,-1\n")
+
+	tests := []struct {
+		name    string
+		csv     string
+		table   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "import",
+			csv:   "Name,ID,population\nKabul,1,1780000\n\"Qandahar, \"\"Kandahar\"\"\",2,\n",
+			table: "city",
+			want:  "2 rows imported into city",
+		},
+		{
+			name:    "unknown column",
+			csv:     "id,country\n3,AFG\n",
+			table:   "city",
+			wantErr: true,
+		},
+		{
+			name:    "invalid integer",
+			csv:     "id,name\n3,Herat\nfour,Mazar-e-Sharif\n",
+			table:   "city",
+			wantErr: true,
+		},
+		{
+			name:    "invalid date",
+			csv:     "id,founded\n3,2020/06/01\n",
+			table:   "city",
+			wantErr: true,
+		},
+		{
+			name:    "rejected by the database",
+			csv:     rejected.String(),
+			table:   "city",
+			wantErr: true,
+		},
+		{
+			name:    "unknown table",
+			csv:     "id\n3\n",
+			table:   "country",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ioutil.WriteFile(filepath.Join(tx.historyDir, "city.csv"), []byte(tt.csv), 0644); err != nil {
+				t.Fatal(err)
+			}
+			params := lsp.ExecuteCommandParams{
+				Command:   CommandImportCSV,
+				Arguments: []interface{}{"city.csv", tt.table},
+			}
+			var got string
+			err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("import must fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("conn.Call workspace/executeCommand:", err)
+			}
+			if diff := cmp.Diff(tt.want, got[:len(tt.want)]); diff != "" {
+				t.Errorf("unmatch result (- want, + got):\n%s", diff)
+			}
+		})
+	}
+
+	// rejected files must not have imported any rows
+	tx.textDocumentDidOpen(t, testFileURI, "SELECT id, name, population FROM city ORDER BY id;")
+	var rows string
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &rows); err != nil {
+		t.Fatal("conn.Call workspace/executeCommand:", err)
+	}
+	want := `+----+----------------------+------------+
+| ID |         NAME         | POPULATION |
++----+----------------------+------------+
+|  1 | Kabul                |    1780000 |
+|  2 | Qandahar, "Kandahar" | <nil>      |
++----+----------------------+------------+
+2 rows in set
+
+
+`
+	if diff := cmp.Diff(want, rows); diff != "" {
+		t.Errorf("unmatch imported rows (- want, + got):\n%s", diff)
+	}
+}
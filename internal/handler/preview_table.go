@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+	"github.com/olekukonko/tablewriter"
+)
+
+func (s *Server) previewTable(ctx context.Context, params lsp.ExecuteCommandParams) (result interface{}, err error) {
+	if len(params.Arguments) == 0 {
+		return nil, fmt.Errorf("required arguments were not provided: <Table>")
+	}
+	name, ok := params.Arguments[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("specify the table as a string")
+	}
+	if s.dbConn == nil {
+		return nil, errors.New("database connection is not open")
+	}
+	cols, err := s.tableColumns(name)
+	if err != nil {
+		return nil, err
+	}
+	schema, table := splitTableName(name)
+	if schema == "" {
+		schema = cols[0].Schema
+	}
+
+	columns, rows, err := s.previewRows(ctx, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	writer := tablewriter.NewWriter(buf)
+	writer.SetHeader(columns)
+	writer.SetAutoWrapText(false)
+	writer.AppendBulk(rows)
+	writer.Render()
+	fmt.Fprintf(buf, "%d rows of %s", len(rows), qualifiedName(schema, table))
+	fmt.Fprintln(buf, "")
+	return buf.String(), nil
+}
+
+// previewRows returns the columns and the first rows of the table, sampled
+// with a bounded query within the time limit of the preview setting of the
+// connection. It fails unless the connection enables the preview.
+func (s *Server) previewRows(ctx context.Context, schema, table string) ([]string, [][]string, error) {
+	if s.dbConn == nil {
+		return nil, nil, errors.New("database connection is not open")
+	}
+	cfg := s.curDBCfg.Preview
+	if cfg == nil {
+		return nil, nil, errors.New("table preview is disabled, enable it with connections[].preview")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.QueryTimeout())
+	defer cancel()
+	repo, err := s.newDBRepository(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := repo.Query(ctx, database.PreviewQuery(repo.Driver(), schema, table, cfg.RowLimit()))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	columns, err := database.Columns(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+	stringRows, err := database.ScanRows(rows, len(columns))
+	if err != nil {
+		return nil, nil, err
+	}
+	return columns, stringRows, rows.Err()
+}
+
+// markdownTable renders the rows as a Markdown table, escaping the pipes and
+// line breaks of the values.
+func markdownTable(columns []string, rows [][]string) string {
+	escape := strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+	buf := new(bytes.Buffer)
+	writeRow := func(values []string) {
+		for _, v := range values {
+			fmt.Fprintf(buf, "| %s ", escape.Replace(v))
+		}
+		fmt.Fprintln(buf, "|")
+	}
+	writeRow(columns)
+	for range columns {
+		fmt.Fprint(buf, "|---")
+	}
+	fmt.Fprintln(buf, "|")
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return buf.String()
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/internal/config"
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+)
+
+func Test_previewTable(t *testing.T) {
+	tx := newTestContext()
+	tx.setup(t)
+	defer tx.tearDown()
+
+	dbCfg := &database.DBConfig{
+		Driver:         "sqlite3",
+		DataSourceName: filepath.Join(tx.historyDir, "preview.db"),
+		Preview:        &database.PreviewConfig{Rows: 2},
+	}
+	tx.server.WSCfg = &config.Config{
+		Connections: []*database.DBConfig{dbCfg},
+	}
+	if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+		t.Fatal(err)
+	}
+	tx.textDocumentDidOpen(t, testFileURI, "CREATE TABLE city (id INTEGER NOT NULL, name TEXT); INSERT INTO city VALUES (1, 'Kabul'), (2, 'Qandahar|Kandahar'), (3, 'Herat');")
+	var res interface{}
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", lsp.ExecuteCommandParams{
+		Command:   CommandExecuteQuery,
+		Arguments: []interface{}{testFileURI},
+	}, &res); err != nil {
+		t.Fatal("conn.Call workspace/executeCommand:", err)
+	}
+	// refresh the cache to know the columns of the created table
+	if err := tx.server.reconnectionDB(tx.ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	params := lsp.ExecuteCommandParams{
+		Command:   CommandPreviewTable,
+		Arguments: []interface{}{"city"},
+	}
+	var got string
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err != nil {
+		t.Fatal("conn.Call workspace/executeCommand:", err)
+	}
+	want := `+----+-------------------+
+| ID |       NAME        |
++----+-------------------+
+|  1 | Kabul             |
+|  2 | Qandahar|Kandahar |
++----+-------------------+
+2 rows of city
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unmatch preview (- want, + got):\n%s", diff)
+	}
+
+	tx.textDocumentDidOpen(t, testFileURI, "SELECT name FROM city")
+	var hoverRes lsp.Hover
+	if err := tx.conn.Call(tx.ctx, "textDocument/hover", lsp.HoverParams{
+		TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{URI: testFileURI},
+			Position:     lsp.Position{Line: 0, Character: 18},
+		},
+	}, &hoverRes); err != nil {
+		t.Fatal("conn.Call textDocument/hover:", err)
+	}
+	wantSample := "Sample rows:\n\n| id | name |\n|---|---|\n| 1 | Kabul |\n| 2 | Qandahar\\|Kandahar |\n"
+	if !strings.Contains(hoverRes.Contents.Value, wantSample) {
+		t.Errorf("hover must contain the sample rows %q, got %q", wantSample, hoverRes.Contents.Value)
+	}
+
+	dbCfg.Preview = nil
+	if err := tx.conn.Call(tx.ctx, "workspace/executeCommand", params, &got); err == nil {
+		t.Error("preview must fail unless enabled for the connection")
+	}
+}
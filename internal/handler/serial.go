@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type queuedRequest struct {
+	ctx  context.Context
+	conn *jsonrpc2.Conn
+	req  *jsonrpc2.Request
+}
+
+// SerialHandler handles requests one at a time in the order they arrive, on a
+// goroutine of its own. The connection's read loop is never blocked by a
+// running handler, so a handler can send a request to the client (such as
+// window/workDoneProgress/create) and wait for its response.
+//
+// The goroutine stops once the exit notification is handled or the
+// connection is closed, dropping the requests still queued.
+type SerialHandler struct {
+	h jsonrpc2.Handler
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []*queuedRequest
+	started bool
+	stopped bool
+	done    chan struct{}
+}
+
+// NewSerialHandler wraps h so that requests are handled serially without
+// blocking the connection's read loop.
+func NewSerialHandler(h jsonrpc2.Handler) *SerialHandler {
+	sh := &SerialHandler{
+		h:    h,
+		done: make(chan struct{}),
+	}
+	sh.cond = sync.NewCond(&sh.mu)
+	return sh
+}
+
+func (sh *SerialHandler) Handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if sh.stopped {
+		return
+	}
+	if !sh.started {
+		sh.started = true
+		go sh.run()
+		go sh.stopOnDisconnect(conn)
+	}
+	sh.queue = append(sh.queue, &queuedRequest{ctx: ctx, conn: conn, req: req})
+	sh.cond.Signal()
+}
+
+// Close stops handling requests and waits for the running one to return.
+func (sh *SerialHandler) Close() {
+	sh.stop()
+	sh.mu.Lock()
+	started := sh.started
+	sh.mu.Unlock()
+	if started {
+		<-sh.done
+	}
+}
+
+func (sh *SerialHandler) stop() {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.stopped = true
+	sh.queue = nil
+	sh.cond.Broadcast()
+}
+
+func (sh *SerialHandler) stopOnDisconnect(conn *jsonrpc2.Conn) {
+	select {
+	case <-conn.DisconnectNotify():
+		sh.stop()
+	case <-sh.done:
+	}
+}
+
+func (sh *SerialHandler) run() {
+	defer close(sh.done)
+	for {
+		sh.mu.Lock()
+		for len(sh.queue) == 0 && !sh.stopped {
+			sh.cond.Wait()
+		}
+		if sh.stopped {
+			sh.mu.Unlock()
+			return
+		}
+		qr := sh.queue[0]
+		sh.queue[0] = nil
+		sh.queue = sh.queue[1:]
+		sh.mu.Unlock()
+
+		sh.h.Handle(qr.ctx, qr.conn, qr.req)
+		if qr.req.Method == "exit" {
+			sh.stop()
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type recordHandler struct {
+	mu      sync.Mutex
+	methods []string
+}
+
+func (h *recordHandler) Handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.methods = append(h.methods, req.Method)
+}
+
+func (h *recordHandler) handled() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string{}, h.methods...)
+}
+
+func waitClosed(t *testing.T, sh *SerialHandler) {
+	t.Helper()
+	closed := make(chan struct{})
+	go func() {
+		sh.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("serial handler did not stop")
+	}
+}
+
+func TestSerialHandlerStopsOnExit(t *testing.T) {
+	h := &recordHandler{}
+	sh := NewSerialHandler(h)
+	client, server := net.Pipe()
+	connServer := jsonrpc2.NewConn(context.Background(), jsonrpc2.NewBufferedStream(server, jsonrpc2.VSCodeObjectCodec{}), sh)
+	defer connServer.Close()
+	conn := jsonrpc2.NewConn(context.Background(), jsonrpc2.NewBufferedStream(client, jsonrpc2.VSCodeObjectCodec{}), &recordHandler{})
+	defer conn.Close()
+
+	for _, method := range []string{"initialized", "exit"} {
+		if err := conn.Notify(context.Background(), method, nil); err != nil {
+			t.Fatal("conn.Notify:", err)
+		}
+	}
+	select {
+	case <-sh.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("serial handler did not stop after exit")
+	}
+	if err := conn.Notify(context.Background(), "initialized", nil); err != nil {
+		t.Fatal("conn.Notify:", err)
+	}
+	waitClosed(t, sh)
+
+	want := []string{"initialized", "exit"}
+	got := h.handled()
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("handled %v, want %v", got, want)
+	}
+}
+
+func TestSerialHandlerStopsOnDisconnect(t *testing.T) {
+	sh := NewSerialHandler(&recordHandler{})
+	client, server := net.Pipe()
+	connServer := jsonrpc2.NewConn(context.Background(), jsonrpc2.NewBufferedStream(server, jsonrpc2.VSCodeObjectCodec{}), sh)
+	conn := jsonrpc2.NewConn(context.Background(), jsonrpc2.NewBufferedStream(client, jsonrpc2.VSCodeObjectCodec{}), &recordHandler{})
+	if err := conn.Notify(context.Background(), "initialized", nil); err != nil {
+		t.Fatal("conn.Notify:", err)
+	}
+	conn.Close()
+	<-connServer.DisconnectNotify()
+	select {
+	case <-sh.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("serial handler did not stop after disconnect")
+	}
+	waitClosed(t, sh)
+}
+
+func TestSerialHandlerCloseBeforeStart(t *testing.T) {
+	waitClosed(t, NewSerialHandler(&recordHandler{}))
+}
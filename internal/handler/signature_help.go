@@ -4,7 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/lighttiger2505/sqls/ast"
+	"github.com/lighttiger2505/sqls/ast/astutil"
+	"github.com/lighttiger2505/sqls/dialect"
 	"github.com/lighttiger2505/sqls/internal/database"
 	"github.com/lighttiger2505/sqls/internal/lsp"
 	"github.com/lighttiger2505/sqls/parser"
@@ -28,18 +32,18 @@ func (s *Server) handleTextDocumentSignatureHelp(ctx context.Context, conn *json
 		return nil, fmt.Errorf("document not found: %s", params.TextDocument.URI)
 	}
 
-	res, err := SignatureHelp(f.Text, params, s.worker.Cache())
+	var driver dialect.DatabaseDriver
+	if s.dbConn != nil {
+		driver = s.dbConn.Driver
+	}
+	res, err := SignatureHelp(f.Text, params, s.worker.Cache(), driver)
 	if err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func SignatureHelp(text string, params lsp.SignatureHelpParams, dbCache *database.DBCache) (*lsp.SignatureHelp, error) {
-	if dbCache == nil {
-		return nil, nil
-	}
-
+func SignatureHelp(text string, params lsp.SignatureHelpParams, dbCache *database.DBCache, driver dialect.DatabaseDriver) (*lsp.SignatureHelp, error) {
 	parsed, err := parser.Parse(text)
 	if err != nil {
 		return nil, err
@@ -52,8 +56,20 @@ func SignatureHelp(text string, params lsp.SignatureHelpParams, dbCache *databas
 	nodeWalker := parseutil.NewNodeWalker(parsed, pos)
 	types := getSignatureHelpTypes(nodeWalker)
 
+	if signatureHelpIs(types, SignatureHelpTypeFunction) {
+		call := parseutil.ExtractFunctionCall(parsed, pos)
+		if call != nil {
+			if sh := functionSignatureHelp(call, dbCache, driver); sh != nil {
+				return sh, nil
+			}
+		}
+	}
+
 	switch {
 	case signatureHelpIs(types, SignatureHelpTypeInsertValue):
+		if dbCache == nil {
+			return nil, nil
+		}
 		insert, err := parseutil.ExtractInsert(parsed, pos)
 		if err != nil {
 			return nil, err
@@ -101,11 +117,77 @@ func SignatureHelp(text string, params lsp.SignatureHelpParams, dbCache *databas
 	}
 }
 
+// functionSignatureHelp returns the signatures of the called stored routine
+// or built-in function, with the argument under the cursor as the active
+// parameter.
+func functionSignatureHelp(call *parseutil.FunctionCall, dbCache *database.DBCache, driver dialect.DatabaseDriver) *lsp.SignatureHelp {
+	argIdx := call.ArgIndex
+	name := strings.Trim(call.Name, "`\"")
+
+	signatures := []lsp.SignatureInformation{}
+	if dbCache != nil {
+		for _, routine := range dbCache.Routine("", name) {
+			doc := fmt.Sprintf("%s.%s %s", routine.Schema, routine.Name, strings.ToLower(routine.Type))
+			signatures = append(signatures, signatureInformation(routine.Signature(), doc, routine.Arguments))
+		}
+	}
+	for _, builtin := range dialect.LookupFunction(driver, name) {
+		signatures = append(signatures, signatureInformation(builtin.Signature(), builtin.Description, builtin.Arguments))
+	}
+	if len(signatures) == 0 {
+		return nil
+	}
+
+	// the first signature taking the argument is active
+	active := -1
+	for i := range signatures {
+		paramIdx, ok := activeArgument(signatures[i].Parameters, argIdx)
+		signatures[i].ActiveParameter = float64(paramIdx)
+		if ok && active < 0 {
+			active = i
+		}
+	}
+	if active < 0 {
+		active = 0
+	}
+	return &lsp.SignatureHelp{
+		Signatures:      signatures,
+		ActiveSignature: float64(active),
+		ActiveParameter: signatures[active].ActiveParameter,
+	}
+}
+
+func signatureInformation(label, doc string, args []string) lsp.SignatureInformation {
+	params := []lsp.ParameterInformation{}
+	for _, arg := range args {
+		params = append(params, lsp.ParameterInformation{Label: arg})
+	}
+	return lsp.SignatureInformation{
+		Label:         label,
+		Documentation: doc,
+		Parameters:    params,
+	}
+}
+
+// activeArgument returns the parameter for the argument index. Arguments past
+// the last parameter belong to a trailing "...", and ok is false if there is
+// no such parameter.
+func activeArgument(params []lsp.ParameterInformation, argIdx int) (idx int, ok bool) {
+	if argIdx < len(params) {
+		return argIdx, true
+	}
+	if len(params) > 0 && params[len(params)-1].Label == "..." {
+		return len(params) - 1, true
+	}
+	return 0, false
+}
+
 type signatureHelpType int
 
 const (
 	_ signatureHelpType = iota
 	SignatureHelpTypeInsertValue
+	SignatureHelpTypeFunction
 	SignatureHelpTypeUnknown = 99
 )
 
@@ -113,19 +195,26 @@ func (sht signatureHelpType) String() string {
 	switch sht {
 	case SignatureHelpTypeInsertValue:
 		return "InsertValue"
+	case SignatureHelpTypeFunction:
+		return "Function"
 	default:
 		return ""
 	}
 }
 
+var functionLiteralMatcher = astutil.NodeMatcher{
+	NodeTypes: []ast.NodeType{ast.TypeFunctionLiteral},
+}
+
 func getSignatureHelpTypes(nw *parseutil.NodeWalker) []signatureHelpType {
 	syntaxPos := parseutil.CheckSyntaxPosition(nw)
 	types := []signatureHelpType{}
+	if nw.CurNodeIs(functionLiteralMatcher) {
+		types = append(types, SignatureHelpTypeFunction)
+	}
 	switch {
 	case syntaxPos == parseutil.InsertValue:
-		types = []signatureHelpType{
-			SignatureHelpTypeInsertValue,
-		}
+		types = append(types, SignatureHelpTypeInsertValue)
 	default:
 		// pass
 	}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/dialect"
 	"github.com/lighttiger2505/sqls/internal/config"
 	"github.com/lighttiger2505/sqls/internal/database"
 	"github.com/lighttiger2505/sqls/internal/lsp"
@@ -43,6 +44,29 @@ var signatureHelpTestCases = []signatureHelpTestCase{
 	genMultiRecordInsertTest(81, 1),
 	genMultiRecordInsertTest(83, 2),
 	genMultiRecordInsertTest(89, 2),
+
+	// stored function
+	{
+		name:  "stored function",
+		input: "select city_count(Code, ",
+		line:  0,
+		col:   24,
+		want: lsp.SignatureHelp{
+			Signatures: []lsp.SignatureInformation{
+				{
+					Label:         "city_count(country_code char(3), min_population int) RETURNS int",
+					Documentation: "world.city_count function",
+					Parameters: []lsp.ParameterInformation{
+						{Label: "country_code char(3)"},
+						{Label: "min_population int"},
+					},
+					ActiveParameter: 1,
+				},
+			},
+			ActiveSignature: 0.0,
+			ActiveParameter: 1,
+		},
+	},
 }
 
 func genSingleRecordInsertTest(col int, wantActiveParameter int) signatureHelpTestCase {
@@ -183,3 +207,119 @@ func TestSignatureHelpNoneDBConnection(t *testing.T) {
 		})
 	}
 }
+
+func TestFunctionSignatureHelp(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		driver          dialect.DatabaseDriver
+		wantLabels      []string
+		wantSignature   float64
+		wantParameter   float64
+		wantNoSignature bool
+	}{
+		{
+			name:          "first argument",
+			input:         "select date_format(",
+			driver:        dialect.DatabaseDriverMySQL,
+			wantLabels:    []string{"DATE_FORMAT(date, format) RETURNS varchar"},
+			wantParameter: 0,
+		},
+		{
+			name:          "argument after nested function",
+			input:         "select date_format(now(), ",
+			driver:        dialect.DatabaseDriverMySQL,
+			wantLabels:    []string{"DATE_FORMAT(date, format) RETURNS varchar"},
+			wantParameter: 1,
+		},
+		{
+			name:          "third argument",
+			input:         "select substring_index(name, ',', ",
+			driver:        dialect.DatabaseDriverMySQL8,
+			wantLabels:    []string{"SUBSTRING_INDEX(str, delim, count) RETURNS varchar"},
+			wantParameter: 2,
+		},
+		{
+			name:          "innermost function",
+			input:         "select left(concat(name, ",
+			driver:        dialect.DatabaseDriverMySQL,
+			wantLabels:    []string{"CONCAT(str1, str2, ...) RETURNS varchar"},
+			wantParameter: 1,
+		},
+		{
+			name:          "repeated argument",
+			input:         "select concat(a, b, c, ",
+			driver:        dialect.DatabaseDriverMySQL,
+			wantLabels:    []string{"CONCAT(str1, str2, ...) RETURNS varchar"},
+			wantParameter: 2,
+		},
+		{
+			name:   "overload taking the argument",
+			input:  "select log(2, ",
+			driver: dialect.DatabaseDriverPostgreSQL,
+			wantLabels: []string{
+				"LOG(x numeric) RETURNS numeric",
+				"LOG(b numeric, x numeric) RETURNS numeric",
+			},
+			wantSignature: 1,
+			wantParameter: 1,
+		},
+		{
+			name:          "in where",
+			input:         "select * from city where substr(name, 1, ",
+			driver:        dialect.DatabaseDriverSQLite3,
+			wantLabels:    []string{"SUBSTR(X, Y, [Z]) RETURNS text"},
+			wantParameter: 2,
+		},
+		{
+			name:            "after the call",
+			input:           "select left(name, 2) ",
+			driver:          dialect.DatabaseDriverMySQL,
+			wantNoSignature: true,
+		},
+		{
+			name:            "unknown function",
+			input:           "select my_func(",
+			driver:          dialect.DatabaseDriverMySQL,
+			wantNoSignature: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := lsp.SignatureHelpParams{
+				TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+					Position: lsp.Position{
+						Line:      0,
+						Character: len(tt.input),
+					},
+				},
+			}
+			got, err := SignatureHelp(tt.input, params, nil, tt.driver)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantNoSignature {
+				if got != nil {
+					t.Errorf("unexpected signature help, %+v", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("signature help not found")
+			}
+			labels := []string{}
+			for _, sig := range got.Signatures {
+				labels = append(labels, sig.Label)
+			}
+			if diff := cmp.Diff(tt.wantLabels, labels); diff != "" {
+				t.Errorf("unmatch signatures (- want, + got):\n%s", diff)
+			}
+			if got.ActiveSignature != tt.wantSignature {
+				t.Errorf("active signature = %v, want %v", got.ActiveSignature, tt.wantSignature)
+			}
+			if got.ActiveParameter != tt.wantParameter {
+				t.Errorf("active parameter = %v, want %v", got.ActiveParameter, tt.wantParameter)
+			}
+		})
+	}
+}
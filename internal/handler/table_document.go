@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/lighttiger2505/sqls/internal/database"
+	"github.com/lighttiger2505/sqls/internal/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+// tableDocumentScheme is the scheme of the virtual documents holding the
+// CREATE statement of a table, sqls://<connection>/<schema>/<table>.sql
+const tableDocumentScheme = "sqls"
+
+// connectionName names the current connection in virtual document URIs.
+func (s *Server) connectionName() string {
+	if s.curDBCfg == nil {
+		return ""
+	}
+	if s.curDBCfg.Alias != "" {
+		return s.curDBCfg.Alias
+	}
+	return string(s.curDBCfg.Driver)
+}
+
+// tableDocumentURI returns the URI of the virtual document of the table. The
+// schema segment is left out for databases without schemas.
+func (s *Server) tableDocumentURI(schema, table string) string {
+	segments := []string{url.PathEscape(s.connectionName())}
+	if schema != "" {
+		segments = append(segments, url.PathEscape(schema))
+	}
+	segments = append(segments, url.PathEscape(table)+".sql")
+	return tableDocumentScheme + "://" + strings.Join(segments, "/")
+}
+
+// parseTableDocumentURI returns the connection, schema and table of a virtual
+// document URI.
+func parseTableDocumentURI(uri string) (connection, schema, table string, err error) {
+	prefix := tableDocumentScheme + "://"
+	if !strings.HasPrefix(uri, prefix) || !strings.HasSuffix(uri, ".sql") {
+		return "", "", "", fmt.Errorf("invalid table document uri, %q", uri)
+	}
+	segments := strings.Split(strings.TrimSuffix(strings.TrimPrefix(uri, prefix), ".sql"), "/")
+	for i, segment := range segments {
+		if segments[i], err = url.PathUnescape(segment); err != nil {
+			return "", "", "", fmt.Errorf("invalid table document uri, %q", uri)
+		}
+	}
+	switch len(segments) {
+	case 2:
+		return segments[0], "", segments[1], nil
+	case 3:
+		return segments[0], segments[1], segments[2], nil
+	}
+	return "", "", "", fmt.Errorf("invalid table document uri, %q", uri)
+}
+
+// splitTableName splits a "[schema.]table" argument of a command.
+func splitTableName(name string) (schema, table string) {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// tableColumns returns the cached columns of the "[schema.]table" named by a
+// command argument.
+func (s *Server) tableColumns(name string) ([]*database.ColumnDesc, error) {
+	dbCache := s.worker.Cache()
+	if dbCache == nil {
+		return nil, errors.New("database cache is not ready")
+	}
+	schema, table := splitTableName(name)
+
+	var cols []*database.ColumnDesc
+	var ok bool
+	if schema == "" {
+		cols, ok = dbCache.ColumnDescs(table)
+	} else {
+		cols, ok = dbCache.ColumnDatabase(schema, table)
+	}
+	if !ok || len(cols) == 0 {
+		return nil, fmt.Errorf("table not found, %q", name)
+	}
+	return cols, nil
+}
+
+// createTableStatement returns the CREATE statement of the table on the
+// current connection.
+func (s *Server) createTableStatement(ctx context.Context, schema, table string) (string, error) {
+	if s.dbConn == nil {
+		return "", errors.New("database connection is not open")
+	}
+	repo, err := s.newDBRepository(ctx)
+	if err != nil {
+		return "", err
+	}
+	return repo.CreateTableStatement(ctx, schema, table)
+}
+
+func (s *Server) showCreateTable(ctx context.Context, params lsp.ExecuteCommandParams) (result interface{}, err error) {
+	if len(params.Arguments) == 0 {
+		return nil, fmt.Errorf("required arguments were not provided: <Table>")
+	}
+	name, ok := params.Arguments[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("specify the table as a string")
+	}
+	schema, table := splitTableName(name)
+	stmt, err := s.createTableStatement(ctx, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	return stmt + "\n", nil
+}
+
+// handleVirtualTextDocument returns the content of a sqls:// document, for
+// clients following the locations of go-to-definition or the links of hover.
+func (s *Server) handleVirtualTextDocument(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (result interface{}, err error) {
+	if req.Params == nil {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams}
+	}
+
+	var params lsp.VirtualTextDocumentParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+
+	connection, schema, table, err := parseTableDocumentURI(params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	if connection != s.connectionName() {
+		return nil, fmt.Errorf("connection %q is not the current connection, switch to it first", connection)
+	}
+	stmt, err := s.createTableStatement(ctx, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	return stmt + "\n", nil
+}
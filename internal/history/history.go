@@ -0,0 +1,196 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// Entry is a statement executed by the user.
+type Entry struct {
+	Alias    string        `json:"alias"`
+	DBName   string        `json:"dbName"`
+	Query    string        `json:"query"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"`
+	RowCount int64         `json:"rowCount"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// MaxEntries is the number of latest entries kept by the history.
+const MaxEntries = 1000
+
+// Store keeps the query history in a file of JSON lines, one entry per line.
+// Entries are loaded on first use and kept in memory afterwards. Only the
+// latest MaxEntries entries are kept, the file is rewritten with them once it
+// has grown to twice as many lines.
+type Store struct {
+	path  string
+	limit int
+
+	mu      sync.Mutex
+	loaded  bool
+	entries []*Entry
+	// lines is the number of entries in the file, including the dropped ones
+	lines int
+}
+
+func NewStore(path string) *Store {
+	return &Store{path: path, limit: MaxEntries}
+}
+
+// Append adds the entry to the end of the history file.
+func (s *Store) Append(entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return xerrors.Errorf("cannot marshal history entry, %+v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return xerrors.Errorf("cannot create history directory, %+v", err)
+	}
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return xerrors.Errorf("cannot open history, %+v", err)
+	}
+	_, err = file.Write(append(b, '\n'))
+	file.Close()
+	if err != nil {
+		return xerrors.Errorf("cannot write history, %+v", err)
+	}
+
+	s.entries = append(s.entries, entry)
+	s.lines++
+	if len(s.entries) > s.limit {
+		s.entries = s.entries[len(s.entries)-s.limit:]
+	}
+	if s.lines >= 2*s.limit {
+		return s.compact()
+	}
+	return nil
+}
+
+// Entries returns the entries executed on the connection of the alias, oldest
+// first.
+func (s *Store) Entries(alias string) ([]*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	entries := []*Entry{}
+	for _, entry := range s.entries {
+		if entry.Alias == alias {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// Queries returns the distinct queries executed on the connection of the
+// alias, most recent first.
+func (s *Store) Queries(alias string) ([]string, error) {
+	entries, err := s.Entries(alias)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	queries := []string{}
+	for i := len(entries) - 1; i >= 0; i-- {
+		query := entries[i].Query
+		if seen[query] {
+			continue
+		}
+		seen[query] = true
+		queries = append(queries, query)
+	}
+	return queries, nil
+}
+
+func (s *Store) load() error {
+	if s.loaded {
+		return nil
+	}
+
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		s.loaded = true
+		return nil
+	}
+	if err != nil {
+		return xerrors.Errorf("cannot open history, %+v", err)
+	}
+	defer file.Close()
+
+	entries := []*Entry{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		entry := &Entry{}
+		if err := json.Unmarshal([]byte(line), entry); err != nil {
+			// A broken line must not make the rest of the history unreadable
+			log.Println("skip broken history entry,", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return xerrors.Errorf("cannot read history, %+v", err)
+	}
+
+	s.lines = len(entries)
+	if len(entries) > s.limit {
+		entries = entries[len(entries)-s.limit:]
+	}
+	s.entries = entries
+	s.loaded = true
+	return nil
+}
+
+// compact rewrites the history file with the entries kept in memory.
+func (s *Store) compact() error {
+	tmp := s.path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return xerrors.Errorf("cannot create history, %+v", err)
+	}
+	w := bufio.NewWriter(file)
+	for _, entry := range s.entries {
+		b, err := json.Marshal(entry)
+		if err != nil {
+			file.Close()
+			return xerrors.Errorf("cannot marshal history entry, %+v", err)
+		}
+		w.Write(append(b, '\n'))
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return xerrors.Errorf("cannot write history, %+v", err)
+	}
+	if err := file.Close(); err != nil {
+		return xerrors.Errorf("cannot write history, %+v", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return xerrors.Errorf("cannot replace history, %+v", err)
+	}
+	s.lines = len(s.entries)
+	return nil
+}
@@ -0,0 +1,112 @@
+package history
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqls-history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "sqls", "history.jsonl")
+
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	entries := []*Entry{
+		{Alias: "staging", DBName: "world", Query: "SELECT * FROM city", Time: now, Duration: time.Second, RowCount: 10},
+		{Alias: "staging", DBName: "world", Query: "SELECT * FROM country", Time: now.Add(time.Minute), RowCount: 3},
+		{Alias: "production", DBName: "world", Query: "SELECT * FROM city", Time: now.Add(2 * time.Minute), Error: "no such table: city"},
+	}
+
+	store := NewStore(path)
+	for _, entry := range entries {
+		if err := store.Append(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A new store reads the entries written by the previous one
+	got, err := NewStore(path).Entries("staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(entries[:2], got); diff != "" {
+		t.Errorf("unmatch entries (- want, + got):\n%s", diff)
+	}
+
+	queries, err := store.Queries("staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"SELECT * FROM country", "SELECT * FROM city"}, queries); diff != "" {
+		t.Errorf("unmatch queries (- want, + got):\n%s", diff)
+	}
+}
+
+func TestStore_Limit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqls-history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "history.jsonl")
+
+	store := NewStore(path)
+	store.limit = 3
+	for i := 0; i < 7; i++ {
+		entry := &Entry{Alias: "staging", Query: fmt.Sprintf("SELECT %d", i)}
+		if err := store.Append(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	queries := func(store *Store) []string {
+		entries, err := store.Entries("staging")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := []string{}
+		for _, entry := range entries {
+			got = append(got, entry.Query)
+		}
+		return got
+	}
+	want := []string{"SELECT 4", "SELECT 5", "SELECT 6"}
+	if diff := cmp.Diff(want, queries(store)); diff != "" {
+		t.Errorf("unmatch entries (- want, + got):\n%s", diff)
+	}
+
+	// The file was rewritten at the sixth entry and has grown by one since
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(b), "\n"); lines != 4 {
+		t.Errorf("want 4 lines in the history file, got %d", lines)
+	}
+
+	reloaded := NewStore(path)
+	reloaded.limit = 3
+	if diff := cmp.Diff(want, queries(reloaded)); diff != "" {
+		t.Errorf("unmatch reloaded entries (- want, + got):\n%s", diff)
+	}
+}
+
+func TestStore_NotExist(t *testing.T) {
+	got, err := NewStore(filepath.Join(os.TempDir(), "sqls-not-exist", "history.jsonl")).Entries("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("want no entries, got %d", len(got))
+	}
+}
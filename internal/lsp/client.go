@@ -2,7 +2,9 @@ package lsp
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sync/atomic"
 
 	"github.com/sourcegraph/jsonrpc2"
 )
@@ -12,6 +14,8 @@ type Messenger interface {
 	ShowInfo(context.Context, string) error
 	ShowWarning(context.Context, string) error
 	ShowError(context.Context, string) error
+	ShowMessageRequest(context.Context, MessageType, string, ...string) (*MessageActionItem, error)
+	ShowInputRequest(context.Context, string) (*string, error)
 }
 
 type LspMessenger struct {
@@ -59,3 +63,101 @@ func (m *LspMessenger) ShowError(ctx context.Context, message string) error {
 	}
 	return m.conn.Notify(ctx, "window/showMessage", params)
 }
+
+// ShowMessageRequest asks the user to choose one of the actions. The returned
+// item is nil if the request was dismissed without a choice.
+func (m *LspMessenger) ShowMessageRequest(ctx context.Context, messageType MessageType, message string, actions ...string) (*MessageActionItem, error) {
+	log.Println("Send Message Request:", message)
+	params := &ShowMessageRequestParams{
+		Type:    messageType,
+		Message: message,
+	}
+	for _, action := range actions {
+		params.Actions = append(params.Actions, MessageActionItem{Title: action})
+	}
+	var item *MessageActionItem
+	if err := m.conn.Call(ctx, "window/showMessageRequest", params, &item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// ShowInputRequest asks the user to enter a value with sqls/showInputRequest.
+// The returned value is nil if the input was canceled.
+func (m *LspMessenger) ShowInputRequest(ctx context.Context, prompt string) (*string, error) {
+	log.Println("Send Input Request:", prompt)
+	params := &ShowInputRequestParams{
+		Prompt: prompt,
+	}
+	var value *string
+	if err := m.conn.Call(ctx, "sqls/showInputRequest", params, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+var progressTokenCount int64
+
+// WorkDoneProgress reports the progress of a long running operation to the
+// client. A nil *WorkDoneProgress reports nothing.
+type WorkDoneProgress struct {
+	conn  *jsonrpc2.Conn
+	token interface{}
+}
+
+// NewWorkDoneProgress returns a reporter for the token given by the client.
+// If the client did not give a token and create is true, a new token is
+// requested with window/workDoneProgress/create.
+func NewWorkDoneProgress(ctx context.Context, conn *jsonrpc2.Conn, token interface{}, create bool) *WorkDoneProgress {
+	if token == nil {
+		if !create {
+			return nil
+		}
+		token = fmt.Sprintf("sqls-%d", atomic.AddInt64(&progressTokenCount, 1))
+		params := &WorkDoneProgressCreateParams{
+			Token: token,
+		}
+		if err := conn.Call(ctx, "window/workDoneProgress/create", params, nil); err != nil {
+			log.Println("cannot create work done progress,", err)
+			return nil
+		}
+	}
+	return &WorkDoneProgress{
+		conn:  conn,
+		token: token,
+	}
+}
+
+func (p *WorkDoneProgress) Begin(ctx context.Context, title, message string) error {
+	return p.notify(ctx, &WorkDoneProgressBegin{
+		Kind:    "begin",
+		Title:   title,
+		Message: message,
+	})
+}
+
+func (p *WorkDoneProgress) Report(ctx context.Context, message string, percentage int) error {
+	return p.notify(ctx, &WorkDoneProgressReport{
+		Kind:       "report",
+		Message:    message,
+		Percentage: percentage,
+	})
+}
+
+func (p *WorkDoneProgress) End(ctx context.Context, message string) error {
+	return p.notify(ctx, &WorkDoneProgressEnd{
+		Kind:    "end",
+		Message: message,
+	})
+}
+
+func (p *WorkDoneProgress) notify(ctx context.Context, value interface{}) error {
+	if p == nil {
+		return nil
+	}
+	params := &ProgressParams{
+		Token: p.token,
+		Value: value,
+	}
+	return p.conn.Notify(ctx, "$/progress", params)
+}
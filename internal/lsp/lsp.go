@@ -17,6 +17,22 @@ type InitializeOptions struct {
 }
 
 type ClientCapabilities struct {
+	Window       WindowClientCapabilities       `json:"window,omitempty"`
+	TextDocument TextDocumentClientCapabilities `json:"textDocument,omitempty"`
+}
+
+type TextDocumentClientCapabilities struct {
+	Completion CompletionClientCapabilities `json:"completion,omitempty"`
+}
+
+type CompletionClientCapabilities struct {
+	CompletionItem struct {
+		SnippetSupport bool `json:"snippetSupport,omitempty"`
+	} `json:"completionItem,omitempty"`
+}
+
+type WindowClientCapabilities struct {
+	WorkDoneProgress bool `json:"workDoneProgress,omitempty"`
 }
 
 type InitializeResult struct {
@@ -246,6 +262,18 @@ type TextDocumentPositionParams struct {
 
 type CodeActionKind string
 
+type DefinitionParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+	PartialResultParams
+}
+
+// VirtualTextDocumentParams is the parameter of the sqls specific
+// sqls/virtualTextDocument request.
+type VirtualTextDocumentParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
 type Location struct {
 	URI   string `json:"uri"`
 	Range Range  `json:"range"`
@@ -291,6 +319,8 @@ type CodeActionParams struct {
 
 type ExecuteCommandParams struct {
 	WorkDoneProgressParams
+	// sqls specific option for receiving the result of each statement as soon as it is ready
+	PartialResultParams
 
 	Command   string        `json:"command"`
 	Arguments []interface{} `json:"arguments,omitempty"`
@@ -322,6 +352,39 @@ type WorkDoneProgressOptions struct {
 	WorkDoneProgress bool `json:"workDoneProgress,omitempty"`
 }
 
+// https://microsoft.github.io/language-server-protocol/specifications/specification-3-16/#progress
+
+type ProgressParams struct {
+	Token interface{} `json:"token"` // integer | string
+	Value interface{} `json:"value"`
+}
+
+// https://microsoft.github.io/language-server-protocol/specifications/specification-3-16/#window_workDoneProgress_create
+
+type WorkDoneProgressCreateParams struct {
+	Token interface{} `json:"token"` // integer | string
+}
+
+type WorkDoneProgressBegin struct {
+	Kind        string `json:"kind"`
+	Title       string `json:"title"`
+	Cancellable bool   `json:"cancellable,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Percentage  int    `json:"percentage"`
+}
+
+type WorkDoneProgressReport struct {
+	Kind        string `json:"kind"`
+	Cancellable bool   `json:"cancellable,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Percentage  int    `json:"percentage,omitempty"`
+}
+
+type WorkDoneProgressEnd struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message,omitempty"`
+}
+
 type FormattingOptions struct {
 	TabSize                float64 `json:"tabSize"`
 	InsertSpaces           bool    `json:"insertSpaces"`
@@ -411,6 +474,12 @@ type MessageActionItem struct {
 	Title string `json:"title"`
 }
 
+// ShowInputRequestParams is the parameter of sqls/showInputRequest, a sqls
+// specific request asking the user to enter a value.
+type ShowInputRequestParams struct {
+	Prompt string `json:"prompt"`
+}
+
 type MessageType float64
 
 var (
@@ -72,7 +72,7 @@ func main() {
 			log.Println(err)
 		}
 	}()
-	h := jsonrpc2.HandlerWithError(server.Handle)
+	h := handler.NewSerialHandler(jsonrpc2.HandlerWithError(server.Handle))
 
 	// Load specific config
 	if configFile != "" {
@@ -104,6 +104,7 @@ func main() {
 		h,
 		connOpt...,
 	).DisconnectNotify()
+	h.Close()
 	log.Println("sqls: connections closed")
 }
 
@@ -54,8 +54,14 @@ func parseInfixGroup(reader *astutil.NodeReader, matcher astutil.NodeMatcher, ig
 }
 
 func Parse(text string) (ast.TokenList, error) {
+	return ParseDialect(text, &dialect.GenericSQLDialect{})
+}
+
+// ParseDialect parses the text with the dialect, such as the one of the
+// database the text is executed on.
+func ParseDialect(text string, d dialect.Dialect) (ast.TokenList, error) {
 	src := bytes.NewBuffer([]byte(text))
-	p, err := NewParser(src, &dialect.GenericSQLDialect{})
+	p, err := NewParser(src, d)
 	if err != nil {
 		return nil, err
 	}
@@ -332,6 +338,7 @@ var operatorTargetMatcher = astutil.NodeMatcher{
 	ExpectTokens: []token.Kind{
 		token.Number,
 		token.Char,
+		token.Placeholder,
 		token.SingleQuotedString,
 		token.NationalStringLiteral,
 	},
@@ -408,6 +415,7 @@ var comparisonTargetMatcher = astutil.NodeMatcher{
 	ExpectTokens: []token.Kind{
 		token.Number,
 		token.Char,
+		token.Placeholder,
 		token.SingleQuotedString,
 		token.NationalStringLiteral,
 	},
@@ -538,6 +546,7 @@ var identifierListTargetMatcher = astutil.NodeMatcher{
 	ExpectTokens: []token.Kind{
 		token.Number,
 		token.Char,
+		token.Placeholder,
 		token.SingleQuotedString,
 		token.NationalStringLiteral,
 	},
@@ -222,6 +222,33 @@ func parseInsertValues(reader *astutil.NodeReader) []ast.Node {
 	return []ast.Node{identList}
 }
 
+// ExtractPlaceholders returns the bind parameter placeholders such as ?, $1,
+// :name and @name in order of appearance.
+func ExtractPlaceholders(parsed ast.TokenList) []string {
+	placeholders := []string{}
+	for _, tok := range ExtractPlaceholderTokens(parsed) {
+		placeholders = append(placeholders, tok.String())
+	}
+	return placeholders
+}
+
+// ExtractPlaceholderTokens returns the tokens of the placeholders in order of
+// appearance, so that they can be rewritten.
+func ExtractPlaceholderTokens(parsed ast.TokenList) []*ast.SQLToken {
+	matcher := astutil.NodeMatcher{
+		ExpectTokens: []token.Kind{
+			token.Placeholder,
+		},
+	}
+	toks := []*ast.SQLToken{}
+	for _, node := range astutil.NewNodeReader(parsed).FindRecursive(matcher) {
+		if tok, ok := node.(ast.Token); ok {
+			toks = append(toks, tok.GetToken())
+		}
+	}
+	return toks
+}
+
 func filterPrefixGroup(reader *astutil.NodeReader, prefixMatcher astutil.NodeMatcher, peekMatcher astutil.NodeMatcher) []ast.Node {
 	var results []ast.Node
 	for reader.NextNode(false) {
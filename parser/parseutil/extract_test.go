@@ -3,6 +3,9 @@ package parseutil
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/dialect"
+	"github.com/lighttiger2505/sqls/parser"
 	"github.com/lighttiger2505/sqls/token"
 )
 
@@ -346,3 +349,49 @@ func TestExtractInsertValues(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractPlaceholders(t *testing.T) {
+	testcases := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "none",
+			input: "SELECT ID, Name FROM city WHERE ID = 1",
+			want:  []string{},
+		},
+		{
+			name:  "question",
+			input: "SELECT ID, Name FROM city WHERE ID = ? AND Name = ?",
+			want:  []string{"?", "?"},
+		},
+		{
+			name:  "numbered",
+			input: "SELECT ID, Name FROM city WHERE ID = $1 OR ID = $2 + $1",
+			want:  []string{"$1", "$2", "$1"},
+		},
+		{
+			name:  "named",
+			input: "insert into city (ID, Name) VALUES (:id, @name)",
+			want:  []string{":id", "@name"},
+		},
+		{
+			name:  "not placeholder",
+			input: "SELECT ID::text, '?' FROM city",
+			want:  []string{},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := parser.ParseDialect(tt.input, dialect.DatabaseDialect(dialect.DatabaseDriverSQLite3))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ExtractPlaceholders(query)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch placeholders (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
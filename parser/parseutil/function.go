@@ -0,0 +1,86 @@
+package parseutil
+
+import (
+	"strings"
+
+	"github.com/lighttiger2505/sqls/ast"
+	"github.com/lighttiger2505/sqls/ast/astutil"
+	"github.com/lighttiger2505/sqls/token"
+)
+
+// FunctionCall is a call of a function with the index of the argument at a
+// position.
+type FunctionCall struct {
+	Name     string
+	ArgIndex int
+}
+
+var functionLiteralMatcher = astutil.NodeMatcher{
+	NodeTypes: []ast.NodeType{ast.TypeFunctionLiteral},
+}
+
+// ExtractFunctionCall returns the innermost function call whose arguments
+// enclose the position, or nil if there is none. The calls nested in an
+// unclosed parenthesis are not parsed as function literals, so they are
+// followed by the parentheses and commas before the position.
+func ExtractFunctionCall(parsed ast.TokenList, pos token.Pos) *FunctionCall {
+	nw := NewNodeWalker(parsed, pos)
+	if !nw.CurNodeIs(functionLiteralMatcher) {
+		return nil
+	}
+	fn := nw.CurNodeButtomMatched(functionLiteralMatcher).(*ast.FunctionLiteral)
+	paren, ok := fn.Toks[len(fn.Toks)-1].(*ast.Parenthesis)
+	if !ok || 0 >= token.ComparePos(pos, paren.Pos()) {
+		return nil
+	}
+	toks := paren.Toks[1:]
+	if paren.Toks[len(paren.Toks)-1].String() == ")" {
+		if 0 <= token.ComparePos(pos, paren.End()) {
+			return nil
+		}
+		toks = toks[:len(toks)-1]
+	}
+
+	calls := []*FunctionCall{{Name: fn.Toks[0].String()}}
+	var prev ast.Node
+	for _, tok := range flattenIdentiferList(toks) {
+		if 0 <= token.ComparePos(tok.Pos(), pos) {
+			break
+		}
+		switch tok.String() {
+		case "(":
+			// a parenthesis not following a name is a grouping
+			name := ""
+			if prev != nil && prev.String() != "," && prev.String() != "(" {
+				name = prev.String()
+			}
+			calls = append(calls, &FunctionCall{Name: name})
+		case ")":
+			if len(calls) > 1 {
+				calls = calls[:len(calls)-1]
+			}
+		case ",":
+			calls[len(calls)-1].ArgIndex++
+		}
+		if strings.TrimSpace(tok.String()) != "" {
+			prev = tok
+		}
+	}
+	call := calls[len(calls)-1]
+	if call.Name == "" {
+		return nil
+	}
+	return call
+}
+
+func flattenIdentiferList(toks []ast.Node) []ast.Node {
+	flat := []ast.Node{}
+	for _, tok := range toks {
+		if list, ok := tok.(*ast.IdentiferList); ok {
+			flat = append(flat, flattenIdentiferList(list.Toks)...)
+			continue
+		}
+		flat = append(flat, tok)
+	}
+	return flat
+}
@@ -0,0 +1,85 @@
+package parseutil
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/parser"
+	"github.com/lighttiger2505/sqls/token"
+)
+
+func TestExtractFunctionCall(t *testing.T) {
+	testcases := []struct {
+		name  string
+		input string
+		col   int
+		want  *FunctionCall
+	}{
+		{
+			name:  "first argument",
+			input: "select left(",
+			col:   12,
+			want:  &FunctionCall{Name: "left", ArgIndex: 0},
+		},
+		{
+			name:  "second argument",
+			input: "select left(name, 3) from city",
+			col:   18,
+			want:  &FunctionCall{Name: "left", ArgIndex: 1},
+		},
+		{
+			name:  "before the comma",
+			input: "select left(name, 3) from city",
+			col:   16,
+			want:  &FunctionCall{Name: "left", ArgIndex: 0},
+		},
+		{
+			name:  "after closed nested call",
+			input: "select date_format(now(), ",
+			col:   26,
+			want:  &FunctionCall{Name: "date_format", ArgIndex: 1},
+		},
+		{
+			name:  "in unclosed nested call",
+			input: "select left(concat(name, ",
+			col:   25,
+			want:  &FunctionCall{Name: "concat", ArgIndex: 1},
+		},
+		{
+			name:  "after nested call in unclosed call",
+			input: "select left(concat(name, 'a'), ",
+			col:   31,
+			want:  &FunctionCall{Name: "left", ArgIndex: 1},
+		},
+		{
+			name:  "grouping parenthesis",
+			input: "select coalesce((a), ",
+			col:   21,
+			want:  &FunctionCall{Name: "coalesce", ArgIndex: 1},
+		},
+		{
+			name:  "on the name",
+			input: "select left(name, 3) from city",
+			col:   9,
+			want:  nil,
+		},
+		{
+			name:  "after the call",
+			input: "select left(name, 3) from city",
+			col:   20,
+			want:  nil,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parser.Parse(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ExtractFunctionCall(parsed, token.Pos{Line: 0, Col: tt.col})
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch function call (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
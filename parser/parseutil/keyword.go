@@ -0,0 +1,94 @@
+package parseutil
+
+import (
+	"strings"
+
+	"github.com/lighttiger2505/sqls/ast"
+	"github.com/lighttiger2505/sqls/token"
+)
+
+// WordPhrase is a run of consecutive words of a statement, such as
+// "ON CONFLICT".
+type WordPhrase struct {
+	Words string
+	From  token.Pos
+	To    token.Pos
+}
+
+// ExtractWordPhrases returns the phrases of up to maxWords words which
+// contain the word whose characters include the position, the longest first.
+// The words are joined by a space, and a phrase does not span punctuation or
+// quoted identifiers.
+func ExtractWordPhrases(parsed ast.TokenList, pos token.Pos, maxWords int) []*WordPhrase {
+	nw := NewNodeWalker(parsed, pos)
+	if len(nw.Paths) == 0 {
+		return nil
+	}
+	stmt := nw.Paths[0].CurNode
+	toks := leafTokens(stmt)
+
+	cur := -1
+	for i, tok := range toks {
+		if isWord(tok) && 0 <= token.ComparePos(pos, tok.Pos()) && 0 > token.ComparePos(pos, tok.End()) {
+			cur = i
+			break
+		}
+	}
+	if cur < 0 {
+		return nil
+	}
+
+	phrases := []*WordPhrase{}
+	for n := maxWords; n > 0; n-- {
+		for start := cur - n + 1; start <= cur; start++ {
+			end := start + n
+			if start < 0 || end > len(toks) || !allWords(toks[start:end]) {
+				continue
+			}
+			words := make([]string, n)
+			for i, tok := range toks[start:end] {
+				words[i] = tok.String()
+			}
+			phrases = append(phrases, &WordPhrase{
+				Words: strings.Join(words, " "),
+				From:  toks[start].Pos(),
+				To:    toks[end-1].End(),
+			})
+		}
+	}
+	return phrases
+}
+
+// leafTokens returns the tokens of the node in order without the whitespaces.
+func leafTokens(node ast.Node) []ast.Node {
+	list, ok := node.(ast.TokenList)
+	if !ok {
+		if tok, ok := node.(ast.Token); ok && tok.GetToken().MatchKind(token.Whitespace) {
+			return nil
+		}
+		return []ast.Node{node}
+	}
+	results := []ast.Node{}
+	for _, child := range list.GetTokens() {
+		results = append(results, leafTokens(child)...)
+	}
+	return results
+}
+
+func isWord(node ast.Node) bool {
+	tok, ok := node.(ast.Token)
+	if !ok || !tok.GetToken().MatchKind(token.SQLKeyword) {
+		return false
+	}
+	word, ok := tok.GetToken().Value.(*token.SQLWord)
+	return ok && word.QuoteStyle == 0
+}
+
+func allWords(nodes []ast.Node) bool {
+	for _, node := range nodes {
+		if !isWord(node) {
+			return false
+		}
+	}
+	return true
+}
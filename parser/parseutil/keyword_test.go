@@ -0,0 +1,77 @@
+package parseutil
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/sqls/parser"
+	"github.com/lighttiger2505/sqls/token"
+)
+
+func TestExtractWordPhrases(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		col      int
+		maxWords int
+		want     []string
+	}{
+		{
+			name:     "single word",
+			input:    "SELECT Name FROM city",
+			col:      2,
+			maxWords: 2,
+			want:     []string{"SELECT Name", "SELECT"},
+		},
+		{
+			name:     "surrounding words",
+			input:    "SELECT Name FROM city",
+			col:      13,
+			maxWords: 2,
+			want:     []string{"Name FROM", "FROM city", "FROM"},
+		},
+		{
+			name:     "multiple keyword",
+			input:    "SELECT CountryCode FROM city GROUP BY CountryCode",
+			col:      36,
+			maxWords: 2,
+			want:     []string{"GROUP BY", "BY CountryCode", "BY"},
+		},
+		{
+			name:     "stop at punctuation",
+			input:    "INSERT INTO city (ID) VALUES (1) ON CONFLICT (ID) DO NOTHING",
+			col:      37,
+			maxWords: 3,
+			want:     []string{"ON CONFLICT", "CONFLICT"},
+		},
+		{
+			name:     "quoted identifier",
+			input:    "SELECT `Name` FROM city",
+			col:      9,
+			maxWords: 2,
+			want:     []string{},
+		},
+		{
+			name:     "whitespace",
+			input:    "SELECT Name FROM city",
+			col:      11,
+			maxWords: 2,
+			want:     []string{},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parser.Parse(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, phrase := range ExtractWordPhrases(parsed, token.Pos{Line: 0, Col: tt.col}, tt.maxWords) {
+				got = append(got, phrase.Words)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatch phrases (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -194,6 +194,33 @@ func ExtractTable(parsed ast.TokenList, pos token.Pos) ([]*TableInfo, error) {
 	return extractTableIdentifier(list, false)
 }
 
+// ExtractJoinedTable returns the table of the last JOIN clause before the
+// position, the table that an ON condition at the position is written for.
+func ExtractJoinedTable(parsed ast.TokenList, pos token.Pos) (*TableInfo, error) {
+	stmt, err := extractFocusedStatement(parsed, pos)
+	if err != nil {
+		return nil, err
+	}
+	list := stmt
+	if encloseIsSubQuery(stmt, pos) {
+		list = extractFocusedSubQuery(stmt, pos)
+	}
+	var joined ast.Node
+	for _, node := range ExtractTableFactor(list) {
+		if token.ComparePos(node.End(), pos) <= 0 {
+			joined = node
+		}
+	}
+	if joined == nil {
+		return nil, nil
+	}
+	infos, err := parseTableInfo(joined)
+	if err != nil || len(infos) == 0 {
+		return nil, err
+	}
+	return infos[0], nil
+}
+
 var identifierMatcher = astutil.NodeMatcher{
 	NodeTypes: []ast.NodeType{
 		ast.TypeIdentifer,
@@ -813,6 +813,52 @@ func TestExtractTable(t *testing.T) {
 	}
 }
 
+func TestExtractJoinedTable(t *testing.T) {
+	testcases := []struct {
+		name  string
+		input string
+		pos   token.Pos
+		want  *TableInfo
+	}{
+		{
+			name:  "aliased join",
+			input: "select * from city as c left join country as co on ",
+			pos:   token.Pos{Line: 0, Col: 51},
+			want: &TableInfo{
+				Name:  "country",
+				Alias: "co",
+			},
+		},
+		{
+			name:  "last join before position",
+			input: "select * from city c join country co on co.Code = c.CountryCode join countrylanguage on  where 1 = 1",
+			pos:   token.Pos{Line: 0, Col: 88},
+			want: &TableInfo{
+				Name: "countrylanguage",
+			},
+		},
+		{
+			name:  "join after position",
+			input: "select * from city c join country co on ",
+			pos:   token.Pos{Line: 0, Col: 14},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := initExtractTable(t, tt.input)
+			got, err := ExtractJoinedTable(stmt, tt.pos)
+			if err != nil {
+				t.Fatalf("error: %+v", err)
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("unmatched value: %s", d)
+			}
+		})
+	}
+}
+
 func initExtractTable(t *testing.T, input string) ast.TokenList {
 	t.Helper()
 
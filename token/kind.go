@@ -68,6 +68,8 @@ const (
 	LBrace
 	// Right brace `}`
 	RBrace
+	// Bind parameter placeholder i.e: ?, $1, :name, @name
+	Placeholder
 	// ILLEGAL sqltoken
 	ILLEGAL
 )
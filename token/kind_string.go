@@ -40,12 +40,13 @@ func _() {
 	_ = x[Ampersand-29]
 	_ = x[LBrace-30]
 	_ = x[RBrace-31]
-	_ = x[ILLEGAL-32]
+	_ = x[Placeholder-32]
+	_ = x[ILLEGAL-33]
 }
 
-const _Kind_name = "SQLKeywordNumberCharSingleQuotedStringNationalStringLiteralCommaWhitespaceCommentEqNeqLtGtLtEqGtEqPlusMinusMultDivCaretModLParenRParenPeriodColonDoubleColonSemicolonBackslashLBracketRBracketAmpersandLBraceRBraceILLEGAL"
+const _Kind_name = "SQLKeywordNumberCharSingleQuotedStringNationalStringLiteralCommaWhitespaceCommentEqNeqLtGtLtEqGtEqPlusMinusMultDivCaretModLParenRParenPeriodColonDoubleColonSemicolonBackslashLBracketRBracketAmpersandLBraceRBracePlaceholderILLEGAL"
 
-var _Kind_index = [...]uint8{0, 10, 16, 20, 38, 59, 64, 74, 81, 83, 86, 88, 90, 94, 98, 102, 107, 111, 114, 119, 122, 128, 134, 140, 145, 156, 165, 174, 182, 190, 199, 205, 211, 218}
+var _Kind_index = [...]uint8{0, 10, 16, 20, 38, 59, 64, 74, 81, 83, 86, 88, 90, 94, 98, 102, 107, 111, 114, 119, 122, 128, 134, 140, 145, 156, 165, 174, 182, 190, 199, 205, 211, 222, 229}
 
 func (i Kind) String() string {
 	if i < 0 || i >= Kind(len(_Kind_index)-1) {
@@ -203,6 +203,23 @@ func (t *Tokenizer) next() (Kind, interface{}, error) {
 		v := MakeKeyword(s, 0)
 		return SQLKeyword, v, nil
 
+	case t.Dialect.IsPlaceHolderStart(r):
+		t.Scanner.Next()
+		n := t.Scanner.Peek()
+		if t.isPlaceHolder(r, n) {
+			s := t.tokenizePlaceHolder(r)
+			return Placeholder, s, nil
+		}
+		switch {
+		case r == ':':
+			return t.tokenizeColon()
+		case t.Dialect.IsIdentifierStart(r):
+			s := t.tokenizeWord(r)
+			return SQLKeyword, MakeKeyword(s, 0), nil
+		}
+		t.Col += 1
+		return Char, string(r), nil
+
 	case t.Dialect.IsIdentifierStart(r):
 		t.Scanner.Next()
 		s := t.tokenizeWord(r)
@@ -343,14 +360,7 @@ func (t *Tokenizer) next() (Kind, interface{}, error) {
 		}
 	case r == ':':
 		t.Scanner.Next()
-		n := t.Scanner.Peek()
-		if n == ':' {
-			t.Scanner.Next()
-			t.Col += 2
-			return DoubleColon, "::", nil
-		}
-		t.Col += 1
-		return Colon, ":", nil
+		return t.tokenizeColon()
 	case r == ';':
 		t.Scanner.Next()
 		t.Col += 1
@@ -405,6 +415,47 @@ func (t *Tokenizer) tokenizeWord(f rune) string {
 	return string(str)
 }
 
+// isPlaceHolder reports whether the start rune r followed by n begins a
+// placeholder. ? may stand alone, :name and @name must start with a letter,
+// others like $1 need at least one part.
+func (t *Tokenizer) isPlaceHolder(r, n rune) bool {
+	switch r {
+	case '?':
+		return true
+	case ':', '@':
+		return (n >= 'a' && n <= 'z') || (n >= 'A' && n <= 'Z') || n == '_'
+	}
+	return t.Dialect.IsPlaceHolderPart(n)
+}
+
+func (t *Tokenizer) tokenizePlaceHolder(f rune) string {
+	var str []rune
+	str = append(str, f)
+
+	for {
+		r := t.Scanner.Peek()
+		if t.Dialect.IsPlaceHolderPart(r) {
+			t.Scanner.Next()
+			str = append(str, r)
+		} else {
+			break
+		}
+	}
+	t.Col += len(str)
+	return string(str)
+}
+
+// tokenizeColon tokenizes : or :: once the first colon is read.
+func (t *Tokenizer) tokenizeColon() (Kind, interface{}, error) {
+	if t.Scanner.Peek() == ':' {
+		t.Scanner.Next()
+		t.Col += 2
+		return DoubleColon, "::", nil
+	}
+	t.Col += 1
+	return Colon, ":", nil
+}
+
 func (t *Tokenizer) tokenizeSingleQuotedString() string {
 	var str []rune
 	t.Scanner.Next()
@@ -17,6 +17,8 @@ func TestTokenizer_Tokenize(t *testing.T) {
 		name string
 		in   string
 		out  []*Token
+		// dialect is the generic one when nil
+		dialect dialect.Dialect
 	}{
 		{
 			name: "whitespace",
@@ -655,6 +657,84 @@ comment */`,
 				},
 			},
 		},
+		{
+			name:    "placeholders",
+			in:      "? ?1 $1 :user_id @name @@version",
+			dialect: &dialect.SQLite3Dialect{},
+			out: []*Token{
+				{
+					Kind:  Placeholder,
+					Value: "?",
+					From:  Pos{Line: 0, Col: 0},
+					To:    Pos{Line: 0, Col: 1},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 0, Col: 1},
+					To:    Pos{Line: 0, Col: 2},
+				},
+				{
+					Kind:  Placeholder,
+					Value: "?1",
+					From:  Pos{Line: 0, Col: 2},
+					To:    Pos{Line: 0, Col: 4},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 0, Col: 4},
+					To:    Pos{Line: 0, Col: 5},
+				},
+				{
+					Kind:  Placeholder,
+					Value: "$1",
+					From:  Pos{Line: 0, Col: 5},
+					To:    Pos{Line: 0, Col: 7},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 0, Col: 7},
+					To:    Pos{Line: 0, Col: 8},
+				},
+				{
+					Kind:  Placeholder,
+					Value: ":user_id",
+					From:  Pos{Line: 0, Col: 8},
+					To:    Pos{Line: 0, Col: 16},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 0, Col: 16},
+					To:    Pos{Line: 0, Col: 17},
+				},
+				{
+					Kind:  Placeholder,
+					Value: "@name",
+					From:  Pos{Line: 0, Col: 17},
+					To:    Pos{Line: 0, Col: 22},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 0, Col: 22},
+					To:    Pos{Line: 0, Col: 23},
+				},
+				{
+					Kind: SQLKeyword,
+					Value: &SQLWord{
+						Value:      "@@version",
+						QuoteStyle: 0,
+						Keyword:    "@@VERSION",
+						Kind:       dialect.Unmatched,
+					},
+					From: Pos{Line: 0, Col: 23},
+					To:   Pos{Line: 0, Col: 32},
+				},
+			},
+		},
 		{
 			name: "others",
 			in:   "\\[{&}]",
@@ -702,7 +782,11 @@ comment */`,
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			src := strings.NewReader(c.in)
-			tokenizer := NewTokenizer(src, &dialect.GenericSQLDialect{})
+			var d dialect.Dialect = &dialect.GenericSQLDialect{}
+			if c.dialect != nil {
+				d = c.dialect
+			}
+			tokenizer := NewTokenizer(src, d)
 
 			tok, err := tokenizer.Tokenize()
 			if err != nil {
@@ -876,3 +960,62 @@ test
 		}
 	})
 }
+
+func TestTokenizer_DatabasePlaceholders(t *testing.T) {
+	src := "? ?1 $1 :id @id a::int x := 1 b ? 'k'"
+	cases := []struct {
+		driver dialect.DatabaseDriver
+		want   []string
+		// the colons of :id, the cast and the assignment
+		wantColons int
+	}{
+		{dialect.DatabaseDriverSQLite3, []string{"?", "?1", "$1", ":id", "@id", "?"}, 2},
+		{dialect.DatabaseDriverMySQL, []string{"?", "?", "?"}, 3},
+		{dialect.DatabaseDriverPostgreSQL, []string{"$1"}, 3},
+	}
+	for _, c := range cases {
+		t.Run(string(c.driver), func(t *testing.T) {
+			tokenizer := NewTokenizer(bytes.NewBufferString(src), dialect.DatabaseDialect(c.driver))
+			toks, err := tokenizer.Tokenize()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			colons := 0
+			for _, tok := range toks {
+				switch tok.Kind {
+				case Placeholder:
+					got = append(got, tok.Value.(string))
+				case DoubleColon, Colon:
+					colons++
+				}
+			}
+			if d := cmp.Diff(c.want, got); d != "" {
+				t.Errorf("unmatch placeholders (- want, + got):\n%s", d)
+			}
+			if colons != c.wantColons {
+				t.Errorf("want %d colons, got %d", c.wantColons, colons)
+			}
+		})
+	}
+}
+
+func TestTokenizer_GenericNoPlaceholders(t *testing.T) {
+	tokenizer := NewTokenizer(bytes.NewBufferString("SELECT @id, :id FROM city"), &dialect.GenericSQLDialect{})
+	toks, err := tokenizer.Tokenize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	words := []string{}
+	for _, tok := range toks {
+		switch tok.Kind {
+		case Placeholder:
+			t.Errorf("the generic dialect must not tokenize placeholders, got %q", tok.Value)
+		case SQLKeyword:
+			words = append(words, tok.Value.(*SQLWord).String())
+		}
+	}
+	if d := cmp.Diff([]string{"SELECT", "@id", "id", "FROM", "city"}, words); d != "" {
+		t.Errorf("unmatch words (- want, + got):\n%s", d)
+	}
+}